@@ -0,0 +1,385 @@
+package apps
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	JC "jxwatcher/core"
+	JT "jxwatcher/types"
+	JW "jxwatcher/widgets"
+)
+
+var ledgerTypeOptions = []string{
+	JC.LEDGER_BUY,
+	JC.LEDGER_SELL,
+	JC.LEDGER_TRANSFER_IN,
+	JC.LEDGER_TRANSFER_OUT,
+}
+
+var ledgerMethodOptions = []string{
+	JC.LEDGER_METHOD_FIFO,
+	JC.LEDGER_METHOD_LIFO,
+	JC.LEDGER_METHOD_AVERAGE,
+}
+
+func NewLedgerForm(
+	onSave func(),
+	onRender func(layer *fyne.Container),
+	onDestroy func(layer *fyne.Container),
+) JW.DialogForm {
+
+	JC.PrintPerfStats("Opening ledger form", time.Now())
+
+	var allowValidation bool = false
+
+	validateNumber := func(s string, required bool) error {
+		if !allowValidation {
+			return nil
+		}
+		if len(s) == 0 {
+			if required {
+				return errors.New(JC.Translate("This field is required"))
+			}
+			return nil
+		}
+		value, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return errors.New(JC.Translate("Invalid number"))
+		}
+		if required && value <= 0 {
+			return errors.New(JC.Translate("Must larger than zero"))
+		}
+		if value < 0 {
+			return errors.New(JC.Translate("Must not be negative"))
+		}
+		return nil
+	}
+
+	validateCoin := func(s string, other string) error {
+		if !allowValidation {
+			return nil
+		}
+		if len(s) == 0 {
+			return errors.New(JC.Translate("Please select a cryptocurrency"))
+		}
+		id, err := strconv.ParseInt(JT.UsePanelMaps().GetIdByDisplay(s), 10, 64)
+		if err != nil || !JT.UsePanelMaps().ValidateId(id) {
+			return errors.New(JC.Translate("Invalid cryptocurrency selected"))
+		}
+		if s == other {
+			return errors.New(JC.Translate("Coin and quote must different"))
+		}
+		return nil
+	}
+
+	validateDate := func(s string) error {
+		if !allowValidation {
+			return nil
+		}
+		ts, err := time.ParseInLocation("2006-01-02", s, time.Local)
+		if err != nil {
+			return errors.New(JC.Translate("Use YYYY-MM-DD"))
+		}
+		if ts.After(time.Now()) {
+			return errors.New(JC.Translate("Cannot be in the future"))
+		}
+		return nil
+	}
+
+	var parent JW.DialogForm
+
+	entries := JT.UseLedger().GetEntries()
+	buttons := []JW.ActionButton{}
+
+	cm := JT.UsePanelMaps().GetOptions()
+	cs := JT.UsePanelMaps().GetMaps().GetSearchMap()
+
+	pce := container.NewStack()
+	pqe := container.NewStack()
+	pop := []*fyne.Container{pce, pqe}
+
+	me := widget.NewSelect(ledgerMethodOptions, nil)
+	me.SetSelected(JT.UseConfig().GetLedgerMethod())
+
+	ke := widget.NewSelect(ledgerTypeOptions, nil)
+	ke.SetSelectedIndex(0)
+
+	dte := JW.NewTextEntry()
+	ce := JW.NewCompletionEntry(cm, cs, pce)
+	qe := JW.NewCompletionEntry(cm, cs, pqe)
+	ne := JW.NewNumericalEntry(true)
+	pe := JW.NewNumericalEntry(true)
+	fe := JW.NewNumericalEntry(true)
+	oe := JW.NewTextEntry()
+
+	dte.SetPlaceHolder("YYYY-MM-DD")
+	dte.SetDefaultValue(time.Now().Format("2006-01-02"))
+	pe.SetPlaceHolder(JC.Translate("Price per coin in quote"))
+	fe.SetPlaceHolder(JC.Translate("Fee in quote, optional"))
+	oe.SetPlaceHolder(JC.Translate("Note, optional"))
+
+	dte.Validator = validateDate
+	ce.Validator = func(s string) error {
+		return validateCoin(s, qe.Text)
+	}
+	qe.Validator = func(s string) error {
+		return validateCoin(s, ce.Text)
+	}
+	ne.Validator = func(s string) error {
+		return validateNumber(s, true)
+	}
+	pe.Validator = func(s string) error {
+		return validateNumber(s, ke.Selected == JC.LEDGER_BUY || ke.Selected == JC.LEDGER_SELL)
+	}
+	fe.Validator = func(s string) error {
+		return validateNumber(s, false)
+	}
+
+	entriesBox := container.NewVBox()
+	summaryBox := container.NewVBox()
+
+	var renderEntries func()
+
+	renderSummary := func() {
+		summaryBox.RemoveAll()
+
+		positions := JT.ComputeLedger(entries, me.Selected, JT.UseLedger().GetRate)
+		if len(positions) == 0 {
+			summaryBox.Add(widget.NewLabel(JC.Translate("No positions yet")))
+			return
+		}
+
+		for _, lp := range positions {
+			summaryBox.Add(widget.NewLabel(lp.Format()))
+		}
+	}
+
+	renderEntries = func() {
+		for _, btn := range buttons {
+			btn.Destroy()
+		}
+
+		buttons = []JW.ActionButton{}
+		entriesBox.RemoveAll()
+
+		for i, entry := range entries {
+			idx := i
+			remove := JW.NewActionButton(
+				"remove_ledger_entry",
+				JC.STRING_EMPTY,
+				theme.DeleteIcon(),
+				JC.Translate("Remove Entry"),
+				JW.ActionStateNormal,
+				func(JW.ActionButton) {
+					entries = append(entries[:idx], entries[idx+1:]...)
+					renderEntries()
+					renderSummary()
+					parent.Refresh()
+				},
+				nil,
+			)
+
+			buttons = append(buttons, remove)
+
+			label := widget.NewLabel(entry.Format())
+			label.Truncation = fyne.TextTruncateEllipsis
+
+			entriesBox.Add(container.NewBorder(nil, nil, nil, remove, label))
+		}
+	}
+
+	me.OnChanged = func(string) {
+		renderSummary()
+		if parent != nil {
+			parent.Refresh()
+		}
+	}
+
+	addBtn := JW.NewActionButton(
+		"add_ledger_entry",
+		JC.Translate("Add Entry"),
+		theme.ContentAddIcon(),
+		JC.Translate("Add entry to the ledger"),
+		JW.ActionStateNormal,
+		func(JW.ActionButton) {
+			allowValidation = true
+			defer func() { allowValidation = false }()
+
+			hasError := false
+			for _, err := range []error{
+				dte.Validate(), ce.Validate(), qe.Validate(), ne.Validate(), pe.Validate(), fe.Validate(),
+			} {
+				if err != nil {
+					hasError = true
+				}
+			}
+
+			if hasError {
+				parent.Refresh()
+				return
+			}
+
+			cid := JT.UsePanelMaps().GetIdByDisplay(ce.Text)
+			qid := JT.UsePanelMaps().GetIdByDisplay(qe.Text)
+
+			entry := JT.NewLedgerEntry()
+			entry.Date = dte.Text
+			entry.Type = ke.Selected
+			entry.Coin, _ = strconv.ParseInt(cid, 10, 64)
+			entry.CoinSymbol = JT.UsePanelMaps().GetSymbolById(cid)
+			entry.Quote, _ = strconv.ParseInt(qid, 10, 64)
+			entry.QuoteSymbol = JT.UsePanelMaps().GetSymbolById(qid)
+			entry.Quantity = ne.GetFloat()
+			entry.Price = pe.GetFloat()
+			entry.Fee = fe.GetFloat()
+			entry.Note = strings.TrimSpace(oe.Text)
+
+			entries = append(entries, *entry)
+
+			ne.SetText(JC.STRING_EMPTY)
+			pe.SetText(JC.STRING_EMPTY)
+			fe.SetText(JC.STRING_EMPTY)
+			oe.SetText(JC.STRING_EMPTY)
+
+			renderEntries()
+			renderSummary()
+			parent.Refresh()
+		},
+		nil,
+	)
+
+	csvFilter := storage.NewExtensionFileFilter([]string{".csv"})
+
+	importBtn := JW.NewActionButton(
+		"import_ledger_csv",
+		JC.Translate("Import CSV"),
+		theme.FolderOpenIcon(),
+		JC.Translate("Import ledger entries from CSV"),
+		JW.ActionStateNormal,
+		func(JW.ActionButton) {
+			fd := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+				if err != nil || reader == nil {
+					return
+				}
+				defer reader.Close()
+
+				imported, err := JT.ParseLedgerCSV(reader)
+				if err != nil {
+					JC.Logln("Failed to import ledger csv:", err)
+					JC.Notify(JC.NotifyUnableToImportLedgerEntries)
+					return
+				}
+
+				entries = append(entries, imported...)
+
+				renderEntries()
+				renderSummary()
+				parent.Refresh()
+
+				JC.Logln("Imported ledger entries:", len(imported))
+				JC.Notify(JC.NotifyLedgerEntriesImported)
+			}, JC.Window)
+
+			fd.SetFilter(csvFilter)
+			fd.Show()
+		},
+		nil,
+	)
+
+	exportBtn := JW.NewActionButton(
+		"export_ledger_csv",
+		JC.Translate("Export CSV"),
+		theme.DocumentSaveIcon(),
+		JC.Translate("Export ledger entries to CSV"),
+		JW.ActionStateNormal,
+		func(JW.ActionButton) {
+			fd := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+				if err != nil || writer == nil {
+					return
+				}
+				defer writer.Close()
+
+				if err := JT.WriteLedgerCSV(writer, entries); err != nil {
+					JC.Logln("Failed to export ledger csv:", err)
+					JC.Notify(JC.NotifyUnableToExportLedgerEntries)
+					return
+				}
+
+				JC.Notify(JC.NotifyLedgerExportedSuccessfully)
+			}, JC.Window)
+
+			fd.SetFileName("ledger.csv")
+			fd.SetFilter(csvFilter)
+			fd.Show()
+		},
+		nil,
+	)
+
+	renderEntries()
+	renderSummary()
+
+	fi := []*widget.FormItem{
+		widget.NewFormItem(JC.Translate("Lot Method"), me),
+		widget.NewFormItem(JC.Translate("Entries"), entriesBox),
+		widget.NewFormItem(JC.Translate("Type"), ke),
+		widget.NewFormItem(JC.Translate("Date"), dte),
+		widget.NewFormItem(JC.Translate("Coin"), ce),
+		widget.NewFormItem(JC.Translate("Quote"), qe),
+		widget.NewFormItem(JC.Translate("Quantity"), ne),
+		widget.NewFormItem(JC.Translate("Price"), pe),
+		widget.NewFormItem(JC.Translate("Fee"), fe),
+		widget.NewFormItem(JC.Translate("Note"), oe),
+		widget.NewFormItem(JC.STRING_EMPTY, container.NewHBox(addBtn)),
+		widget.NewFormItem(JC.Translate("Profit & Loss"), summaryBox),
+	}
+
+	csvBox := container.NewHBox(importBtn, exportBtn)
+
+	parent = JW.NewDialogForm(JC.Translate("Ledger"), fi, []*fyne.Container{csvBox}, nil, pop, nil,
+		func() bool {
+			JT.UseLedger().SetEntries(entries)
+			JT.UseConfig().LedgerMethod = me.Selected
+
+			if onSave != nil {
+				onSave()
+			}
+
+			return true
+		},
+		onRender,
+		func(layer *fyne.Container) {
+			for _, btn := range buttons {
+				btn.Destroy()
+			}
+
+			addBtn.Destroy()
+			importBtn.Destroy()
+			exportBtn.Destroy()
+
+			ce.Destroy()
+			qe.Destroy()
+
+			if !JC.IsMobile {
+				JT.UsePanelMaps().GetMaps().ClearMapCache()
+			}
+
+			if onDestroy != nil {
+				onDestroy(layer)
+			}
+		},
+		JC.Window)
+
+	ce.SetParent(parent)
+	qe.SetParent(parent)
+
+	return parent
+}
@@ -227,21 +227,21 @@ func NewAppLayout() fyne.CanvasObject {
 	manager := UseLayout()
 	manager.topBar = NewTopBar()
 
-	manager.loading = NewAppPage(nil, "Loading...", nil)
-	manager.error = NewAppPage(nil, "Failed to start application...", nil)
+	manager.loading = NewAppPage(nil, JC.Translate("Loading..."), nil)
+	manager.error = NewAppPage(nil, JC.Translate("Failed to start application..."), nil)
 
 	contentIcon := theme.ContentAddIcon()
-	manager.actionAddPanel = NewAppPage(&contentIcon, "Add Panel", func() {
+	manager.actionAddPanel = NewAppPage(&contentIcon, JC.Translate("Add Panel"), func() {
 		UseAction().Call(JC.ACT_PANEL_ADD)
 	})
 
 	settingIcon := theme.SettingsIcon()
-	manager.actionFixSetting = NewAppPage(&settingIcon, "Open Settings", func() {
+	manager.actionFixSetting = NewAppPage(&settingIcon, JC.Translate("Open Settings"), func() {
 		UseAction().Call(JC.ACT_OPEN_SETTINGS)
 	})
 
 	restoreIcon := theme.ViewRestoreIcon()
-	manager.actionGetCryptos = NewAppPage(&restoreIcon, "Fetch Crypto Data", func() {
+	manager.actionGetCryptos = NewAppPage(&restoreIcon, JC.Translate("Fetch Crypto Data"), func() {
 		UseAction().Call(JC.ACT_CRYPTO_REFRESH_MAP)
 	})
 
@@ -347,15 +347,17 @@ func (a *statusManager) Refresh() *statusManager {
 	now := time.Now().UnixNano()
 	a.lastRefresh.Store(now)
 
-	fyne.Do(func() {
-		UseLayout().UpdateState()
-	})
-
-	JC.UseDebouncer().Call("refreshing_main_layout", 60*time.Millisecond, func() {
+	if !JC.IsHeadless {
 		fyne.Do(func() {
-			UseAction().Refresh()
+			UseLayout().UpdateState()
+		})
+
+		JC.UseDebouncer().Call("refreshing_main_layout", 60*time.Millisecond, func() {
+			fyne.Do(func() {
+				UseAction().Refresh()
+			})
 		})
-	})
+	}
 
 	if !a.IsReady() || a.HasError() {
 		JC.Logf("Application Status: Ready: %v | NoPanels: %v|%d | BadConfig: %v | BadCryptos: %v | BadTickers: %v | LastChange: %d | LastRefresh: %d", a.IsReady(), a.IsValidPanels(), a.PanelsCount(), !a.IsValidConfig(), !a.IsValidCrypto(), a.bad_tickers.Load(), a.lastChange.Load(), a.lastRefresh.Load())
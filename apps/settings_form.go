@@ -25,14 +25,14 @@ func NewSettingsForm(
 			return nil
 		}
 		if s == JC.STRING_EMPTY {
-			return errors.New("This field is required")
+			return errors.New(JC.Translate("This field is required"))
 		}
 		u, err := url.ParseRequestURI(s)
 		if err != nil {
-			return errors.New("Invalid URL format")
+			return errors.New(JC.Translate("Invalid URL format"))
 		}
 		if u.Scheme != "http" && u.Scheme != "https" {
-			return errors.New("Only http or https allowed")
+			return errors.New(JC.Translate("Only http or https allowed"))
 		}
 
 		return nil
@@ -43,14 +43,58 @@ func NewSettingsForm(
 			return nil
 		}
 		if s == JC.STRING_EMPTY {
-			return errors.New("This field is required")
+			return errors.New(JC.Translate("This field is required"))
 		}
 		val, err := strconv.ParseInt(s, 10, 64)
 		if err != nil {
-			return errors.New("No decimals allowed")
+			return errors.New(JC.Translate("No decimals allowed"))
 		}
 		if val < 0 {
-			return errors.New("Must larger than zero")
+			return errors.New(JC.Translate("Must larger than zero"))
+		}
+		return nil
+	}
+
+	validateLimit := func(s string) error {
+		if !allowValidation || s == JC.STRING_EMPTY {
+			return nil
+		}
+		val, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return errors.New(JC.Translate("No decimals allowed"))
+		}
+		if val < 0 {
+			return errors.New(JC.Translate("Must larger than zero"))
+		}
+		return nil
+	}
+
+	validateProxy := func(s string) error {
+		if !allowValidation || s == JC.STRING_EMPTY {
+			return nil
+		}
+		if _, err := JC.ParseProxyURL(s, JC.STRING_EMPTY, JC.STRING_EMPTY); err != nil {
+			return errors.New(JC.Translate("Only http, https or socks5 proxy allowed"))
+		}
+		return nil
+	}
+
+	validateCABundle := func(s string) error {
+		if !allowValidation || s == JC.STRING_EMPTY {
+			return nil
+		}
+		if _, err := JC.LoadCABundle(JT.ExpandConfigPath(s)); err != nil {
+			return errors.New(JC.Translate("No certificates found in file"))
+		}
+		return nil
+	}
+
+	validatePinnedKeys := func(s string) error {
+		if !allowValidation {
+			return nil
+		}
+		if _, err := JT.ParsePinnedKeys(s); err != nil {
+			return errors.New(JC.Translate("Use one host and sha256 key per line"))
 		}
 		return nil
 	}
@@ -65,7 +109,20 @@ func NewSettingsForm(
 	rsi := JW.NewTextEntry()
 	etf := JW.NewTextEntry()
 	dominance := JW.NewTextEntry()
-	authkey := JW.NewTextEntry()
+	authkey := JW.NewPasswordEntry()
+	passphrase := JW.NewPasswordEntry()
+	proxy := JW.NewTextEntry()
+	proxyUsername := JW.NewTextEntry()
+	proxyPassword := JW.NewPasswordEntry()
+	caBundle := JW.NewTextEntry()
+	pinnedKeys := widget.NewMultiLineEntry()
+	quotaPerMinute := JW.NewNumericalEntry(false)
+	quotaDaily := JW.NewNumericalEntry(false)
+	quotaMonthly := JW.NewNumericalEntry(false)
+	quotaUsage := widget.NewLabel(JC.STRING_EMPTY)
+	fiat := widget.NewSelect(JC.GetFiatCodes(), nil)
+	locale := widget.NewSelect(nil, nil)
+	themeMode := widget.NewSelect(nil, nil)
 
 	delay.SetDefaultValue(strconv.FormatInt(JT.UseConfig().Delay, 10))
 	cryptos.SetText(JT.UseConfig().DataEndpoint)
@@ -77,7 +134,45 @@ func NewSettingsForm(
 	rsi.SetText(JT.UseConfig().RSIEndpoint)
 	etf.SetText(JT.UseConfig().ETFEndpoint)
 	dominance.SetText(JT.UseConfig().DominanceEndpoint)
-	authkey.SetText(JT.UseConfig().AuthKey)
+	authkey.SetText(JT.UseAuthKey().Get())
+	proxy.SetText(JT.UseConfig().Proxy)
+	proxyUsername.SetText(JT.UseConfig().ProxyUsername)
+	proxyPassword.SetText(JT.UseConfig().ProxyPassword)
+	caBundle.SetText(JT.UseConfig().CABundle)
+	pinnedKeys.SetText(JT.FormatPinnedKeys(JT.UseConfig().PinnedKeys))
+	quotaPerMinute.SetDefaultValue(strconv.FormatInt(JT.UseConfig().QuotaPerMinute, 10))
+	quotaDaily.SetDefaultValue(strconv.FormatInt(JT.UseConfig().QuotaDaily, 10))
+	quotaMonthly.SetDefaultValue(strconv.FormatInt(JT.UseConfig().QuotaMonthly, 10))
+	if JC.UseQuota() != nil {
+		quotaUsage.SetText(JC.UseQuota().GetUsage().Format())
+	}
+	fiat.SetSelected(JT.UseConfig().GetDisplayFiat().Code)
+
+	proxy.SetPlaceHolder("socks5://127.0.0.1:1080")
+	caBundle.SetPlaceHolder(JC.Translate("Optional, PEM file with extra certificates"))
+	pinnedKeys.SetPlaceHolder("pro-api.coinmarketcap.com sha256/...")
+
+	// Language names are shown in their own language, the empty code follows the system
+	localeCodes := []string{JC.STRING_EMPTY, JC.LOCALE_ENGLISH, JC.LOCALE_GERMAN, JC.LOCALE_INDONESIAN}
+	localeNames := []string{JC.Translate("System"), "English", "Deutsch", "Bahasa Indonesia"}
+	locale.SetOptions(localeNames)
+	for i, code := range localeCodes {
+		if code == JT.UseConfig().GetLocale() {
+			locale.SetSelectedIndex(i)
+		}
+	}
+
+	passphrase.SetPlaceHolder(JC.Translate("Optional, encrypts the key"))
+
+	switch {
+	case JT.UseAuthKey().GetSource() == JC.AUTH_KEY_SOURCE_ENV:
+		authkey.SetPlaceHolder(JC.Translatef("Set by %s", JC.AUTH_KEY_ENV))
+		authkey.SetText(JC.STRING_EMPTY)
+		authkey.Disable()
+		passphrase.Disable()
+	case JT.UseAuthKey().IsLocked():
+		authkey.SetPlaceHolder(JC.Translate("Vault is locked"))
+	}
 
 	delay.Validator = validateDelay
 	cryptos.Validator = validateURL
@@ -89,22 +184,65 @@ func NewSettingsForm(
 	rsi.Validator = validateURL
 	etf.Validator = validateURL
 	dominance.Validator = validateURL
+	proxy.Validator = validateProxy
+	caBundle.Validator = validateCABundle
+	pinnedKeys.Validator = validatePinnedKeys
+	quotaPerMinute.Validator = validateLimit
+	quotaDaily.Validator = validateLimit
+	quotaMonthly.Validator = validateLimit
+
+	themeModes := []string{JC.THEME_SYSTEM, JC.THEME_LIGHT, JC.THEME_DARK}
+	themeMode.SetOptions([]string{JC.Translate("System"), JC.Translate("Light"), JC.Translate("Dark")})
+	for i, mode := range themeModes {
+		if mode == JT.UseConfig().GetTheme() {
+			themeMode.SetSelectedIndex(i)
+		}
+	}
+
+	tickerSettings := JT.GetTickerSettings()
+	tickerTitles := []string{}
+	tickerVisible := []string{}
+	for _, ticker := range tickerSettings {
+		title := JC.Translate(ticker.Title)
+		tickerTitles = append(tickerTitles, title)
+		if !ticker.Hidden {
+			tickerVisible = append(tickerVisible, title)
+		}
+	}
+
+	tickers := widget.NewCheckGroup(tickerTitles, nil)
+	tickers.Horizontal = true
+	tickers.SetSelected(tickerVisible)
 
 	items := []*widget.FormItem{
-		widget.NewFormItem("Crypto Maps Endpoint", cryptos),
-		widget.NewFormItem("Exchange Endpoint", exchange),
-		widget.NewFormItem("AltSeason Endpoint", altindex),
-		widget.NewFormItem("Fear & Greed Endpoint", feargreed),
-		widget.NewFormItem("CMC100 Endpoint", cmc100),
-		widget.NewFormItem("MarketCap Endpoint", marketcap),
-		widget.NewFormItem("RSI Endpoint", rsi),
-		widget.NewFormItem("ETF Endpoint", etf),
-		widget.NewFormItem("Dominance Endpoint", dominance),
-		widget.NewFormItem("Authorization Key", authkey),
-		widget.NewFormItem("Delay (sec)", delay),
+		widget.NewFormItem(JC.Translate("Crypto Maps Endpoint"), cryptos),
+		widget.NewFormItem(JC.Translate("Exchange Endpoint"), exchange),
+		widget.NewFormItem(JC.Translate("AltSeason Endpoint"), altindex),
+		widget.NewFormItem(JC.Translate("Fear & Greed Endpoint"), feargreed),
+		widget.NewFormItem(JC.Translate("CMC100 Endpoint"), cmc100),
+		widget.NewFormItem(JC.Translate("MarketCap Endpoint"), marketcap),
+		widget.NewFormItem(JC.Translate("RSI Endpoint"), rsi),
+		widget.NewFormItem(JC.Translate("ETF Endpoint"), etf),
+		widget.NewFormItem(JC.Translate("Dominance Endpoint"), dominance),
+		widget.NewFormItem(JC.Translate("Authorization Key"), authkey),
+		widget.NewFormItem(JC.Translate("Vault Passphrase"), passphrase),
+		widget.NewFormItem(JC.Translate("Proxy"), proxy),
+		widget.NewFormItem(JC.Translate("Proxy Username"), proxyUsername),
+		widget.NewFormItem(JC.Translate("Proxy Password"), proxyPassword),
+		widget.NewFormItem(JC.Translate("CA Bundle"), caBundle),
+		widget.NewFormItem(JC.Translate("Pinned Keys"), pinnedKeys),
+		widget.NewFormItem(JC.Translate("Requests per Minute"), quotaPerMinute),
+		widget.NewFormItem(JC.Translate("Daily Requests"), quotaDaily),
+		widget.NewFormItem(JC.Translate("Monthly Requests"), quotaMonthly),
+		widget.NewFormItem(JC.Translate("Request Quota"), quotaUsage),
+		widget.NewFormItem(JC.Translate("Delay (sec)"), delay),
+		widget.NewFormItem(JC.Translate("Display Currency"), fiat),
+		widget.NewFormItem(JC.Translate("Language"), locale),
+		widget.NewFormItem(JC.Translate("Theme"), themeMode),
+		widget.NewFormItem(JC.Translate("Visible Tickers"), tickers),
 	}
 
-	return JW.NewDialogForm("Settings", items, nil, nil, nil, nil,
+	return JW.NewDialogForm(JC.Translate("Settings"), items, nil, nil, nil, nil,
 		func() bool {
 			defer func() { allowValidation = false }()
 
@@ -141,6 +279,24 @@ func NewSettingsForm(
 			if delay.Validate() != nil {
 				hasError = true
 			}
+			if proxy.Validate() != nil {
+				hasError = true
+			}
+			if caBundle.Validate() != nil {
+				hasError = true
+			}
+			if pinnedKeys.Validate() != nil {
+				hasError = true
+			}
+			if quotaPerMinute.Validate() != nil {
+				hasError = true
+			}
+			if quotaDaily.Validate() != nil {
+				hasError = true
+			}
+			if quotaMonthly.Validate() != nil {
+				hasError = true
+			}
 
 			if hasError {
 				return false
@@ -157,7 +313,35 @@ func NewSettingsForm(
 			JT.UseConfig().RSIEndpoint = rsi.Text
 			JT.UseConfig().ETFEndpoint = etf.Text
 			JT.UseConfig().DominanceEndpoint = dominance.Text
-			JT.UseConfig().AuthKey = authkey.Text
+			JT.UseConfig().Proxy = proxy.Text
+			JT.UseConfig().ProxyUsername = proxyUsername.Text
+			JT.UseConfig().ProxyPassword = proxyPassword.Text
+			JT.UseConfig().CABundle = caBundle.Text
+			JT.UseConfig().PinnedKeys, _ = JT.ParsePinnedKeys(pinnedKeys.Text)
+			JT.UseConfig().QuotaPerMinute, _ = strconv.ParseInt(quotaPerMinute.Text, 10, 64)
+			JT.UseConfig().QuotaDaily, _ = strconv.ParseInt(quotaDaily.Text, 10, 64)
+			JT.UseConfig().QuotaMonthly, _ = strconv.ParseInt(quotaMonthly.Text, 10, 64)
+			if !authkey.Disabled() && (authkey.Text != JT.UseAuthKey().Get() || passphrase.Text != JC.STRING_EMPTY) {
+				if err := JT.UseAuthKey().Set(authkey.Text, passphrase.Text); err != nil {
+					JC.Logln("Failed to store auth key:", err)
+					JC.Notify(JC.NotifyFailedToStoreAuthKey)
+				}
+			}
+			JT.UseConfig().DisplayFiat = fiat.Selected
+			if index := locale.SelectedIndex(); index >= 0 {
+				JT.UseConfig().Locale = localeCodes[index]
+			}
+			if index := themeMode.SelectedIndex(); index >= 0 {
+				JT.UseConfig().Theme = themeModes[index]
+			}
+
+			shown := map[string]bool{}
+			for _, title := range tickers.Selected {
+				shown[title] = true
+			}
+			for _, ticker := range tickerSettings {
+				JT.SetTickerHidden(ticker.Type, !shown[JC.Translate(ticker.Title)])
+			}
 
 			if onSave != nil {
 				onSave()
@@ -0,0 +1,75 @@
+package apps
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	JC "jxwatcher/core"
+	JT "jxwatcher/types"
+	JW "jxwatcher/widgets"
+)
+
+func NewTickerBreakdown(
+	tickerType string,
+	onRender func(layer *fyne.Container),
+	onDestroy func(layer *fyne.Container),
+) JW.DialogForm {
+
+	JC.PrintPerfStats("Opening ticker breakdown", time.Now())
+
+	rows := JT.GetTickerBreakdownRows(tickerType, JT.TickerHistoryPoints)
+	if len(rows) == 0 {
+		return nil
+	}
+
+	sparklines := []*JW.Sparkline{}
+	fi := []*widget.FormItem{}
+
+	for _, row := range rows {
+		value := row.Value
+		if value == JC.STRING_EMPTY {
+			value = JC.Translate("No data yet")
+		}
+
+		updated := JC.Translate("Not fetched yet")
+		if !row.Timestamp.IsZero() {
+			updated = JC.Translatef("As of %s", row.Timestamp.Local().Format("2006-01-02 15:04:05"))
+		}
+
+		vl := widget.NewLabelWithStyle(value, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		tl := widget.NewLabel(updated)
+		tl.Truncation = fyne.TextTruncateEllipsis
+
+		sl := JW.NewSparkline(JC.UseTheme().GetColor(JC.ColorNamePanelSparkline), JC.UseTheme().Size(JC.SizePanelSparklineStroke))
+		sl.SetValues(row.History)
+		sparklines = append(sparklines, sl)
+
+		// The sparkline has no minimum size of its own
+		spacer := canvas.NewRectangle(nil)
+		spacer.SetMinSize(fyne.NewSize(0, JC.UseTheme().Size(JC.SizePanelSparkline)))
+
+		item := widget.NewFormItem(row.Title, container.NewVBox(
+			container.NewBorder(nil, nil, vl, nil, tl),
+			container.NewStack(spacer, sl),
+		))
+
+		fi = append(fi, item)
+	}
+
+	return JW.NewDialogForm(rows[0].Title, fi, nil, nil, nil, nil, nil,
+		onRender,
+		func(layer *fyne.Container) {
+			for _, sl := range sparklines {
+				sl.Destroy()
+			}
+
+			if onDestroy != nil {
+				onDestroy(layer)
+			}
+		},
+		JC.Window)
+}
@@ -0,0 +1,60 @@
+package apps
+
+import (
+	"errors"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+
+	JC "jxwatcher/core"
+	JT "jxwatcher/types"
+	JW "jxwatcher/widgets"
+)
+
+func NewVaultForm(
+	onUnlock func(),
+	onRender func(layer *fyne.Container),
+	onDestroy func(layer *fyne.Container),
+) JW.DialogForm {
+
+	var allowValidation bool = false
+
+	passphrase := JW.NewPasswordEntry()
+	passphrase.Validator = func(s string) error {
+		if !allowValidation {
+			return nil
+		}
+		if s == JC.STRING_EMPTY {
+			return errors.New(JC.Translate("This field is required"))
+		}
+		if err := JT.UseAuthKey().Unlock(s); err != nil {
+			JC.Logln("Failed to unlock auth key vault:", err)
+			return errors.New(JC.Translate("Wrong passphrase"))
+		}
+		return nil
+	}
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(JC.Translate("Passphrase"), passphrase),
+	}
+
+	return JW.NewDialogForm(JC.Translate("Unlock Vault"), items, nil, nil, nil, nil,
+		func() bool {
+			defer func() { allowValidation = false }()
+
+			allowValidation = true
+
+			if passphrase.Validate() != nil {
+				return false
+			}
+
+			if onUnlock != nil {
+				onUnlock()
+			}
+
+			return true
+		},
+		onRender,
+		onDestroy,
+		JC.Window)
+}
@@ -24,11 +24,11 @@ const STRING_LESS_EQUAL = "<="
 const STRING_GREATER_EQUAL = ">="
 const STRING_ELLIPISIS = "..."
 
-const FMT_SHORT_TRILLION_DOLLAR = "$%.2fT"
-const FMT_SHORT_BILLION_DOLLAR = "$%.2fB"
-const FMT_SHORT_MILLION_DOLLAR = "$%.2fM"
-const FMT_SHORT_THOUSAND_DOLLAR = "$%.2fK"
-const FMT_SHORT_DOLLAR = "$%.2f"
+const FMT_SHORT_TRILLION = "%.2fT"
+const FMT_SHORT_BILLION = "%.2fB"
+const FMT_SHORT_MILLION = "%.2fM"
+const FMT_SHORT_THOUSAND = "%.2fK"
+const FMT_SHORT = "%.2f"
 
 const NETWORKING_SUCCESS = 0
 const NETWORKING_ERROR_CONNECTION = -1
@@ -42,6 +42,9 @@ const NETWORKING_FAILED_CREATE_FILE = -8
 const NETWORKING_ERROR_FIREWALL = -9
 const NETWORKING_NO_INTERNET = -10
 const NETWORKING_RATE_LIMIT = -11
+const NETWORKING_TLS_ERROR = -12
+const NETWORKING_PROXY_ERROR = -13
+const NETWORKING_QUOTA_EXCEEDED = -14
 
 const NETWORKING_MAXIMUM_CONNECTION = 14
 
@@ -69,9 +72,74 @@ const STATUS_NETWORK_ERROR = 1
 const STATUS_CONFIG_ERROR = 2
 const STATUS_BAD_DATA_RECEIVED = 3
 const STATUS_CANCELLED = 4
+const STATUS_SKIPPED = 5
 
 const WATCHER_DISABLED = -9999
 
+const WATCHER_LOGIC_AND = 0
+const WATCHER_LOGIC_OR = 1
+
+const WATCHER_METRIC_RATE = 0
+const WATCHER_METRIC_CHANGE_24H = 1
+const WATCHER_METRIC_CHANGE_SINCE_ALERT = 2
+const WATCHER_METRIC_CHANGE_WINDOW = 3
+
+const WATCHER_OPERATOR_EQUAL = 0
+const WATCHER_OPERATOR_LESS = 1
+const WATCHER_OPERATOR_GREATER = 2
+const WATCHER_OPERATOR_CROSS_ABOVE = 3
+const WATCHER_OPERATOR_CROSS_BELOW = 4
+const WATCHER_OPERATOR_MOVES_BY = 5
+
+const ALERT_SINK_DESKTOP = "desktop"
+const ALERT_SINK_WEBHOOK = "webhook"
+const ALERT_SINK_COMMAND = "command"
+const ALERT_SINK_LOG = "log"
+const ALERT_SINK_SMTP = "smtp"
+
+const API_HOST = "127.0.0.1"
+
+const RATE_PROVIDER_CMC = "coinmarketcap"
+const RATE_PROVIDER_COINGECKO = "coingecko"
+const RATE_PROVIDER_BINANCE = "binance"
+const RATE_PROVIDER_KRAKEN = "kraken"
+
+const LOCALE_ENGLISH = "en"
+const LOCALE_GERMAN = "de"
+const LOCALE_INDONESIAN = "id"
+
+const THEME_SYSTEM = "system"
+const THEME_LIGHT = "light"
+const THEME_DARK = "dark"
+
+const AUTH_KEY_ENV = "JXWATCHER_AUTH_KEY"
+const AUTH_VAULT_PASSPHRASE_ENV = "JXWATCHER_VAULT_PASSPHRASE"
+
+const AUTH_KEY_SOURCE_NONE = ""
+const AUTH_KEY_SOURCE_ENV = "env"
+const AUTH_KEY_SOURCE_FILE = "file"
+const AUTH_KEY_SOURCE_VAULT = "vault"
+
+const QUOTA_LOW_RESERVE = 10
+const QUOTA_DEFAULT_RETRY_AFTER = time.Minute
+const QUOTA_MAXIMUM_RETRY_AFTER = time.Hour
+
+const FIAT_USD = "USD"
+const FIAT_USD_ID = 2781
+
+const LEDGER_METHOD_FIFO = "fifo"
+const LEDGER_METHOD_LIFO = "lifo"
+const LEDGER_METHOD_AVERAGE = "average"
+
+const LEDGER_BUY = "buy"
+const LEDGER_SELL = "sell"
+const LEDGER_TRANSFER_IN = "transfer_in"
+const LEDGER_TRANSFER_OUT = "transfer_out"
+
+const COLOR_RULE_POSITIVE = "positive"
+const COLOR_RULE_NEGATIVE = "negative"
+const COLOR_RULE_ZERO = "zero"
+
 const POS_CENTER = 0
 const POS_LEFT = 1
 const POS_RIGHT = 2
@@ -95,6 +163,7 @@ const ColorNameDarkGrey fyne.ThemeColorName = "darkGrey"
 const ColorNamePanelBG fyne.ThemeColorName = "panelBG"
 const ColorNamePanelPlaceholder fyne.ThemeColorName = "panelPlaceholder"
 const ColorNameTickerBG fyne.ThemeColorName = "tickerBG"
+const ColorNamePanelSparkline fyne.ThemeColorName = "panelSparkline"
 
 const SizeLayoutPadding fyne.ThemeSizeName = "layoutPadding"
 const SizePanelBorderRadius fyne.ThemeSizeName = "panelBorderRadius"
@@ -108,6 +177,9 @@ const SizePanelBottomTextSmall fyne.ThemeSizeName = "panelBottomTextSmall"
 const SizePanelContentSmall fyne.ThemeSizeName = "panelContentSmall"
 const SizePanelWidth fyne.ThemeSizeName = "panelWidth"
 const SizePanelHeight fyne.ThemeSizeName = "panelHeight"
+const SizePanelSparkline fyne.ThemeSizeName = "panelSparkline"
+const SizePanelSparklineSmall fyne.ThemeSizeName = "panelSparklineSmall"
+const SizePanelSparklineStroke fyne.ThemeSizeName = "panelSparklineStroke"
 const SizeActionBtnWidth fyne.ThemeSizeName = "actionBtnWidth"
 const SizeActionBtnGap fyne.ThemeSizeName = "actionBtnGap"
 const SizeTickerBorderRadius fyne.ThemeSizeName = "tickerBorderRadius"
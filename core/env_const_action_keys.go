@@ -1,6 +1,7 @@
 package core
 
 const ACT_OPEN_SETTINGS = "open_settings"
+const ACT_OPEN_LEDGER = "open_ledger"
 
 const ACT_CRYPTO_GET_MAP = "crypto_get_map"
 const ACT_CRYPTO_REFRESH_MAP = "crypto_refresh_map"
@@ -22,9 +23,11 @@ const ACT_WATCHER_EDIT = "watcher_edit"
 
 const ACT_TICKER_TOGGLE = "tickers_toggle"
 const ACT_TICKER_UPDATE = "tickers_update"
+const ACT_TICKER_SCHEDULE = "tickers_schedule"
 
 const ACT_TICKER_GET_ALTSEASON = "ticker_get_alt_season"
 const ACT_TICKER_GET_CMC100 = "ticker_get_cmc100"
+const ACT_TICKER_GET_CUSTOM = "ticker_get_custom"
 const ACT_TICKER_GET_DOMINANCE = "ticker_get_dominance"
 const ACT_TICKER_GET_ETF = "ticker_get_etf"
 const ACT_TICKER_GET_FEARGREED = "ticker_get_feargreed"
@@ -2,6 +2,8 @@ package core
 
 /** Generated message constant */
 const NotifyApplicationIsStarting = "Application is starting..."
+const NotifyAuthKeyVaultUnlocked = "Authorization key vault unlocked."
+const NotifyConfigurationReloadedFromFile = "Configuration reloaded from file."
 const NotifyConfigurationSavedSuccessfully = "Configuration saved successfully."
 const NotifyCryptoMapRegeneratedSuccessfully = "Crypto map regenerated successfully"
 const NotifyExchangeFetchCompleted = "Exchange fetch completed."
@@ -10,23 +12,37 @@ const NotifyFailedToCreateCryptosDataFile = "Failed to create cryptos data file"
 const NotifyFailedToFetchCryptosData = "Failed to fetch cryptos data"
 const NotifyFailedToLoadCryptosData = "Failed to load cryptos data"
 const NotifyFailedToSaveConfiguration = "Failed to save configuration."
+const NotifyFailedToSaveLedger = "Failed to save ledger."
 const NotifyFailedToSavePanelSettings = "Failed to save panel settings."
+const NotifyFailedToStoreAuthKey = "Failed to store authorization key."
 const NotifyFetchingTheLatestExchangeRates = "Fetching the latest exchange rates..."
 const NotifyFetchingTheLatestTickerData = "Fetching the latest ticker data..."
 const NotifyInvalidConfigurationUnableToResetCryptos = "Invalid configuration. Unable to reset cryptos map."
+const NotifyInvalidNetworkSettings = "Invalid network settings, keeping the previous connection."
+const NotifyLedgerEntriesImported = "Ledger entries imported."
+const NotifyLedgerExportedSuccessfully = "Ledger exported successfully."
+const NotifyLedgerSavedSuccessfully = "Ledger saved successfully."
 const NotifyNewPanelCreated = "New panel created."
 const NotifyPanelDisplayRefreshedWithLatestRates = "Panel display refreshed with latest rates"
 const NotifyPanelRemovedSuccessfully = "Panel removed successfully."
 const NotifyPanelSettingsSaved = "Panel settings saved."
 const NotifyPanelsHaveBeenReorderedAndUpdated = "Panels have been reordered and updated."
+const NotifyPanelsReloadedFromFile = "Panels reloaded from file."
 const NotifyPleaseCheckYourNetworkConnection = "Please check your network connection."
 const NotifyPleaseCheckYourSettings = "Please check your settings."
+const NotifyRequestQuotaIsRunningLow = "Request quota is running low, tickers are paused."
 const NotifyRequestingLatestCryptosDataFromExchange = "Requesting latest cryptos data from exchange..."
 const NotifySavingConfiguration = "Saving configuration..."
+const NotifySavingLedger = "Saving ledger..."
 const NotifySavingPanelSettings = "Saving panel settings..."
 const NotifySuccessfullyRetrievedCryptosDataFromExch = "Successfully retrieved cryptos data from exchange."
 const NotifyTickerDisplayRefreshedWithNewRates = "Ticker display refreshed with new rates"
 const NotifyTickerFetchCompleted = "Ticker fetch completed."
+const NotifyTickersHaveBeenReorderedAndUpdated = "Tickers have been reordered and updated."
 const NotifyUnableToAddNewPanelPleaseTryAgain = "Unable to add new panel. Please try again."
+const NotifyUnableToExportLedgerEntries = "Unable to export ledger entries."
+const NotifyUnableToImportLedgerEntries = "Unable to import ledger entries."
 const NotifyUnableToLoadPanelsDataFromFile = "Unable to load panels data from file."
+const NotifyUnableToReloadConfigurationFile = "Unable to reload config.json, keeping current settings."
+const NotifyUnableToReloadPanelsFile = "Unable to reload panels.json, keeping current panels."
 const NotifyUnableToUpdatePanelPleaseTryAgain = "Unable to update panel. Please try again."
@@ -10,3 +10,4 @@ var App fyne.App
 var Window fyne.Window
 var ShutdownCtx, ShutdownCancel = context.WithCancel(context.Background())
 var AppInFocus bool
+var IsHeadless bool
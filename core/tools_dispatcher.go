@@ -8,7 +8,7 @@ import (
 
 type Dispatcher interface {
 	Init()
-	Submit(fn func())
+	Submit(fn func()) bool
 	Pause()
 	Resume()
 	Start()
@@ -117,14 +117,17 @@ func (d *dispatcher) Drain() {
 	}
 }
 
-func (d *dispatcher) Submit(fn func()) {
+// Submit reports false when the job was dropped because the dispatcher is not running or its queue is full
+func (d *dispatcher) Submit(fn func()) bool {
 	if !d.state.Is(STATE_RUNNING) {
-		return
+		return false
 	}
 
 	select {
 	case d.queue <- fn:
+		return true
 	default:
+		return false
 	}
 }
 
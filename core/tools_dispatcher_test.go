@@ -161,6 +161,23 @@ func TestDispatcherDrain(t *testing.T) {
 	}
 }
 
+func TestDispatcherSubmitReportsDrop(t *testing.T) {
+	d := NewDispatcher(1, 1, 0)
+
+	if !d.Submit(func() {}) {
+		t.Error("Expected job to be queued")
+	}
+	if d.Submit(func() {}) {
+		t.Error("Expected job to be dropped on a full queue")
+	}
+
+	d.Destroy()
+
+	if d.Submit(func() {}) {
+		t.Error("Expected job to be dropped after destroy")
+	}
+}
+
 func TestDispatcherDestroy(t *testing.T) {
 	d := NewDispatcher(10, 2, 50*time.Millisecond)
 
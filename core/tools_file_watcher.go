@@ -0,0 +1,209 @@
+package core
+
+import (
+	"crypto/sha256"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+var coreFileWatcher *fileWatcher = nil
+
+type fileWatcherEntry struct {
+	path  string
+	hash  [sha256.Size]byte
+	fn    func(data []byte)
+	timer *time.Timer
+}
+
+type fileWatcher struct {
+	mu      sync.Mutex
+	watcher *fsnotify.Watcher
+	entries map[string]*fileWatcherEntry
+	dirs    map[string]bool
+	delay   time.Duration
+	state   *stateManager
+}
+
+func (fw *fileWatcher) Init() {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	fw.entries = make(map[string]*fileWatcherEntry)
+	fw.dirs = make(map[string]bool)
+	fw.delay = 500 * time.Millisecond
+	fw.state = NewStateManager(STATE_RUNNING)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		Logln("Failed to start file watcher:", err)
+		return
+	}
+
+	fw.watcher = watcher
+
+	go fw.listen(watcher)
+}
+
+func (fw *fileWatcher) SetDelay(delay time.Duration) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	fw.delay = delay
+}
+
+// Add watches the parent directory, editors and dotfile tools often replace the file instead of writing to it
+func (fw *fileWatcher) Add(path string, fn func(data []byte)) error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	if fw.watcher == nil || fw.state.Is(STATE_DESTROYED) {
+		return errors.New("file watcher is not running")
+	}
+
+	path = filepath.Clean(path)
+	entry := &fileWatcherEntry{path: path, fn: fn}
+
+	if data, err := os.ReadFile(path); err == nil {
+		entry.hash = sha256.Sum256(data)
+	}
+
+	targets := []string{path}
+	if real, err := filepath.EvalSymlinks(path); err == nil && real != path {
+		targets = append(targets, real)
+	}
+
+	for _, target := range targets {
+		dir := filepath.Dir(target)
+		if !fw.dirs[dir] {
+			if err := fw.watcher.Add(dir); err != nil {
+				return err
+			}
+			fw.dirs[dir] = true
+		}
+
+		fw.entries[target] = entry
+	}
+
+	return nil
+}
+
+// Remember records content written by the app itself so it won't be reported as an external change
+func (fw *fileWatcher) Remember(path string, data []byte) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	if entry, ok := fw.entries[filepath.Clean(path)]; ok {
+		entry.hash = sha256.Sum256(data)
+	}
+}
+
+func (fw *fileWatcher) Destroy() {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	if fw.state == nil || fw.state.Is(STATE_DESTROYED) {
+		return
+	}
+
+	fw.state.Change(STATE_DESTROYED)
+
+	for _, entry := range fw.entries {
+		if entry.timer != nil {
+			entry.timer.Stop()
+		}
+	}
+
+	if fw.watcher != nil {
+		fw.watcher.Close()
+	}
+}
+
+func (fw *fileWatcher) listen(watcher *fsnotify.Watcher) {
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			fw.schedule(filepath.Clean(event.Name))
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+
+			Logln("File watcher error:", err)
+
+		case <-ShutdownCtx.Done():
+			return
+		}
+	}
+}
+
+// Writes usually arrive as several events, only the settled content is checked
+func (fw *fileWatcher) schedule(path string) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	entry, ok := fw.entries[path]
+	if !ok || fw.state.Is(STATE_DESTROYED) {
+		return
+	}
+
+	if entry.timer != nil {
+		entry.timer.Stop()
+	}
+
+	entry.timer = time.AfterFunc(fw.delay, func() {
+		fw.check(entry)
+	})
+}
+
+func (fw *fileWatcher) check(entry *fileWatcherEntry) {
+	if fw.state.Is(STATE_DESTROYED) || IsShuttingDown() {
+		return
+	}
+
+	data, err := os.ReadFile(entry.path)
+	if err != nil {
+		Logln("Failed to read watched file:", err)
+		return
+	}
+
+	hash := sha256.Sum256(data)
+
+	fw.mu.Lock()
+	if hash == entry.hash {
+		fw.mu.Unlock()
+		return
+	}
+	entry.hash = hash
+	fw.mu.Unlock()
+
+	Logln("Detected external change on", entry.path)
+
+	if entry.fn != nil {
+		entry.fn(data)
+	}
+}
+
+func RegisterFileWatcher() *fileWatcher {
+	if coreFileWatcher == nil {
+		coreFileWatcher = &fileWatcher{}
+	}
+	return coreFileWatcher
+}
+
+func UseFileWatcher() *fileWatcher {
+	return coreFileWatcher
+}
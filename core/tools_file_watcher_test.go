@@ -0,0 +1,128 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestFileWatcher(t *testing.T) *fileWatcher {
+	fw := &fileWatcher{}
+	fw.Init()
+	fw.SetDelay(30 * time.Millisecond)
+
+	if fw.watcher == nil {
+		t.Skip("File watcher is not supported here")
+	}
+
+	t.Cleanup(fw.Destroy)
+
+	return fw
+}
+
+func waitFileWatcher(ch chan string, timeout time.Duration) (string, bool) {
+	select {
+	case data := <-ch:
+		return data, true
+	case <-time.After(timeout):
+		return "", false
+	}
+}
+
+func TestFileWatcherDetectsChange(t *testing.T) {
+	fw := newTestFileWatcher(t)
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(path, []byte(`{"delay":60}`), 0644)
+
+	changes := make(chan string, 5)
+	if err := fw.Add(path, func(data []byte) { changes <- string(data) }); err != nil {
+		t.Fatalf("Expected watcher to add file, got %v", err)
+	}
+
+	os.WriteFile(path, []byte(`{"delay":120}`), 0644)
+
+	data, ok := waitFileWatcher(changes, time.Second)
+	if !ok {
+		t.Fatal("Expected change to be reported")
+	}
+	if data != `{"delay":120}` {
+		t.Errorf("Expected new content, got %q", data)
+	}
+
+	if _, ok := waitFileWatcher(changes, 150*time.Millisecond); ok {
+		t.Error("Expected a single report per settled change")
+	}
+}
+
+func TestFileWatcherIgnoresSameContent(t *testing.T) {
+	fw := newTestFileWatcher(t)
+
+	path := filepath.Join(t.TempDir(), "panels.json")
+	os.WriteFile(path, []byte(`[]`), 0644)
+
+	changes := make(chan string, 5)
+	fw.Add(path, func(data []byte) { changes <- string(data) })
+
+	os.WriteFile(path, []byte(`[]`), 0644)
+
+	if _, ok := waitFileWatcher(changes, 200*time.Millisecond); ok {
+		t.Error("Expected unchanged content to be ignored")
+	}
+}
+
+func TestFileWatcherRememberSuppressesOwnWrites(t *testing.T) {
+	fw := newTestFileWatcher(t)
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(path, []byte(`{}`), 0644)
+
+	changes := make(chan string, 5)
+	fw.Add(path, func(data []byte) { changes <- string(data) })
+
+	fw.Remember(path, []byte(`{"theme":"light"}`))
+	os.WriteFile(path, []byte(`{"theme":"light"}`), 0644)
+
+	if _, ok := waitFileWatcher(changes, 200*time.Millisecond); ok {
+		t.Error("Expected remembered content to be ignored")
+	}
+}
+
+func TestFileWatcherDetectsReplacedFile(t *testing.T) {
+	fw := newTestFileWatcher(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	os.WriteFile(path, []byte(`{}`), 0644)
+
+	changes := make(chan string, 5)
+	fw.Add(path, func(data []byte) { changes <- string(data) })
+
+	tmp := filepath.Join(dir, "config.json.tmp")
+	os.WriteFile(tmp, []byte(`{"locale":"de"}`), 0644)
+	os.Rename(tmp, path)
+
+	data, ok := waitFileWatcher(changes, time.Second)
+	if !ok || data != `{"locale":"de"}` {
+		t.Errorf("Expected replaced file to be reported, got %q", data)
+	}
+}
+
+func TestFileWatcherDestroy(t *testing.T) {
+	fw := newTestFileWatcher(t)
+	fw.Destroy()
+
+	if err := fw.Add(filepath.Join(t.TempDir(), "config.json"), nil); err == nil {
+		t.Error("Expected Add to fail after Destroy")
+	}
+}
+
+func TestFileWatcherSingleton(t *testing.T) {
+	fw1 := RegisterFileWatcher()
+	fw2 := UseFileWatcher()
+	if fw1 != fw2 {
+		t.Error("Expected singleton instance")
+	}
+	coreFileWatcher = nil
+}
@@ -0,0 +1,194 @@
+package core
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	golocale "github.com/jeandeaual/go-locale"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+//go:embed locales/*.json
+var localeCatalogs embed.FS
+
+var activeLocale *localeManager = nil
+
+type localeFormatType struct {
+	Thousands string
+	Decimal   string
+}
+
+// English is the source language, messages are looked up by their English text
+var localeFormats = map[string]localeFormatType{
+	LOCALE_ENGLISH:    {Thousands: ",", Decimal: "."},
+	LOCALE_GERMAN:     {Thousands: ".", Decimal: ","},
+	LOCALE_INDONESIAN: {Thousands: ".", Decimal: ","},
+}
+
+type localeManager struct {
+	mu        sync.RWMutex
+	bundle    *i18n.Bundle
+	localizer *i18n.Localizer
+	code      string
+}
+
+func (l *localeManager) Init() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.bundle = i18n.NewBundle(language.English)
+
+	files, _ := localeCatalogs.ReadDir("locales")
+	for _, file := range files {
+		data, err := localeCatalogs.ReadFile(path.Join("locales", file.Name()))
+		if err != nil {
+			Logln("Failed to read locale catalog:", file.Name(), err)
+			continue
+		}
+
+		if _, err := l.bundle.ParseMessageFileBytes(data, file.Name()); err != nil {
+			Logln("Failed to parse locale catalog:", file.Name(), err)
+		}
+	}
+
+	l.code = LOCALE_ENGLISH
+	l.localizer = i18n.NewLocalizer(l.bundle, l.code)
+}
+
+// Empty or unknown codes follow the system language, falling back to English
+func (l *localeManager) SetLocale(code string) {
+	resolved := MatchLocale(code)
+	if resolved == STRING_EMPTY {
+		resolved = DetectLocale()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.bundle == nil {
+		return
+	}
+
+	l.code = resolved
+	l.localizer = i18n.NewLocalizer(l.bundle, l.code)
+}
+
+func (l *localeManager) GetLocale() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.code
+}
+
+func (l *localeManager) GetFormat() localeFormatType {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if format, ok := localeFormats[l.code]; ok {
+		return format
+	}
+
+	return localeFormats[LOCALE_ENGLISH]
+}
+
+func (l *localeManager) Translate(msg string) string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if l.localizer == nil || msg == STRING_EMPTY {
+		return msg
+	}
+
+	text, err := l.localizer.Localize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{ID: msg, Other: msg},
+	})
+	if err != nil {
+		return msg
+	}
+
+	return text
+}
+
+func GetLocaleCodes() []string {
+	return []string{LOCALE_ENGLISH, LOCALE_GERMAN, LOCALE_INDONESIAN}
+}
+
+// Returns the shipped locale closest to the given tag, or empty when none matches
+func MatchLocale(code string) string {
+	code = strings.TrimSpace(strings.ReplaceAll(code, "_", "-"))
+	if code == STRING_EMPTY {
+		return STRING_EMPTY
+	}
+
+	tag, err := language.Parse(code)
+	if err != nil {
+		return STRING_EMPTY
+	}
+
+	base, _ := tag.Base()
+	if _, ok := localeFormats[base.String()]; ok {
+		return base.String()
+	}
+
+	return STRING_EMPTY
+}
+
+func DetectLocale() string {
+	locales, err := golocale.GetLocales()
+	if err != nil {
+		return LOCALE_ENGLISH
+	}
+
+	for _, code := range locales {
+		if resolved := MatchLocale(code); resolved != STRING_EMPTY {
+			return resolved
+		}
+	}
+
+	return LOCALE_ENGLISH
+}
+
+func Translate(msg string) string {
+	if activeLocale == nil {
+		return msg
+	}
+
+	return activeLocale.Translate(msg)
+}
+
+func TranslateAll(msgs []string) []string {
+	translated := make([]string, len(msgs))
+	for i, msg := range msgs {
+		translated[i] = Translate(msg)
+	}
+
+	return translated
+}
+
+// The format itself is the message, so translations must keep its verbs
+func Translatef(format string, args ...any) string {
+	return fmt.Sprintf(Translate(format), args...)
+}
+
+func getLocaleFormat() localeFormatType {
+	if activeLocale == nil {
+		return localeFormats[LOCALE_ENGLISH]
+	}
+
+	return activeLocale.GetFormat()
+}
+
+func RegisterLocaleManager() *localeManager {
+	if activeLocale == nil {
+		activeLocale = &localeManager{}
+	}
+	return activeLocale
+}
+
+func UseLocale() *localeManager {
+	return activeLocale
+}
@@ -0,0 +1,146 @@
+package core
+
+import (
+	"log"
+	"os"
+	"testing"
+)
+
+type localeNullWriter struct{}
+
+func (localeNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func localeTurnOffLogs() {
+	log.SetOutput(localeNullWriter{})
+}
+
+func localeTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func useTestLocale(t *testing.T, code string) {
+	t.Helper()
+
+	previous := activeLocale
+	activeLocale = &localeManager{}
+	activeLocale.Init()
+	activeLocale.SetLocale(code)
+
+	t.Cleanup(func() {
+		activeLocale = previous
+	})
+}
+
+func TestMatchLocale(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"en", LOCALE_ENGLISH},
+		{"de_DE", LOCALE_GERMAN},
+		{"de-AT", LOCALE_GERMAN},
+		{" id-ID ", LOCALE_INDONESIAN},
+		{"fr", STRING_EMPTY},
+		{"not a locale", STRING_EMPTY},
+		{STRING_EMPTY, STRING_EMPTY},
+	}
+
+	for _, tt := range tests {
+		if got := MatchLocale(tt.input); got != tt.expected {
+			t.Errorf("MatchLocale(%q) = %q; want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestLocaleCatalogsLoaded(t *testing.T) {
+	localeTurnOffLogs()
+	defer localeTurnOnLogs()
+
+	for _, code := range GetLocaleCodes() {
+		useTestLocale(t, code)
+
+		if got := UseLocale().GetLocale(); got != code {
+			t.Errorf("Expected locale %s, got %s", code, got)
+		}
+
+		if code != LOCALE_ENGLISH && Translate(NotifyConfigurationSavedSuccessfully) == NotifyConfigurationSavedSuccessfully {
+			t.Errorf("Expected %s catalog to translate notifications", code)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	localeTurnOffLogs()
+	defer localeTurnOnLogs()
+
+	if got := Translate("Settings"); got != "Settings" {
+		t.Errorf("Expected untranslated message without a locale manager, got %s", got)
+	}
+
+	useTestLocale(t, LOCALE_GERMAN)
+
+	if got := Translate("Settings"); got != "Einstellungen" {
+		t.Errorf("Expected German translation, got %s", got)
+	}
+
+	if got := Translate("Not in any catalog"); got != "Not in any catalog" {
+		t.Errorf("Expected missing messages to fall back to English, got %s", got)
+	}
+
+	if got := Translatef("As of %s", "2025-01-01"); got != "Stand 2025-01-01" {
+		t.Errorf("Expected translated format, got %s", got)
+	}
+
+	if got := TranslateAll([]string{"Save", "Cancel"}); got[0] != "Speichern" || got[1] != "Abbrechen" {
+		t.Errorf("Expected all messages translated, got %v", got)
+	}
+
+	UseLocale().SetLocale("fr")
+	if got := UseLocale().GetLocale(); MatchLocale(got) != got {
+		t.Errorf("Expected unknown locale to resolve to a shipped one, got %s", got)
+	}
+}
+
+func TestFormatNumberLocale(t *testing.T) {
+	localeTurnOffLogs()
+	defer localeTurnOnLogs()
+
+	tests := []struct {
+		code     string
+		value    float64
+		frac     int
+		number   string
+		decimal  string
+		localize string
+	}{
+		{LOCALE_ENGLISH, 1234567.891, 2, "1,234,567.89", "1234567.89", "1.23"},
+		{LOCALE_GERMAN, 1234567.891, 2, "1.234.567,89", "1234567,89", "1,23"},
+		{LOCALE_INDONESIAN, 1234567.891, 2, "1.234.567,89", "1234567,89", "1,23"},
+		{LOCALE_GERMAN, 1000, 0, "1.000", "1000", "1,23"},
+	}
+
+	for _, tt := range tests {
+		useTestLocale(t, tt.code)
+
+		if got := FormatNumberWithCommas(tt.value, tt.frac); got != tt.number {
+			t.Errorf("[%s] FormatNumberWithCommas(%v) = %s; want %s", tt.code, tt.value, got, tt.number)
+		}
+		if got := FormatDecimal(tt.value, tt.frac); got != tt.decimal {
+			t.Errorf("[%s] FormatDecimal(%v) = %s; want %s", tt.code, tt.value, got, tt.decimal)
+		}
+		if got := LocalizeDecimal("1.23"); got != tt.localize {
+			t.Errorf("[%s] LocalizeDecimal(1.23) = %s; want %s", tt.code, got, tt.localize)
+		}
+	}
+
+	useTestLocale(t, LOCALE_GERMAN)
+	eur, _ := GetFiat("EUR")
+	if got := FormatFiat(1234.5, eur); got != "€1.234,5" {
+		t.Errorf("Expected German fiat formatting, got %s", got)
+	}
+	if got := FormatShortFiat("1234567", eur); got != "€1,23M" {
+		t.Errorf("Expected German short fiat formatting, got %s", got)
+	}
+}
@@ -0,0 +1,409 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/buger/jsonparser"
+)
+
+var coreQuota *quotaManager = nil
+
+// Only these domains bill requests against the daily and monthly budget
+var quotaBudgetDomains = []string{"coinmarketcap.com"}
+
+type quotaPriorityKey struct{}
+
+type QuotaUsageType struct {
+	Today        int64
+	Month        int64
+	DailyLimit   int64
+	MonthlyLimit int64
+	Remaining    int64
+}
+
+func (u QuotaUsageType) IsLimited() bool {
+	return u.Remaining >= 0
+}
+
+func (u QuotaUsageType) Format() string {
+	if u.IsLimited() {
+		return Translatef("%s requests left", FormatNumberWithCommas(float64(u.Remaining), 0))
+	}
+
+	return Translatef("%s requests today", FormatNumberWithCommas(float64(u.Today), 0))
+}
+
+type quotaFileType struct {
+	Day   string `json:"day"`
+	Month string `json:"month"`
+	Today int64  `json:"today"`
+	Total int64  `json:"total"`
+}
+
+type quotaBucket struct {
+	tokens  float64
+	updated time.Time
+	blocked time.Time
+}
+
+type quotaManager struct {
+	mu        sync.Mutex
+	buckets   map[string]*quotaBucket
+	perMinute int64
+	daily     int64
+	monthly   int64
+	day       string
+	month     string
+	usedDay   int64
+	usedMonth int64
+	warned    string
+	onLow     func()
+	timer     *time.Timer
+	now       func() time.Time
+}
+
+func (q *quotaManager) Init() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.buckets != nil {
+		return
+	}
+
+	q.buckets = make(map[string]*quotaBucket)
+	if q.now == nil {
+		q.now = time.Now
+	}
+
+	q.load()
+	q.rollover(q.now())
+}
+
+// Limits of zero are unlimited, perMinute applies to every host on its own
+func (q *quotaManager) SetLimits(perMinute int64, daily int64, monthly int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.perMinute = perMinute
+	q.daily = daily
+	q.monthly = monthly
+}
+
+func (q *quotaManager) SetOnLow(fn func()) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.onLow = fn
+}
+
+// Acquire waits for a token of the host bucket, low priority requests are skipped instead of waiting
+func (q *quotaManager) Acquire(ctx context.Context, host string) int64 {
+	if q == nil {
+		return NETWORKING_SUCCESS
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	low := IsLowPriorityRequest(ctx)
+	host = strings.ToLower(host)
+
+	q.mu.Lock()
+
+	now := q.now()
+	q.rollover(now)
+	bucket := q.bucket(host, now)
+
+	if now.Before(bucket.blocked) {
+		q.mu.Unlock()
+		Logf("Quota %s is rate limited until %s", host, bucket.blocked.Format(time.TimeOnly))
+		return NETWORKING_RATE_LIMIT
+	}
+
+	budgeted := isQuotaBudgetHost(host)
+
+	if budgeted && q.remaining() == 0 {
+		q.mu.Unlock()
+		Logln("Quota exhausted, skipping request to", host)
+		return NETWORKING_QUOTA_EXCEEDED
+	}
+
+	if budgeted && low && q.isLow() {
+		q.mu.Unlock()
+		Logln("Quota running low, skipping low priority request to", host)
+		return NETWORKING_QUOTA_EXCEEDED
+	}
+
+	var wait time.Duration
+	if q.perMinute > 0 {
+		if bucket.tokens < 1 {
+			if low {
+				q.mu.Unlock()
+				Logln("Quota throttled, skipping low priority request to", host)
+				return NETWORKING_QUOTA_EXCEEDED
+			}
+			wait = time.Duration((1 - bucket.tokens) / q.rate() * float64(time.Second))
+		}
+		bucket.tokens--
+	}
+
+	// Reserve the request before releasing the lock so concurrent callers cannot overrun the budget
+	var onLow func()
+	if budgeted {
+		onLow = q.count()
+	}
+
+	q.mu.Unlock()
+
+	if onLow != nil {
+		onLow()
+	}
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			q.Refund(host)
+			return NETWORKING_ERROR_CONNECTION
+		case <-timer.C:
+		}
+	}
+
+	return NETWORKING_SUCCESS
+}
+
+// Refund gives back a request reserved by Acquire that never reached the server
+func (q *quotaManager) Refund(host string) {
+	if q == nil || !isQuotaBudgetHost(strings.ToLower(host)) {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.usedDay = max(q.usedDay-1, 0)
+	q.usedMonth = max(q.usedMonth-1, 0)
+}
+
+// Block stops requests to the host until the server allows them again
+func (q *quotaManager) Block(host string, duration time.Duration) {
+	if q == nil {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now()
+	bucket := q.bucket(strings.ToLower(host), now)
+
+	if until := now.Add(duration); until.After(bucket.blocked) {
+		bucket.blocked = until
+	}
+	bucket.tokens = 0
+
+	Logf("Quota blocking %s for %s", host, duration)
+}
+
+func (q *quotaManager) GetUsage() QuotaUsageType {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.rollover(q.now())
+
+	return QuotaUsageType{
+		Today:        q.usedDay,
+		Month:        q.usedMonth,
+		DailyLimit:   q.daily,
+		MonthlyLimit: q.monthly,
+		Remaining:    q.remaining(),
+	}
+}
+
+func (q *quotaManager) IsLow() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.rollover(q.now())
+
+	return q.isLow()
+}
+
+func (q *quotaManager) Save() bool {
+	q.mu.Lock()
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+	}
+	data := quotaFileType{
+		Day:   q.day,
+		Month: q.month,
+		Today: q.usedDay,
+		Total: q.usedMonth,
+	}
+	q.mu.Unlock()
+
+	return SaveFileToStorage("quota.json", data)
+}
+
+func (q *quotaManager) Destroy() {
+	q.Save()
+}
+
+func (q *quotaManager) load() {
+	content, ok := LoadFileFromStorage("quota.json")
+	if !ok {
+		return
+	}
+
+	data := []byte(content)
+
+	if val, err := jsonparser.GetString(data, "day"); err == nil {
+		q.day = val
+	}
+	if val, err := jsonparser.GetString(data, "month"); err == nil {
+		q.month = val
+	}
+	if val, err := jsonparser.GetInt(data, "today"); err == nil {
+		q.usedDay = val
+	}
+	if val, err := jsonparser.GetInt(data, "total"); err == nil {
+		q.usedMonth = val
+	}
+}
+
+// Counts a request, the caller holds the lock and calls the returned low budget warning after releasing it
+func (q *quotaManager) count() func() {
+	q.usedDay++
+	q.usedMonth++
+
+	if q.timer == nil {
+		q.timer = time.AfterFunc(30*time.Second, func() {
+			q.Save()
+		})
+	}
+
+	if q.isLow() && q.warned != q.day {
+		q.warned = q.day
+		return q.onLow
+	}
+
+	return nil
+}
+
+func (q *quotaManager) rollover(now time.Time) {
+	if day := now.Format(time.DateOnly); day != q.day {
+		q.day = day
+		q.usedDay = 0
+	}
+
+	if month := now.Format("2006-01"); month != q.month {
+		q.month = month
+		q.usedMonth = 0
+	}
+}
+
+func isQuotaBudgetHost(host string) bool {
+	for _, domain := range quotaBudgetDomains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (q *quotaManager) rate() float64 {
+	return float64(q.perMinute) / 60
+}
+
+func (q *quotaManager) bucket(host string, now time.Time) *quotaBucket {
+	bucket, ok := q.buckets[host]
+	if !ok {
+		bucket = &quotaBucket{tokens: float64(q.perMinute), updated: now}
+		q.buckets[host] = bucket
+		return bucket
+	}
+
+	if q.perMinute > 0 {
+		bucket.tokens = min(float64(q.perMinute), bucket.tokens+now.Sub(bucket.updated).Seconds()*q.rate())
+	}
+	bucket.updated = now
+
+	return bucket
+}
+
+// Remaining requests of the tightest budget, -1 without any limit
+func (q *quotaManager) remaining() int64 {
+	remaining := int64(-1)
+
+	if q.daily > 0 {
+		remaining = max(q.daily-q.usedDay, 0)
+	}
+
+	if q.monthly > 0 {
+		left := max(q.monthly-q.usedMonth, 0)
+		if remaining < 0 || left < remaining {
+			remaining = left
+		}
+	}
+
+	return remaining
+}
+
+func (q *quotaManager) isLow() bool {
+	if q.daily > 0 && (q.daily-q.usedDay)*100 < q.daily*QUOTA_LOW_RESERVE {
+		return true
+	}
+
+	return q.monthly > 0 && (q.monthly-q.usedMonth)*100 < q.monthly*QUOTA_LOW_RESERVE
+}
+
+func WithLowPriority(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return context.WithValue(ctx, quotaPriorityKey{}, true)
+}
+
+func IsLowPriorityRequest(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+
+	low, _ := ctx.Value(quotaPriorityKey{}).(bool)
+	return low
+}
+
+// Retry-After is either seconds or an http date, missing values wait a minute
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	wait := QUOTA_DEFAULT_RETRY_AFTER
+
+	if seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil && seconds > 0 {
+		wait = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		wait = at.Sub(now)
+	}
+
+	return min(wait, QUOTA_MAXIMUM_RETRY_AFTER)
+}
+
+func RegisterQuotaManager() *quotaManager {
+	if coreQuota == nil {
+		coreQuota = &quotaManager{}
+	}
+	return coreQuota
+}
+
+func UseQuota() *quotaManager {
+	return coreQuota
+}
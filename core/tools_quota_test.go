@@ -0,0 +1,394 @@
+package core
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type quotaNullWriter struct{}
+
+func (quotaNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func quotaTurnOffLogs() {
+	log.SetOutput(quotaNullWriter{})
+}
+
+func quotaTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func newTestQuota(t *testing.T) (*quotaManager, *time.Time) {
+	previous := userDirectory
+	userDirectory = t.TempDir()
+	t.Cleanup(func() {
+		userDirectory = previous
+	})
+
+	current := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	q := &quotaManager{now: func() time.Time { return current }}
+	q.Init()
+
+	t.Cleanup(func() {
+		if q.timer != nil {
+			q.timer.Stop()
+		}
+	})
+
+	return q, &current
+}
+
+// Lets the local test servers count against the budget
+func quotaBudgetLocalhost(t *testing.T) {
+	previous := quotaBudgetDomains
+	quotaBudgetDomains = append([]string{"127.0.0.1"}, previous...)
+	t.Cleanup(func() {
+		quotaBudgetDomains = previous
+	})
+}
+
+func TestQuotaTokenBucket(t *testing.T) {
+	quotaTurnOffLogs()
+	defer quotaTurnOnLogs()
+
+	q, current := newTestQuota(t)
+	q.SetLimits(2, 0, 0)
+
+	low := WithLowPriority(context.Background())
+
+	if q.Acquire(context.Background(), "api.coinmarketcap.com") != NETWORKING_SUCCESS || q.Acquire(low, "api.coinmarketcap.com") != NETWORKING_SUCCESS {
+		t.Fatal("Expected the first two requests to pass")
+	}
+
+	if code := q.Acquire(low, "api.coinmarketcap.com"); code != NETWORKING_QUOTA_EXCEEDED {
+		t.Errorf("Expected low priority request skipped on empty bucket, got %s", GetNetworkingCodeName(code))
+	}
+
+	if code := q.Acquire(low, "pro-api.coinmarketcap.com"); code != NETWORKING_SUCCESS {
+		t.Errorf("Expected other hosts to have their own bucket, got %s", GetNetworkingCodeName(code))
+	}
+
+	*current = current.Add(30 * time.Second)
+
+	if code := q.Acquire(low, "api.coinmarketcap.com"); code != NETWORKING_SUCCESS {
+		t.Errorf("Expected bucket refilled after 30 seconds, got %s", GetNetworkingCodeName(code))
+	}
+
+	if usage := q.GetUsage(); usage.Today != 4 || usage.Month != 4 || usage.IsLimited() || usage.Format() != "4 requests today" {
+		t.Errorf("Unexpected usage %+v", usage)
+	}
+}
+
+func TestQuotaThrottlesHighPriority(t *testing.T) {
+	quotaTurnOffLogs()
+	defer quotaTurnOnLogs()
+
+	q, _ := newTestQuota(t)
+	q.SetLimits(600, 0, 0)
+	q.buckets["api.coinmarketcap.com"] = &quotaBucket{tokens: 0, updated: q.now()}
+
+	start := time.Now()
+	if code := q.Acquire(context.Background(), "api.coinmarketcap.com"); code != NETWORKING_SUCCESS {
+		t.Errorf("Expected high priority request to wait for a token, got %s", GetNetworkingCodeName(code))
+	}
+	if time.Since(start) < 50*time.Millisecond {
+		t.Error("Expected high priority request to be throttled")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if code := q.Acquire(ctx, "api.coinmarketcap.com"); code != NETWORKING_ERROR_CONNECTION {
+		t.Errorf("Expected cancelled wait to fail, got %s", GetNetworkingCodeName(code))
+	}
+}
+
+func TestQuotaBudget(t *testing.T) {
+	quotaTurnOffLogs()
+	defer quotaTurnOnLogs()
+
+	q, current := newTestQuota(t)
+	q.SetLimits(0, 100, 1000)
+
+	var warned atomic.Int64
+	q.SetOnLow(func() { warned.Add(1) })
+
+	q.usedDay = 89
+	q.usedMonth = 500
+
+	low := WithLowPriority(context.Background())
+
+	for range 2 {
+		if code := q.Acquire(low, "api.coinmarketcap.com"); code != NETWORKING_SUCCESS {
+			t.Fatalf("Expected request within budget, got %s", GetNetworkingCodeName(code))
+		}
+	}
+
+	if !q.IsLow() || warned.Load() != 1 {
+		t.Errorf("Expected budget to run low with one warning, got %v %d", q.IsLow(), warned.Load())
+	}
+
+	if code := q.Acquire(low, "api.coinmarketcap.com"); code != NETWORKING_QUOTA_EXCEEDED {
+		t.Errorf("Expected low priority request skipped on low budget, got %s", GetNetworkingCodeName(code))
+	}
+	if code := q.Acquire(context.Background(), "api.coinmarketcap.com"); code != NETWORKING_SUCCESS {
+		t.Errorf("Expected high priority request on low budget, got %s", GetNetworkingCodeName(code))
+	}
+	if warned.Load() != 1 {
+		t.Errorf("Expected a single warning per day, got %d", warned.Load())
+	}
+
+	q.usedDay = 100
+	if code := q.Acquire(context.Background(), "api.coinmarketcap.com"); code != NETWORKING_QUOTA_EXCEEDED {
+		t.Errorf("Expected exhausted budget to skip every request, got %s", GetNetworkingCodeName(code))
+	}
+
+	*current = current.Add(24 * time.Hour)
+
+	usage := q.GetUsage()
+	if usage.Today != 0 || usage.Month != 503 || usage.Remaining != 100 {
+		t.Errorf("Expected daily counter reset on the next day, got %+v", usage)
+	}
+	if usage.Format() != "100 requests left" {
+		t.Errorf("Unexpected usage text %q", usage.Format())
+	}
+
+	*current = time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	if usage := q.GetUsage(); usage.Month != 0 {
+		t.Errorf("Expected monthly counter reset on the next month, got %+v", usage)
+	}
+}
+
+func TestQuotaBudgetHosts(t *testing.T) {
+	quotaTurnOffLogs()
+	defer quotaTurnOnLogs()
+
+	q, _ := newTestQuota(t)
+	q.SetLimits(0, 1, 0)
+
+	if code := q.Acquire(context.Background(), "API.CoinMarketCap.com"); code != NETWORKING_SUCCESS {
+		t.Fatalf("Expected request within budget, got %s", GetNetworkingCodeName(code))
+	}
+	if code := q.Acquire(context.Background(), "s3.coinmarketcap.com"); code != NETWORKING_QUOTA_EXCEEDED {
+		t.Errorf("Expected coinmarketcap hosts to share the budget, got %s", GetNetworkingCodeName(code))
+	}
+	if code := q.Acquire(context.Background(), "api.binance.com"); code != NETWORKING_SUCCESS {
+		t.Errorf("Expected other hosts to skip the budget, got %s", GetNetworkingCodeName(code))
+	}
+	if code := q.Acquire(context.Background(), "notcoinmarketcap.com"); code != NETWORKING_SUCCESS {
+		t.Errorf("Expected lookalike hosts to skip the budget, got %s", GetNetworkingCodeName(code))
+	}
+
+	if usage := q.GetUsage(); usage.Today != 1 {
+		t.Errorf("Expected only budget hosts counted, got %d", usage.Today)
+	}
+}
+
+func TestQuotaConcurrentAcquire(t *testing.T) {
+	quotaTurnOffLogs()
+	defer quotaTurnOnLogs()
+
+	q, _ := newTestQuota(t)
+	q.SetLimits(0, 10, 0)
+
+	var passed atomic.Int64
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if q.Acquire(context.Background(), "api.coinmarketcap.com") == NETWORKING_SUCCESS {
+				passed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if passed.Load() != 10 || q.GetUsage().Today != 10 {
+		t.Errorf("Expected exactly the budget to pass, got %d passed and %d counted", passed.Load(), q.GetUsage().Today)
+	}
+}
+
+func TestQuotaRefund(t *testing.T) {
+	quotaTurnOffLogs()
+	defer quotaTurnOnLogs()
+
+	q, _ := newTestQuota(t)
+	q.SetLimits(600, 0, 0)
+	q.buckets["api.coinmarketcap.com"] = &quotaBucket{tokens: 0, updated: q.now()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if code := q.Acquire(ctx, "api.coinmarketcap.com"); code != NETWORKING_ERROR_CONNECTION {
+		t.Fatalf("Expected cancelled wait to fail, got %s", GetNetworkingCodeName(code))
+	}
+	if usage := q.GetUsage(); usage.Today != 0 || usage.Month != 0 {
+		t.Errorf("Expected cancelled request refunded, got %+v", usage)
+	}
+
+	q.Refund("api.coinmarketcap.com")
+	if usage := q.GetUsage(); usage.Today != 0 {
+		t.Errorf("Expected refund to never go below zero, got %d", usage.Today)
+	}
+}
+
+func TestGetRequestRefundsFailedTransport(t *testing.T) {
+	quotaTurnOffLogs()
+	defer quotaTurnOnLogs()
+
+	quotaBudgetLocalhost(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	endpoint := server.URL
+	server.Close()
+
+	q, _ := newTestQuota(t)
+
+	previous := coreQuota
+	coreQuota = q
+	defer func() {
+		coreQuota = previous
+	}()
+
+	if code := GetRequest(context.Background(), endpoint, nil, nil); code == NETWORKING_SUCCESS {
+		t.Fatal("Expected request to a closed server to fail")
+	}
+
+	if usage := q.GetUsage(); usage.Today != 0 {
+		t.Errorf("Expected failed transport refunded, got %d", usage.Today)
+	}
+}
+
+func TestQuotaPersistence(t *testing.T) {
+	quotaTurnOffLogs()
+	defer quotaTurnOnLogs()
+
+	q, _ := newTestQuota(t)
+	q.Acquire(context.Background(), "api.coinmarketcap.com")
+	q.Acquire(context.Background(), "api.coinmarketcap.com")
+
+	if !q.Save() {
+		t.Fatal("Expected quota.json saved")
+	}
+
+	reloaded := &quotaManager{now: q.now}
+	reloaded.Init()
+
+	if usage := reloaded.GetUsage(); usage.Today != 2 || usage.Month != 2 {
+		t.Errorf("Expected counters restored from quota.json, got %+v", usage)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	for value, expected := range map[string]time.Duration{
+		"120":                           2 * time.Minute,
+		"":                              QUOTA_DEFAULT_RETRY_AFTER,
+		"soon":                          QUOTA_DEFAULT_RETRY_AFTER,
+		"999999":                        QUOTA_MAXIMUM_RETRY_AFTER,
+		"Tue, 10 Mar 2026 12:05:00 GMT": 5 * time.Minute,
+	} {
+		if got := ParseRetryAfter(value, now); got != expected {
+			t.Errorf("Expected %q to wait %s, got %s", value, expected, got)
+		}
+	}
+}
+
+func TestGetRequestHonorsRetryAfter(t *testing.T) {
+	quotaTurnOffLogs()
+	defer quotaTurnOnLogs()
+
+	var hits atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	quotaBudgetLocalhost(t)
+
+	q, current := newTestQuota(t)
+
+	previous := coreQuota
+	coreQuota = q
+	defer func() {
+		coreQuota = previous
+	}()
+
+	if code := GetRequest(context.Background(), server.URL, nil, nil); code != NETWORKING_RATE_LIMIT {
+		t.Errorf("Expected rate limit, got %s", GetNetworkingCodeName(code))
+	}
+	if code := GetRequest(context.Background(), server.URL, nil, nil); code != NETWORKING_RATE_LIMIT {
+		t.Errorf("Expected rate limit while blocked, got %s", GetNetworkingCodeName(code))
+	}
+	if hits.Load() != 1 {
+		t.Errorf("Expected no request while blocked, got %d", hits.Load())
+	}
+
+	*current = current.Add(121 * time.Second)
+
+	GetRequest(context.Background(), server.URL, nil, nil)
+	if hits.Load() != 2 {
+		t.Errorf("Expected request after Retry-After passed, got %d", hits.Load())
+	}
+
+	if usage := q.GetUsage(); usage.Today != 2 {
+		t.Errorf("Expected only sent requests counted, got %d", usage.Today)
+	}
+}
+
+func TestGetRequestThrottledIsSkipped(t *testing.T) {
+	quotaTurnOffLogs()
+	defer quotaTurnOnLogs()
+
+	var hits atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	q, _ := newTestQuota(t)
+	q.SetLimits(1, 0, 0)
+
+	previous := coreQuota
+	coreQuota = q
+	defer func() {
+		coreQuota = previous
+	}()
+
+	low := WithLowPriority(context.Background())
+
+	first := DetectHTTPResponse(GetRequest(low, server.URL, nil, nil))
+	second := DetectHTTPResponse(GetRequest(low, server.URL, nil, nil))
+
+	if first != STATUS_SUCCESS || second != STATUS_SKIPPED {
+		t.Fatalf("Expected success then skipped, got %d and %d", first, second)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("Expected the throttled request to never reach the server, got %d", hits.Load())
+	}
+
+	if MergeHTTPStatus(STATUS_SKIPPED, second) != STATUS_SKIPPED {
+		t.Error("Expected a batch of skipped fetches to stay skipped")
+	}
+	if MergeHTTPStatus(MergeHTTPStatus(STATUS_SKIPPED, first), second) != STATUS_SUCCESS {
+		t.Error("Expected a skipped fetch to not override a success")
+	}
+	if MergeHTTPStatus(MergeHTTPStatus(STATUS_SKIPPED, second), STATUS_NETWORK_ERROR) != STATUS_NETWORK_ERROR {
+		t.Error("Expected a skipped fetch to not hide a network error")
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"image/color"
 	"math"
+	"strconv"
+	"strings"
 	"sync"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/theme"
+	"github.com/buger/jsonparser"
+	json "github.com/goccy/go-json"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/opentype"
 )
@@ -22,13 +26,24 @@ type appTheme struct {
 	fontRegularTT *opentype.Font
 	fontBoldTT    *opentype.Font
 	faceCache     map[string]font.Face
+
+	// Fyne reads colors and sizes without going through mu
+	paletteMu sync.RWMutex
+	mode      string
+	colors    map[fyne.ThemeVariant]map[fyne.ThemeColorName]color.Color
+	sizes     map[fyne.ThemeSizeName]float32
 }
 
 func (t *appTheme) Init() {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	t.variant = theme.VariantDark
-	//t.variant = theme.VariantLight
+
+	t.paletteMu.Lock()
+	defer t.paletteMu.Unlock()
+	t.mode = THEME_DARK
+	t.colors = map[fyne.ThemeVariant]map[fyne.ThemeColorName]color.Color{}
+	t.sizes = map[fyne.ThemeSizeName]float32{}
 }
 
 func (t *appTheme) SetVariant(variant fyne.ThemeVariant) {
@@ -37,6 +52,95 @@ func (t *appTheme) SetVariant(variant fyne.ThemeVariant) {
 	t.variant = variant
 }
 
+// System mode follows the variant fyne asks for, light and dark force their own
+func (t *appTheme) SetMode(mode string) {
+	switch mode {
+	case THEME_SYSTEM:
+	case THEME_LIGHT:
+		t.SetVariant(theme.VariantLight)
+	default:
+		mode = THEME_DARK
+		t.SetVariant(theme.VariantDark)
+	}
+
+	t.paletteMu.Lock()
+	defer t.paletteMu.Unlock()
+	t.mode = mode
+}
+
+func (t *appTheme) GetMode() string {
+	t.paletteMu.RLock()
+	defer t.paletteMu.RUnlock()
+
+	return t.mode
+}
+
+// Palette json holds "colors" per variant and shared "sizes", invalid entries are logged and skipped
+func (t *appTheme) SetPalette(data []byte) error {
+	colors := map[fyne.ThemeVariant]map[fyne.ThemeColorName]color.Color{}
+	sizes := map[fyne.ThemeSizeName]float32{}
+
+	if len(strings.TrimSpace(string(data))) != 0 {
+		if !json.Valid(data) {
+			return fmt.Errorf("invalid palette json")
+		}
+
+		variants := map[string]fyne.ThemeVariant{THEME_DARK: theme.VariantDark, THEME_LIGHT: theme.VariantLight}
+		for key, variant := range variants {
+			colors[variant] = map[fyne.ThemeColorName]color.Color{}
+			err := jsonparser.ObjectEach(data, func(name []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+				col, ok := ParseHexColor(string(value))
+				if !ok || dataType != jsonparser.String {
+					Logln("Ignoring invalid palette color:", key, string(name), string(value))
+					return nil
+				}
+				colors[variant][fyne.ThemeColorName(name)] = col
+				return nil
+			}, "colors", key)
+			if err != nil && err != jsonparser.KeyPathNotFoundError {
+				return err
+			}
+		}
+
+		err := jsonparser.ObjectEach(data, func(name []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+			size, err := strconv.ParseFloat(string(value), 32)
+			if err != nil || dataType != jsonparser.Number || size < 0 {
+				Logln("Ignoring invalid palette size:", string(name), string(value))
+				return nil
+			}
+			sizes[fyne.ThemeSizeName(name)] = float32(size)
+			return nil
+		}, "sizes")
+		if err != nil && err != jsonparser.KeyPathNotFoundError {
+			return err
+		}
+	}
+
+	t.paletteMu.Lock()
+	defer t.paletteMu.Unlock()
+	t.colors = colors
+	t.sizes = sizes
+
+	return nil
+}
+
+// Missing palette file means no overrides
+func (t *appTheme) LoadPalette() bool {
+	content, ok := LoadFileFromStorage("theme.json")
+	if !ok {
+		t.SetPalette(nil)
+		return false
+	}
+
+	if err := t.SetPalette([]byte(content)); err != nil {
+		Logf("Failed to parse theme.json: %v", err)
+		t.SetPalette(nil)
+		return false
+	}
+
+	return true
+}
+
 func (t *appTheme) SetFonts(style fyne.TextStyle, font fyne.Resource) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -121,7 +225,18 @@ func (t *appTheme) GetFont(style fyne.TextStyle) *opentype.Font {
 }
 
 func (t *appTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
-	if t.variant == theme.VariantLight {
+	t.paletteMu.RLock()
+	if t.mode != THEME_SYSTEM {
+		variant = t.variant
+	}
+	col, ok := t.colors[variant][name]
+	t.paletteMu.RUnlock()
+
+	if ok {
+		return col
+	}
+
+	if variant == theme.VariantLight {
 		return t.lightColor(name)
 	}
 	return t.darkColor(name)
@@ -139,6 +254,14 @@ func (t *appTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
 }
 
 func (t *appTheme) Size(name fyne.ThemeSizeName) float32 {
+	t.paletteMu.RLock()
+	size, ok := t.sizes[name]
+	t.paletteMu.RUnlock()
+
+	if ok {
+		return size
+	}
+
 	switch name {
 
 	case theme.SizeNameSeparatorThickness:
@@ -240,6 +363,15 @@ func (t *appTheme) Size(name fyne.ThemeSizeName) float32 {
 	case SizePanelHeight:
 		return 110
 
+	case SizePanelSparkline:
+		return 36
+
+	case SizePanelSparklineSmall:
+		return 24
+
+	case SizePanelSparklineStroke:
+		return 1.5
+
 	case SizeActionBtnWidth:
 		return 40
 
@@ -299,7 +431,16 @@ func (t *appTheme) Size(name fyne.ThemeSizeName) float32 {
 func (t *appTheme) GetColor(name fyne.ThemeColorName) color.Color {
 	t.mu.Lock()
 	defer t.mu.Unlock()
-	return t.Color(name, t.variant)
+	return t.Color(name, t.GetVariant())
+}
+
+// The variant in use, asking the system when following it
+func (t *appTheme) GetVariant() fyne.ThemeVariant {
+	if t.GetMode() == THEME_SYSTEM && fyne.CurrentApp() != nil {
+		return fyne.CurrentApp().Settings().ThemeVariant()
+	}
+
+	return t.variant
 }
 
 func (t *appTheme) lightColor(name fyne.ThemeColorName) color.Color {
@@ -367,6 +508,8 @@ func (t *appTheme) lightColor(name fyne.ThemeColorName) color.Color {
 		return color.RGBA{160, 160, 160, 168}
 	case ColorNameTickerBG:
 		return color.RGBA{240, 243, 246, 255}
+	case ColorNamePanelSparkline:
+		return color.RGBA{45, 45, 45, 72}
 	case ColorNameRed:
 		return color.RGBA{255, 190, 190, 255}
 	case ColorNameDarkRed:
@@ -463,6 +606,8 @@ func (t *appTheme) darkColor(name fyne.ThemeColorName) color.Color {
 		return color.RGBA{R: 20, G: 22, B: 30, A: 200}
 	case ColorNameTickerBG:
 		return color.RGBA{R: 50, G: 53, B: 70, A: 255}
+	case ColorNamePanelSparkline:
+		return color.RGBA{R: 255, G: 255, B: 255, A: 64}
 	case ColorNameRed:
 		return color.RGBA{R: 133, G: 36, B: 36, A: 255}
 	case ColorNameDarkRed:
@@ -511,6 +656,39 @@ func (t *appTheme) Destroy() {
 	t.bold = nil
 }
 
+// Accepts #rgb, #rrggbb and #rrggbbaa
+func ParseHexColor(s string) (color.Color, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) == 6 {
+		s += "ff"
+	}
+	if len(s) != 8 {
+		return nil, false
+	}
+
+	val, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, false
+	}
+
+	return color.RGBA{R: uint8(val >> 24), G: uint8(val >> 16), B: uint8(val >> 8), A: uint8(val)}, true
+}
+
+// Whether the name is one of the app colors that user color rules may use
+func IsColorName(name string) bool {
+	switch fyne.ThemeColorName(name) {
+	case ColorNameError, ColorNameTransparent, ColorNameRed, ColorNameDarkRed, ColorNameGreen,
+		ColorNameDarkGreen, ColorNameBlue, ColorNameLightBlue, ColorNameLightPurple, ColorNameLightOrange,
+		ColorNameOrange, ColorNameYellow, ColorNameTeal, ColorNameDarkGrey, ColorNamePanelBG, ColorNameTickerBG:
+		return true
+	}
+	return false
+}
+
 func RegisterThemeManager() *appTheme {
 	if activeTheme == nil {
 		activeTheme = &appTheme{}
@@ -80,3 +80,111 @@ func TestRegisterAndUseThemeManager(t *testing.T) {
 		t.Error("Expected RegisterThemeManager and UseTheme to return the same instance")
 	}
 }
+
+func TestIsColorName(t *testing.T) {
+	for _, name := range []string{"green", "darkRed", "lightPurple", "tickerBG", "panelBG"} {
+		if !IsColorName(name) {
+			t.Errorf("Expected %s to be a color name", name)
+		}
+	}
+
+	for _, name := range []string{"", "Green", "purple", "panelSparkline", string(theme.ColorNameBackground)} {
+		if IsColorName(name) {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+	}
+}
+
+func TestAppThemeSetMode(t *testing.T) {
+	th := &appTheme{}
+	th.Init()
+
+	if th.GetMode() != THEME_DARK {
+		t.Errorf("Expected default mode to be dark, got %s", th.GetMode())
+	}
+
+	th.SetMode(THEME_LIGHT)
+	if c := th.GetColor(theme.ColorNameBackground); c != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("Expected light background, got %v", c)
+	}
+	if c := th.Color(theme.ColorNameBackground, theme.VariantDark); c != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("Expected forced light mode to ignore the requested variant, got %v", c)
+	}
+
+	th.SetMode(THEME_SYSTEM)
+	if c := th.Color(theme.ColorNameBackground, theme.VariantDark); c != (color.RGBA{R: 13, G: 20, B: 33, A: 255}) {
+		t.Errorf("Expected system mode to follow the requested variant, got %v", c)
+	}
+
+	th.SetMode("neon")
+	if th.GetMode() != THEME_DARK || th.GetVariant() != theme.VariantDark {
+		t.Errorf("Expected unknown mode to fall back to dark, got %s", th.GetMode())
+	}
+}
+
+func TestAppThemeSetPalette(t *testing.T) {
+	th := &appTheme{}
+	th.Init()
+
+	err := th.SetPalette([]byte(`{
+		"colors": {
+			"dark": {"panelBG": "#102030", "red": "not-a-color"},
+			"light": {"panelBG": "#f0f0f0cc", "background": "#fff"}
+		},
+		"sizes": {"panelWidth": 360, "tickerHeight": -1, "paddingPanelLeft": "wide"}
+	}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if c := th.GetColor(ColorNamePanelBG); c != (color.RGBA{0x10, 0x20, 0x30, 0xff}) {
+		t.Errorf("Expected dark panel override, got %v", c)
+	}
+	if c := th.GetColor(ColorNameRed); c != (color.RGBA{R: 133, G: 36, B: 36, A: 255}) {
+		t.Errorf("Expected invalid color to keep the default, got %v", c)
+	}
+	if size := th.Size(SizePanelWidth); size != 360 {
+		t.Errorf("Expected panel width override, got %f", size)
+	}
+	if size := th.Size(SizeTickerHeight); size != 46 {
+		t.Errorf("Expected negative size to keep the default, got %f", size)
+	}
+	if size := th.Size(SizePaddingPanelLeft); size != 6 {
+		t.Errorf("Expected non numeric size to keep the default, got %f", size)
+	}
+
+	th.SetMode(THEME_LIGHT)
+	if c := th.GetColor(ColorNamePanelBG); c != (color.RGBA{0xf0, 0xf0, 0xf0, 0xcc}) {
+		t.Errorf("Expected light panel override, got %v", c)
+	}
+
+	if err := th.SetPalette([]byte(`{"colors": [`)); err == nil {
+		t.Error("Expected malformed palette to fail")
+	}
+
+	if err := th.SetPalette(nil); err != nil || th.Size(SizePanelWidth) != 320 {
+		t.Errorf("Expected empty palette to clear overrides, got %v", err)
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected color.Color
+		ok       bool
+	}{
+		{"#fff", color.RGBA{255, 255, 255, 255}, true},
+		{"#336699", color.RGBA{0x33, 0x66, 0x99, 0xff}, true},
+		{" 33669980 ", color.RGBA{0x33, 0x66, 0x99, 0x80}, true},
+		{"#12345", nil, false},
+		{"#zzzzzz", nil, false},
+		{"", nil, false},
+	}
+
+	for _, tt := range tests {
+		c, ok := ParseHexColor(tt.input)
+		if ok != tt.ok || c != tt.expected {
+			t.Errorf("ParseHexColor(%q) = %v, %v; want %v, %v", tt.input, c, ok, tt.expected, tt.ok)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"sort"
 	"sync"
 	"time"
 )
@@ -178,6 +179,19 @@ func (w *worker) GetLastUpdate(key string) time.Time {
 	return time.Time{}
 }
 
+func (w *worker) GetKeys() []string {
+	keys := []string{}
+
+	w.units.Range(func(key, _ any) bool {
+		keys = append(keys, key.(string))
+		return true
+	})
+
+	sort.Strings(keys)
+
+	return keys
+}
+
 func (w *worker) Destroy() {
 	if !w.state.CompareAndChange(STATE_RUNNING, STATE_DESTROYED) &&
 		!w.state.CompareAndChange(STATE_PAUSED, STATE_DESTROYED) {
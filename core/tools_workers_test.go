@@ -151,6 +151,22 @@ func TestWorkerLastUpdate(t *testing.T) {
 	stopWorker(w, "last_update", 20)
 }
 
+func TestWorkerGetKeys(t *testing.T) {
+	w := &worker{}
+	w.Init()
+
+	for _, key := range []string{"worker_b", "worker_a"} {
+		w.Register(key, 1, nil, func() int64 { return 1000 }, func(payload any) bool { return true }, nil)
+	}
+
+	keys := w.GetKeys()
+	if len(keys) != 2 || keys[0] != "worker_a" || keys[1] != "worker_b" {
+		t.Errorf("Expected sorted worker keys, got %v", keys)
+	}
+
+	w.Destroy()
+}
+
 func TestWorkerDestroy(t *testing.T) {
 	w := &worker{}
 	w.Init()
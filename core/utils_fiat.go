@@ -0,0 +1,92 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type FiatType struct {
+	Code     string
+	Id       int64
+	Symbol   string
+	Suffix   bool
+	Decimals int
+}
+
+// CoinMarketCap fiat ids, usable as conversion targets on the exchange endpoint
+var fiatCurrencies = map[string]FiatType{
+	"USD": {Code: "USD", Id: FIAT_USD_ID, Symbol: STRING_DOLLAR, Decimals: 2},
+	"AUD": {Code: "AUD", Id: 2782, Symbol: "A$", Decimals: 2},
+	"BRL": {Code: "BRL", Id: 2783, Symbol: "R$", Decimals: 2},
+	"CAD": {Code: "CAD", Id: 2784, Symbol: "C$", Decimals: 2},
+	"CHF": {Code: "CHF", Id: 2785, Symbol: "CHF", Suffix: true, Decimals: 2},
+	"CNY": {Code: "CNY", Id: 2787, Symbol: "¥", Decimals: 2},
+	"EUR": {Code: "EUR", Id: 2790, Symbol: "€", Decimals: 2},
+	"GBP": {Code: "GBP", Id: 2791, Symbol: "£", Decimals: 2},
+	"HKD": {Code: "HKD", Id: 2792, Symbol: "HK$", Decimals: 2},
+	"IDR": {Code: "IDR", Id: 2794, Symbol: "Rp", Decimals: 0},
+	"INR": {Code: "INR", Id: 2796, Symbol: "₹", Decimals: 2},
+	"JPY": {Code: "JPY", Id: 2797, Symbol: "¥", Decimals: 0},
+	"KRW": {Code: "KRW", Id: 2798, Symbol: "₩", Decimals: 0},
+	"PLN": {Code: "PLN", Id: 2805, Symbol: "zł", Suffix: true, Decimals: 2},
+	"SEK": {Code: "SEK", Id: 2807, Symbol: "kr", Suffix: true, Decimals: 2},
+	"SGD": {Code: "SGD", Id: 2808, Symbol: "S$", Decimals: 2},
+}
+
+func GetFiat(code string) (FiatType, bool) {
+	fiat, ok := fiatCurrencies[strings.ToUpper(strings.TrimSpace(code))]
+	return fiat, ok
+}
+
+func GetFiatCodes() []string {
+	codes := make([]string, 0, len(fiatCurrencies))
+	for code := range fiatCurrencies {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	return codes
+}
+
+func (f FiatType) IsUSD() bool {
+	return f.Id == FIAT_USD_ID
+}
+
+func (f FiatType) Place(value string) string {
+	if f.Suffix {
+		return value + " " + f.Symbol
+	}
+
+	return f.Symbol + value
+}
+
+func FormatFiat(value float64, fiat FiatType) string {
+	if value < 0 {
+		return STRING_MINUS + fiat.Place(FormatNumberWithCommas(math.Abs(value), fiat.Decimals))
+	}
+
+	return fiat.Place(FormatNumberWithCommas(value, fiat.Decimals))
+}
+
+func FormatShortFiat(value string, fiat FiatType) string {
+	num, err := strconv.ParseFloat(strings.TrimSpace(strings.Replace(value, fiat.Symbol, STRING_EMPTY, 1)), 64)
+	if err != nil {
+		return value // fallback if parsing fails
+	}
+
+	switch {
+	case num >= 1_000_000_000_000:
+		return fiat.Place(LocalizeDecimal(fmt.Sprintf(FMT_SHORT_TRILLION, num/1_000_000_000_000)))
+	case num >= 1_000_000_000:
+		return fiat.Place(LocalizeDecimal(fmt.Sprintf(FMT_SHORT_BILLION, num/1_000_000_000)))
+	case num >= 1_000_000:
+		return fiat.Place(LocalizeDecimal(fmt.Sprintf(FMT_SHORT_MILLION, num/1_000_000)))
+	case num >= 1_000:
+		return fiat.Place(LocalizeDecimal(fmt.Sprintf(FMT_SHORT_THOUSAND, num/1_000)))
+	default:
+		return fiat.Place(LocalizeDecimal(fmt.Sprintf(FMT_SHORT, num)))
+	}
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestGetFiat(t *testing.T) {
+	tests := []struct {
+		input    string
+		id       int64
+		expected bool
+	}{
+		{"USD", FIAT_USD_ID, true},
+		{"eur", 2790, true},
+		{" JPY ", 2797, true},
+		{"XYZ", 0, false},
+		{STRING_EMPTY, 0, false},
+	}
+
+	for _, tt := range tests {
+		fiat, ok := GetFiat(tt.input)
+		if ok != tt.expected || fiat.Id != tt.id {
+			t.Errorf("GetFiat(%q) = %d, %v; want %d, %v", tt.input, fiat.Id, ok, tt.id, tt.expected)
+		}
+	}
+
+	codes := GetFiatCodes()
+	if len(codes) != len(fiatCurrencies) || codes[0] != "AUD" {
+		t.Errorf("GetFiatCodes() = %v; want sorted codes", codes)
+	}
+}
+
+func TestFormatFiat(t *testing.T) {
+	eur, _ := GetFiat("EUR")
+	jpy, _ := GetFiat("JPY")
+	sek, _ := GetFiat("SEK")
+
+	tests := []struct {
+		value    float64
+		fiat     FiatType
+		expected string
+	}{
+		{1234.56, eur, "€1,234.56"},
+		{-1234.56, eur, "-€1,234.56"},
+		{1234567.8, jpy, "¥1,234,568"},
+		{1234.5, sek, "1,234.5 kr"},
+	}
+
+	for _, tt := range tests {
+		got := FormatFiat(tt.value, tt.fiat)
+		if got != tt.expected {
+			t.Errorf("FormatFiat(%v, %s) = %s; want %s", tt.value, tt.fiat.Code, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatShortFiat(t *testing.T) {
+	gbp, _ := GetFiat("GBP")
+	idr, _ := GetFiat("IDR")
+	pln, _ := GetFiat("PLN")
+
+	tests := []struct {
+		input    string
+		fiat     FiatType
+		expected string
+	}{
+		{"£1234567", gbp, "£1.23M"},
+		{"1234567890", idr, "Rp1.23B"},
+		{"1234", pln, "1.23K zł"},
+		{"12 zł", pln, "12.00 zł"},
+		{"not-a-number", gbp, "not-a-number"},
+	}
+
+	for _, tt := range tests {
+		got := FormatShortFiat(tt.input, tt.fiat)
+		if got != tt.expected {
+			t.Errorf("FormatShortFiat(%s, %s) = %s; want %s", tt.input, tt.fiat.Code, got, tt.expected)
+		}
+	}
+}
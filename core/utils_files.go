@@ -3,9 +3,11 @@ package core
 import (
 	"bytes"
 	"encoding/gob"
+	"io"
 	"os"
 	"os/user"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"fyne.io/fyne/v2"
@@ -23,7 +25,7 @@ func DeleteFile(path string) bool {
 		return false
 	}
 
-	if err := storage.Delete(fileURI); err != nil {
+	if err := deleteStorageFile(fileURI); err != nil {
 		Logf("Error deleting file: %v", err)
 		return false
 	}
@@ -43,7 +45,7 @@ func CreateFile(path string, textString string) bool {
 		return false
 	}
 
-	writer, err := storage.Writer(fileURI)
+	writer, err := openStorageWriter(fileURI)
 	if err != nil {
 		Logf("Error creating writer: %v", err)
 		return false
@@ -51,6 +53,10 @@ func CreateFile(path string, textString string) bool {
 
 	defer writer.Close()
 
+	if coreFileWatcher != nil {
+		coreFileWatcher.Remember(fileURI.Path(), []byte(textString))
+	}
+
 	_, err = writer.Write([]byte(textString))
 	if err != nil {
 		Logf("Error writing to file: %v", err)
@@ -70,7 +76,7 @@ func FileExists(path string) (bool, error) {
 		return false, err
 	}
 
-	reader, err := storage.Reader(fileURI)
+	reader, err := openStorageReader(fileURI)
 	if err != nil {
 		return false, err
 	}
@@ -96,6 +102,34 @@ func BuildPathRelatedToUserDirectory(additionalPath []string) string {
 	return uri.String()
 }
 
+// Headless runs without a fyne app, so no storage repository is registered and files are accessed directly
+func openStorageReader(fileURI fyne.URI) (io.ReadCloser, error) {
+	if fyne.CurrentApp() == nil {
+		return os.Open(fileURI.Path())
+	}
+
+	return storage.Reader(fileURI)
+}
+
+func openStorageWriter(fileURI fyne.URI) (io.WriteCloser, error) {
+	if fyne.CurrentApp() == nil {
+		if err := os.MkdirAll(filepath.Dir(fileURI.Path()), 0755); err != nil {
+			return nil, err
+		}
+		return os.Create(fileURI.Path())
+	}
+
+	return storage.Writer(fileURI)
+}
+
+func deleteStorageFile(fileURI fyne.URI) error {
+	if fyne.CurrentApp() == nil {
+		return os.Remove(fileURI.Path())
+	}
+
+	return storage.Delete(fileURI)
+}
+
 var userDirectory string = ""
 
 func GetUserDirectory() string {
@@ -160,7 +194,7 @@ func LoadFileFromStorage(filename string) (string, bool) {
 		return STRING_EMPTY, false
 	}
 
-	reader, err := storage.Reader(fileURI)
+	reader, err := openStorageReader(fileURI)
 	if err != nil {
 		Logln("Failed to open", filename, err)
 		return STRING_EMPTY, false
@@ -181,6 +215,56 @@ func EraseFileFromStorage(filename string) bool {
 	return DeleteFile(path)
 }
 
+func AppendFileToStorage(filename string, content string) bool {
+	fileURI, err := storage.ParseURI(BuildPathRelatedToUserDirectory([]string{filename}))
+	if err != nil {
+		Logln("Error parsing URI for", filename, err)
+		return false
+	}
+
+	fullPath := fileURI.Path()
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		Logln("Failed to create directory for", filename, err)
+		return false
+	}
+
+	file, err := os.OpenFile(fullPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		Logln("Failed to open", filename, err)
+		return false
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(content); err != nil {
+		Logln("Failed to append to", filename, err)
+		return false
+	}
+
+	return true
+}
+
+func ListFilesFromStorage(dirname string) []string {
+	dirURI, err := storage.ParseURI(BuildPathRelatedToUserDirectory([]string{dirname}))
+	if err != nil {
+		Logln("Error parsing URI for", dirname, err)
+		return nil
+	}
+
+	entries, err := os.ReadDir(dirURI.Path())
+	if err != nil {
+		return nil
+	}
+
+	files := []string{}
+	for _, entry := range entries {
+		files = append(files, entry.Name())
+	}
+
+	sort.Strings(files)
+
+	return files
+}
+
 func SaveGobToStorage(filename string, data any) bool {
 	var buf bytes.Buffer
 	encoder := gob.NewEncoder(&buf)
@@ -198,7 +282,7 @@ func LoadGobFromStorage(filename string, out interface{}) bool {
 		return false
 	}
 
-	reader, err := storage.Reader(fileURI)
+	reader, err := openStorageReader(fileURI)
 	if err != nil {
 		Logln("Failed to open", filename, err)
 		return false
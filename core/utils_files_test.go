@@ -8,6 +8,7 @@ import (
 
 	json "github.com/goccy/go-json"
 
+	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 )
 
@@ -156,3 +157,67 @@ func TestSaveAndLoadGob(t *testing.T) {
 		t.Errorf("Failed to erase test GOB file: %s", filename)
 	}
 }
+
+func TestAppendAndListFiles(t *testing.T) {
+	filesTurnOffLogs()
+	defer filesTurnOnLogs()
+
+	dirname := "test_append_dir"
+	defer os.RemoveAll(strings.TrimPrefix(BuildPathRelatedToUserDirectory([]string{dirname}), "file://"))
+
+	if !AppendFileToStorage(dirname+"/b.txt", "first\n") {
+		t.Fatal("Failed to append to new file")
+	}
+	if !AppendFileToStorage(dirname+"/b.txt", "second\n") {
+		t.Fatal("Failed to append to existing file")
+	}
+	if !AppendFileToStorage(dirname+"/a.txt", "other\n") {
+		t.Fatal("Failed to append to second file")
+	}
+
+	content, ok := LoadFileFromStorage(dirname + "/b.txt")
+	if !ok {
+		t.Fatal("Failed to load appended file")
+	}
+	if content != "first\nsecond\n" {
+		t.Errorf("Unexpected appended content: %q", content)
+	}
+
+	files := ListFilesFromStorage(dirname)
+	if len(files) != 2 || files[0] != "a.txt" || files[1] != "b.txt" {
+		t.Errorf("Expected sorted [a.txt b.txt], got %v", files)
+	}
+
+	if missing := ListFilesFromStorage("test_missing_dir"); len(missing) != 0 {
+		t.Errorf("Expected no files for missing directory, got %v", missing)
+	}
+}
+
+func TestStorageWithoutApp(t *testing.T) {
+	filesTurnOffLogs()
+	defer filesTurnOnLogs()
+
+	previousApp := fyne.CurrentApp()
+	fyne.SetCurrentApp(nil)
+	defer fyne.SetCurrentApp(previousApp)
+
+	previous := userDirectory
+	userDirectory = t.TempDir()
+	defer func() { userDirectory = previous }()
+
+	if !SaveFileToStorage("headless/state.json", []byte(`{"ready":true}`)) {
+		t.Fatal("Expected file saved without a fyne app")
+	}
+
+	content, ok := LoadFileFromStorage("headless/state.json")
+	if !ok || content != `{"ready":true}` {
+		t.Errorf("Expected saved content loaded, got %q", content)
+	}
+
+	if !EraseFileFromStorage("headless/state.json") {
+		t.Error("Expected file erased without a fyne app")
+	}
+	if _, ok := LoadFileFromStorage("headless/state.json"); ok {
+		t.Error("Expected erased file to be gone")
+	}
+}
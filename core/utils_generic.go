@@ -105,7 +105,7 @@ func TraceGoroutines() {
 }
 
 func Notify(msg string) {
-	UseWorker().Push(ACT_NOTIFICATION_PUSH, msg)
+	UseWorker().Push(ACT_NOTIFICATION_PUSH, Translate(msg))
 }
 
 func EqualStringSlices(a, b []string) bool {
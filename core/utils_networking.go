@@ -1,7 +1,9 @@
 package core
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"crypto/x509"
 	"errors"
 	"io"
@@ -11,6 +13,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 )
@@ -32,9 +35,13 @@ var httpClient = &http.Client{
 	},
 }
 
-func GetRequest(ctx context.Context, targetUrl string, prefetch func(url url.Values, req *http.Request), callback func(ctx context.Context, resp *http.Response) int64) int64 {
+func GetRequest(ctx context.Context, targetUrl string, prefetch func(url url.Values, req *http.Request), callback func(ctx context.Context, resp *http.Response) int64) (code int64) {
 	PrintPerfStats("Network Fetching Request", time.Now())
 
+	defer func() {
+		recordNetworkingOutcome(code)
+	}()
+
 	parsedURL, err := url.Parse(targetUrl)
 	if err != nil {
 		Logln("Network Invalid URL:", err)
@@ -78,7 +85,13 @@ func GetRequest(ctx context.Context, targetUrl string, prefetch func(url url.Val
 	}
 
 	req.URL.RawQuery = q.Encode()
-	resp, err := httpClient.Do(req)
+
+	if code := coreQuota.Acquire(ctx, req.URL.Hostname()); code != NETWORKING_SUCCESS {
+		return code
+	}
+
+	client := useHttpClient()
+	resp, err := client.Do(req)
 
 	// Logf("Network Fetching data from %v [%d]", req.URL, resp.StatusCode)
 
@@ -87,66 +100,20 @@ func GetRequest(ctx context.Context, targetUrl string, prefetch func(url url.Val
 			resp.Body.Close()
 		}
 
-		if tr, ok := httpClient.Transport.(*http.Transport); ok {
+		if tr, ok := client.Transport.(*http.Transport); ok {
 			tr.CloseIdleConnections()
 		}
 
-		var dnsErr *net.DNSError
-		if errors.As(err, &dnsErr) {
-			if dnsErr.IsNotFound || dnsErr.IsTemporary {
-				Logln("Network DNS error:", dnsErr)
-				return NETWORKING_NO_INTERNET
-			}
-		}
-
-		var opErr *net.OpError
-		if errors.As(err, &opErr) {
-			if opErr.Timeout() {
-				Logln("Network Connection timeout:", opErr)
-				return NETWORKING_ERROR_FIREWALL
-			}
-			if errors.Is(opErr.Err, syscall.ECONNREFUSED) {
-				Logln("Network Connection refused:", opErr)
-				return NETWORKING_ERROR_FIREWALL
-			}
-		}
-
-		var urlErr *url.Error
-		if errors.As(err, &urlErr) {
-			var opErr *net.OpError
-			if errors.As(urlErr.Err, &opErr) {
-				if opErr.Timeout() || errors.Is(opErr.Err, syscall.ECONNREFUSED) {
-					Logln("Network Connection refused/timeout inside url.Error:", opErr)
-					return NETWORKING_ERROR_FIREWALL
-				}
-			}
-
-			var hostnameErr x509.HostnameError
-			if errors.As(urlErr.Err, &hostnameErr) {
-				Logln("Network TLS hostname mismatch:", hostnameErr)
-				return NETWORKING_ERROR_CONNECTION
-			}
-
-			var unknownAuthErr x509.UnknownAuthorityError
-			if errors.As(urlErr.Err, &unknownAuthErr) {
-				Logln("Network TLS unknown authority:", unknownAuthErr)
-				return NETWORKING_BAD_CONFIG
-			}
-
-			if strings.Contains(urlErr.Err.Error(), "tls") {
-				Logln("Network TLS handshake/network error:", urlErr.Err)
-				return NETWORKING_ERROR_CONNECTION
-			}
-		}
-
 		Logf("Network Failed to fetch data: %v", err)
 
-		return NETWORKING_ERROR_CONNECTION
+		coreQuota.Refund(req.URL.Hostname())
+
+		return detectRequestError(err)
 	}
 
 	defer resp.Body.Close()
 
-	if tr, ok := httpClient.Transport.(*http.Transport); ok {
+	if tr, ok := client.Transport.(*http.Transport); ok {
 		defer tr.CloseIdleConnections()
 	}
 
@@ -164,8 +131,13 @@ func GetRequest(ctx context.Context, targetUrl string, prefetch func(url url.Val
 		Logf("Network Error %d: Client/config issue", resp.StatusCode)
 		return NETWORKING_BAD_CONFIG
 
+	case 407:
+		Logf("Network Error %d: Proxy authentication required", resp.StatusCode)
+		return NETWORKING_PROXY_ERROR
+
 	case 429:
 		Logf("Network Error %d: Too Many Requests Rate limit exceeded", resp.StatusCode)
+		coreQuota.Block(req.URL.Hostname(), ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()))
 		return NETWORKING_RATE_LIMIT
 
 	case 500, 502, 503, 504:
@@ -178,6 +150,229 @@ func GetRequest(ctx context.Context, targetUrl string, prefetch func(url url.Val
 	}
 }
 
+func PostRequest(ctx context.Context, targetUrl string, body []byte, headers map[string]string) int64 {
+	PrintPerfStats("Network Posting Request", time.Now())
+
+	parsedURL, err := url.Parse(targetUrl)
+	if err != nil || parsedURL.Scheme == STRING_EMPTY || parsedURL.Host == STRING_EMPTY {
+		Logln("Network Invalid URL:", targetUrl)
+		return NETWORKING_URL_ERROR
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if ctx.Err() != nil {
+		return NETWORKING_ERROR_CONNECTION
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", parsedURL.String(), bytes.NewReader(body))
+	if err != nil {
+		Logln("Network Error creating request:", err)
+		return NETWORKING_ERROR_CONNECTION
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := useHttpClient().Do(req)
+	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		Logf("Network Failed to post data: %v", err)
+		return detectRequestError(err)
+	}
+
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return NETWORKING_SUCCESS
+
+	case resp.StatusCode == 429:
+		Logf("Network Error %d: Too Many Requests Rate limit exceeded", resp.StatusCode)
+		return NETWORKING_RATE_LIMIT
+
+	case resp.StatusCode == 407:
+		Logf("Network Error %d: Proxy authentication required", resp.StatusCode)
+		return NETWORKING_PROXY_ERROR
+
+	case resp.StatusCode == 401 || resp.StatusCode == 403:
+		Logf("Network Error %d: Unauthorized", resp.StatusCode)
+		return NETWORKING_UNAUTHORIZED
+
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		Logf("Network Error %d: Client/config issue", resp.StatusCode)
+		return NETWORKING_BAD_CONFIG
+
+	default:
+		Logf("Network Error %d: Request failed", resp.StatusCode)
+		return NETWORKING_ERROR_CONNECTION
+	}
+}
+
+func detectRequestError(err error) int64 {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && (opErr.Op == "proxyconnect" || strings.HasPrefix(opErr.Op, "socks")) {
+		Logln("Network Proxy error:", opErr)
+		return NETWORKING_PROXY_ERROR
+	}
+
+	if errors.Is(err, ErrPinnedKeyMismatch) {
+		Logln("Network TLS pinned key mismatch:", err)
+		return NETWORKING_TLS_ERROR
+	}
+
+	var hostnameErr x509.HostnameError
+	if errors.As(err, &hostnameErr) {
+		Logln("Network TLS hostname mismatch:", hostnameErr)
+		return NETWORKING_TLS_ERROR
+	}
+
+	var unknownAuthErr x509.UnknownAuthorityError
+	if errors.As(err, &unknownAuthErr) {
+		Logln("Network TLS unknown authority:", unknownAuthErr)
+		return NETWORKING_TLS_ERROR
+	}
+
+	var invalidCertErr x509.CertificateInvalidError
+	if errors.As(err, &invalidCertErr) {
+		Logln("Network TLS invalid certificate:", invalidCertErr)
+		return NETWORKING_TLS_ERROR
+	}
+
+	var verifyErr *tls.CertificateVerificationError
+	if errors.As(err, &verifyErr) {
+		Logln("Network TLS verification failed:", verifyErr)
+		return NETWORKING_TLS_ERROR
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		if dnsErr.IsNotFound || dnsErr.IsTemporary {
+			Logln("Network DNS error:", dnsErr)
+			return NETWORKING_NO_INTERNET
+		}
+	}
+
+	if opErr != nil {
+		if opErr.Timeout() {
+			Logln("Network Connection timeout:", opErr)
+			return NETWORKING_ERROR_FIREWALL
+		}
+		if errors.Is(opErr.Err, syscall.ECONNREFUSED) {
+			Logln("Network Connection refused:", opErr)
+			return NETWORKING_ERROR_FIREWALL
+		}
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && strings.Contains(urlErr.Err.Error(), "tls") {
+		Logln("Network TLS handshake/network error:", urlErr.Err)
+		return NETWORKING_TLS_ERROR
+	}
+
+	return NETWORKING_ERROR_CONNECTION
+}
+
+var networkingOutcomes sync.Map
+
+func recordNetworkingOutcome(code int64) {
+	counter, _ := networkingOutcomes.LoadOrStore(code, &atomic.Int64{})
+	counter.(*atomic.Int64).Add(1)
+}
+
+func GetNetworkingOutcomes() map[int64]int64 {
+	outcomes := make(map[int64]int64)
+
+	networkingOutcomes.Range(func(key, value any) bool {
+		outcomes[key.(int64)] = value.(*atomic.Int64).Load()
+		return true
+	})
+
+	return outcomes
+}
+
+// Quota skips never reached the server, so they say nothing about the network or the config
+func DetectHTTPResponse(rs int64) int {
+
+	switch rs {
+	case NETWORKING_SUCCESS:
+		return STATUS_SUCCESS
+
+	case NETWORKING_QUOTA_EXCEEDED:
+		return STATUS_SKIPPED
+
+	case NETWORKING_ERROR_CONNECTION, NETWORKING_RATE_LIMIT, NETWORKING_ERROR_FIREWALL, NETWORKING_NO_INTERNET:
+		return STATUS_NETWORK_ERROR
+
+	case NETWORKING_BAD_CONFIG, NETWORKING_URL_ERROR, NETWORKING_TLS_ERROR, NETWORKING_PROXY_ERROR:
+		return STATUS_CONFIG_ERROR
+
+	case NETWORKING_BAD_DATA_RECEIVED, NETWORKING_DATA_IN_CACHE, NETWORKING_BAD_PAYLOAD, NETWORKING_FAILED_CREATE_FILE:
+		return STATUS_BAD_DATA_RECEIVED
+
+	}
+
+	return STATUS_SUCCESS
+}
+
+// Keeps the worst status of a batch, a skipped fetch only counts when nothing else ran
+func MergeHTTPStatus(current int, next int) int {
+	if current == STATUS_SKIPPED {
+		return next
+	}
+
+	if next == STATUS_SKIPPED {
+		return current
+	}
+
+	return max(current, next)
+}
+
+func GetNetworkingCodeName(code int64) string {
+	switch code {
+	case NETWORKING_SUCCESS:
+		return "success"
+	case NETWORKING_ERROR_CONNECTION:
+		return "error_connection"
+	case NETWORKING_URL_ERROR:
+		return "url_error"
+	case NETWORKING_DATA_IN_CACHE:
+		return "data_in_cache"
+	case NETWORKING_UNAUTHORIZED:
+		return "unauthorized"
+	case NETWORKING_BAD_DATA_RECEIVED:
+		return "bad_data_received"
+	case NETWORKING_BAD_CONFIG:
+		return "bad_config"
+	case NETWORKING_BAD_PAYLOAD:
+		return "bad_payload"
+	case NETWORKING_FAILED_CREATE_FILE:
+		return "failed_create_file"
+	case NETWORKING_ERROR_FIREWALL:
+		return "error_firewall"
+	case NETWORKING_NO_INTERNET:
+		return "no_internet"
+	case NETWORKING_RATE_LIMIT:
+		return "rate_limit"
+	case NETWORKING_TLS_ERROR:
+		return "tls_error"
+	case NETWORKING_PROXY_ERROR:
+		return "proxy_error"
+	case NETWORKING_QUOTA_EXCEEDED:
+		return "quota_exceeded"
+	}
+
+	return "unknown"
+}
+
 var networkingBufPools sync.Map
 
 func getNetworkingBufferPool(key string, size int) *sync.Pool {
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -82,3 +85,77 @@ func TestFetcher_UsesPayload(t *testing.T) {
 		}
 	})
 }
+
+func TestPostRequest(t *testing.T) {
+	var gotBody, gotType, gotHeader string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		gotType = r.Header.Get("Content-Type")
+		gotHeader = r.Header.Get("X-Token")
+
+		switch r.URL.Path {
+		case "/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/broken":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	code := PostRequest(context.Background(), server.URL+"/hook", []byte(`{"a":1}`), map[string]string{"X-Token": "secret"})
+	if code != NETWORKING_SUCCESS {
+		t.Errorf("expected success, got %d", code)
+	}
+	if gotBody != `{"a":1}` || gotType != "application/json" || gotHeader != "secret" {
+		t.Errorf("unexpected request body=%q type=%q header=%q", gotBody, gotType, gotHeader)
+	}
+
+	if code := PostRequest(context.Background(), server.URL+"/limited", nil, nil); code != NETWORKING_RATE_LIMIT {
+		t.Errorf("expected rate limit, got %d", code)
+	}
+	if code := PostRequest(context.Background(), server.URL+"/broken", nil, nil); code != NETWORKING_ERROR_CONNECTION {
+		t.Errorf("expected connection error, got %d", code)
+	}
+	if code := PostRequest(context.Background(), "not a url", nil, nil); code != NETWORKING_URL_ERROR {
+		t.Errorf("expected url error, got %d", code)
+	}
+}
+
+func TestGetRequestOutcomes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/limited" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	before := GetNetworkingOutcomes()
+
+	GetRequest(context.Background(), server.URL+"/ok", nil, nil)
+	GetRequest(context.Background(), server.URL+"/ok", nil, func(ctx context.Context, resp *http.Response) int64 {
+		return NETWORKING_BAD_DATA_RECEIVED
+	})
+	GetRequest(context.Background(), server.URL+"/limited", nil, nil)
+
+	after := GetNetworkingOutcomes()
+
+	for code, expected := range map[int64]int64{
+		NETWORKING_SUCCESS:           1,
+		NETWORKING_BAD_DATA_RECEIVED: 1,
+		NETWORKING_RATE_LIMIT:        1,
+	} {
+		if got := after[code] - before[code]; got != expected {
+			t.Errorf("expected %d %s outcomes, got %d", expected, GetNetworkingCodeName(code), got)
+		}
+	}
+
+	if GetNetworkingCodeName(NETWORKING_RATE_LIMIT) != "rate_limit" || GetNetworkingCodeName(42) != "unknown" {
+		t.Error("unexpected networking code names")
+	}
+}
@@ -0,0 +1,236 @@
+package core
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrPinnedKeyMismatch = errors.New("certificate does not match pinned keys")
+
+var httpClientMu sync.RWMutex
+
+type NetworkingConfigType struct {
+	Proxy         string
+	ProxyUsername string
+	ProxyPassword string
+	CABundle      string
+	PinnedKeys    map[string][]string
+}
+
+func (n NetworkingConfigType) Validate() error {
+	_, err := newHttpTransport(n)
+	return err
+}
+
+func ConfigureNetworking(cfg NetworkingConfigType) error {
+	transport, err := newHttpTransport(cfg)
+	if err != nil {
+		return err
+	}
+
+	httpClientMu.Lock()
+	previous := httpClient
+	httpClient = &http.Client{
+		Timeout:   120 * time.Second,
+		Transport: transport,
+	}
+	httpClientMu.Unlock()
+
+	if tr, ok := previous.Transport.(*http.Transport); ok {
+		tr.CloseIdleConnections()
+	}
+
+	return nil
+}
+
+func useHttpClient() *http.Client {
+	httpClientMu.RLock()
+	defer httpClientMu.RUnlock()
+	return httpClient
+}
+
+func newHttpTransport(cfg NetworkingConfigType) (*http.Transport, error) {
+	transport := &http.Transport{
+		DisableKeepAlives:     false,
+		MaxIdleConns:          NETWORKING_MAXIMUM_CONNECTION,
+		MaxIdleConnsPerHost:   NETWORKING_MAXIMUM_CONNECTION,
+		IdleConnTimeout:       30 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+		TLSHandshakeTimeout:   15 * time.Second,
+		ExpectContinueTimeout: 5 * time.Second,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+	}
+
+	if strings.TrimSpace(cfg.Proxy) != STRING_EMPTY {
+		proxyURL, err := ParseProxyURL(cfg.Proxy, cfg.ProxyUsername, cfg.ProxyPassword)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if strings.TrimSpace(cfg.CABundle) == STRING_EMPTY && len(cfg.PinnedKeys) == 0 {
+		return transport, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if strings.TrimSpace(cfg.CABundle) != STRING_EMPTY {
+		pool, err := LoadCABundle(cfg.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(cfg.PinnedKeys) != 0 {
+		pins, err := normalizePinnedKeys(cfg.PinnedKeys)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+			return verifyPinnedKeys(pins, cs)
+		}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+func ParseProxyURL(raw string, username string, password string) (*url.URL, error) {
+	proxyURL, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || proxyURL.Host == STRING_EMPTY {
+		return nil, fmt.Errorf("invalid proxy url")
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q", proxyURL.Scheme)
+	}
+
+	if username != STRING_EMPTY {
+		proxyURL.User = url.UserPassword(username, password)
+	}
+
+	return proxyURL, nil
+}
+
+func LoadCABundle(path string) (*x509.CertPool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ca bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(content) {
+		return nil, fmt.Errorf("no certificates found in ca bundle %s", path)
+	}
+
+	return pool, nil
+}
+
+func ParsePinnedKey(pin string) (string, error) {
+	pin = strings.TrimPrefix(strings.TrimSpace(pin), "sha256/")
+
+	raw, err := base64.StdEncoding.DecodeString(pin)
+	if err != nil || len(raw) != sha256.Size {
+		return STRING_EMPTY, fmt.Errorf("invalid pinned key %q", pin)
+	}
+
+	return pin, nil
+}
+
+func GetPinnedKey(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return "sha256/" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func normalizePinnedKeys(pinnedKeys map[string][]string) (map[string]map[string]bool, error) {
+	pins := make(map[string]map[string]bool, len(pinnedKeys))
+
+	for host, keys := range pinnedKeys {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host == STRING_EMPTY {
+			return nil, fmt.Errorf("pinned keys without host")
+		}
+
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("no pinned keys for %s", host)
+		}
+
+		pins[host] = make(map[string]bool, len(keys))
+		for _, key := range keys {
+			pin, err := ParsePinnedKey(key)
+			if err != nil {
+				return nil, err
+			}
+			pins[host][pin] = true
+		}
+	}
+
+	return pins, nil
+}
+
+func matchPinnedHost(pins map[string]map[string]bool, host string) map[string]bool {
+	host = strings.ToLower(host)
+
+	if expected, ok := pins[host]; ok {
+		return expected
+	}
+
+	for pattern, expected := range pins {
+		if strings.HasPrefix(pattern, "*.") && strings.HasSuffix(host, pattern[1:]) {
+			return expected
+		}
+	}
+
+	return nil
+}
+
+func verifyPinnedKeys(pins map[string]map[string]bool, cs tls.ConnectionState) error {
+	expected := matchPinnedHost(pins, cs.ServerName)
+
+	// IP hosts are not sent as SNI so the server name stays empty
+	if cs.ServerName == STRING_EMPTY && len(cs.PeerCertificates) != 0 {
+		for host, keys := range pins {
+			if net.ParseIP(host) != nil && cs.PeerCertificates[0].VerifyHostname(host) == nil {
+				expected = keys
+				break
+			}
+		}
+	}
+
+	if expected == nil {
+		return nil
+	}
+
+	for _, chain := range cs.VerifiedChains {
+		for _, cert := range chain {
+			if expected[strings.TrimPrefix(GetPinnedKey(cert), "sha256/")] {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w for %s", ErrPinnedKeyMismatch, cs.ServerName)
+}
@@ -0,0 +1,321 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/pem"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+type transportNullWriter struct{}
+
+func (transportNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func transportTurnOffLogs() {
+	log.SetOutput(transportNullWriter{})
+}
+
+func transportTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func resetTestNetworking(t *testing.T) {
+	t.Cleanup(func() {
+		ConfigureNetworking(NetworkingConfigType{})
+	})
+}
+
+func writeTestCABundle(t *testing.T, server *httptest.Server) string {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	content := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		t.Fatalf("Failed to write ca bundle: %v", err)
+	}
+
+	return path
+}
+
+func TestConfigureNetworkingCABundle(t *testing.T) {
+	transportTurnOffLogs()
+	defer transportTurnOnLogs()
+	resetTestNetworking(t)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if code := GetRequest(context.Background(), server.URL, nil, nil); code != NETWORKING_TLS_ERROR {
+		t.Errorf("Expected tls error without the ca bundle, got %s", GetNetworkingCodeName(code))
+	}
+
+	if err := ConfigureNetworking(NetworkingConfigType{CABundle: writeTestCABundle(t, server)}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if code := GetRequest(context.Background(), server.URL, nil, nil); code != NETWORKING_SUCCESS {
+		t.Errorf("Expected success with the ca bundle, got %s", GetNetworkingCodeName(code))
+	}
+
+	if code := PostRequest(context.Background(), server.URL, nil, nil); code != NETWORKING_SUCCESS {
+		t.Errorf("Expected post success with the ca bundle, got %s", GetNetworkingCodeName(code))
+	}
+}
+
+func TestConfigureNetworkingPinnedKeys(t *testing.T) {
+	transportTurnOffLogs()
+	defer transportTurnOnLogs()
+	resetTestNetworking(t)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	bundle := writeTestCABundle(t, server)
+	host, _, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	ConfigureNetworking(NetworkingConfigType{
+		CABundle:   bundle,
+		PinnedKeys: map[string][]string{host: {GetPinnedKey(server.Certificate())}},
+	})
+
+	if code := GetRequest(context.Background(), server.URL, nil, nil); code != NETWORKING_SUCCESS {
+		t.Errorf("Expected success with matching pin, got %s", GetNetworkingCodeName(code))
+	}
+
+	ConfigureNetworking(NetworkingConfigType{
+		CABundle:   bundle,
+		PinnedKeys: map[string][]string{host: {"sha256/47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="}},
+	})
+
+	if code := GetRequest(context.Background(), server.URL, nil, nil); code != NETWORKING_TLS_ERROR {
+		t.Errorf("Expected tls error with mismatching pin, got %s", GetNetworkingCodeName(code))
+	}
+
+	ConfigureNetworking(NetworkingConfigType{
+		CABundle:   bundle,
+		PinnedKeys: map[string][]string{"pro-api.coinmarketcap.com": {"47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="}},
+	})
+
+	if code := GetRequest(context.Background(), server.URL, nil, nil); code != NETWORKING_SUCCESS {
+		t.Errorf("Expected pins for other hosts to be ignored, got %s", GetNetworkingCodeName(code))
+	}
+}
+
+func TestConfigureNetworkingHTTPProxy(t *testing.T) {
+	transportTurnOffLogs()
+	defer transportTurnOnLogs()
+	resetTestNetworking(t)
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer target.Close()
+
+	var proxied atomic.Int64
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Basic auth for user:secret
+		if r.Header.Get("Proxy-Authorization") != "Basic dXNlcjpzZWNyZXQ=" {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+
+		proxied.Add(1)
+
+		resp, err := http.DefaultTransport.RoundTrip(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+
+		w.WriteHeader(resp.StatusCode)
+		io.Copy(w, resp.Body)
+	}))
+	defer proxy.Close()
+
+	ConfigureNetworking(NetworkingConfigType{Proxy: proxy.URL, ProxyUsername: "user", ProxyPassword: "secret"})
+
+	if code := GetRequest(context.Background(), target.URL, nil, nil); code != NETWORKING_SUCCESS {
+		t.Errorf("Expected success through the proxy, got %s", GetNetworkingCodeName(code))
+	}
+	if proxied.Load() != 1 {
+		t.Errorf("Expected request to go through the proxy, got %d", proxied.Load())
+	}
+
+	ConfigureNetworking(NetworkingConfigType{Proxy: proxy.URL, ProxyUsername: "user", ProxyPassword: "wrong"})
+
+	if code := GetRequest(context.Background(), target.URL, nil, nil); code != NETWORKING_PROXY_ERROR {
+		t.Errorf("Expected proxy error with wrong credentials, got %s", GetNetworkingCodeName(code))
+	}
+
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	closed := "http://" + listener.Addr().String()
+	listener.Close()
+
+	ConfigureNetworking(NetworkingConfigType{Proxy: closed})
+
+	if code := GetRequest(context.Background(), target.URL, nil, nil); code != NETWORKING_PROXY_ERROR {
+		t.Errorf("Expected proxy error with unreachable proxy, got %s", GetNetworkingCodeName(code))
+	}
+}
+
+func startTestSOCKS5Proxy(t *testing.T, username string, password string) (string, *atomic.Int64) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	var connected atomic.Int64
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+
+				header := make([]byte, 2)
+				if _, err := io.ReadFull(conn, header); err != nil {
+					return
+				}
+				io.ReadFull(conn, make([]byte, header[1]))
+				conn.Write([]byte{0x05, 0x02})
+
+				// Username and password sub negotiation
+				version := make([]byte, 2)
+				io.ReadFull(conn, version)
+				user := make([]byte, version[1])
+				io.ReadFull(conn, user)
+				size := make([]byte, 1)
+				io.ReadFull(conn, size)
+				pass := make([]byte, size[0])
+				io.ReadFull(conn, pass)
+
+				if string(user) != username || string(pass) != password {
+					conn.Write([]byte{0x01, 0x01})
+					return
+				}
+				conn.Write([]byte{0x01, 0x00})
+
+				request := make([]byte, 4)
+				io.ReadFull(conn, request)
+
+				var host string
+				switch request[3] {
+				case 0x01:
+					addr := make([]byte, 4)
+					io.ReadFull(conn, addr)
+					host = net.IP(addr).String()
+				case 0x03:
+					io.ReadFull(conn, size)
+					addr := make([]byte, size[0])
+					io.ReadFull(conn, addr)
+					host = string(addr)
+				default:
+					return
+				}
+
+				port := make([]byte, 2)
+				io.ReadFull(conn, port)
+
+				upstream, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))))
+				if err != nil {
+					conn.Write([]byte{0x05, 0x05, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+					return
+				}
+				defer upstream.Close()
+
+				connected.Add(1)
+				conn.Write([]byte{0x05, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+
+				go io.Copy(upstream, conn)
+				io.Copy(conn, upstream)
+			}()
+		}
+	}()
+
+	return "socks5://" + listener.Addr().String(), &connected
+}
+
+func TestConfigureNetworkingSOCKS5Proxy(t *testing.T) {
+	transportTurnOffLogs()
+	defer transportTurnOnLogs()
+	resetTestNetworking(t)
+
+	target := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer target.Close()
+
+	proxyURL, connected := startTestSOCKS5Proxy(t, "user", "secret")
+	bundle := writeTestCABundle(t, target)
+
+	ConfigureNetworking(NetworkingConfigType{Proxy: proxyURL, ProxyUsername: "user", ProxyPassword: "secret", CABundle: bundle})
+
+	if code := GetRequest(context.Background(), target.URL, nil, nil); code != NETWORKING_SUCCESS {
+		t.Errorf("Expected success through the socks5 proxy, got %s", GetNetworkingCodeName(code))
+	}
+	if connected.Load() != 1 {
+		t.Errorf("Expected connection through the socks5 proxy, got %d", connected.Load())
+	}
+
+	ConfigureNetworking(NetworkingConfigType{Proxy: proxyURL, ProxyUsername: "user", ProxyPassword: "wrong", CABundle: bundle})
+
+	if code := GetRequest(context.Background(), target.URL, nil, nil); code != NETWORKING_PROXY_ERROR {
+		t.Errorf("Expected proxy error with wrong credentials, got %s", GetNetworkingCodeName(code))
+	}
+}
+
+func TestConfigureNetworkingInvalid(t *testing.T) {
+	resetTestNetworking(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	os.WriteFile(empty, []byte("not a certificate"), 0600)
+
+	for name, cfg := range map[string]NetworkingConfigType{
+		"bad scheme":     {Proxy: "ftp://127.0.0.1:21"},
+		"missing host":   {Proxy: "http://"},
+		"missing bundle": {CABundle: filepath.Join(t.TempDir(), "missing.pem")},
+		"empty bundle":   {CABundle: empty},
+		"bad pin":        {PinnedKeys: map[string][]string{"example.com": {"abc"}}},
+		"no pins":        {PinnedKeys: map[string][]string{"example.com": {}}},
+	} {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+		if err := ConfigureNetworking(cfg); err == nil {
+			t.Errorf("Expected %s to fail configuring", name)
+		}
+	}
+
+	// A rejected configuration keeps the previous client working
+	if code := GetRequest(context.Background(), server.URL, nil, nil); code != NETWORKING_SUCCESS {
+		t.Errorf("Expected previous client kept, got %s", GetNetworkingCodeName(code))
+	}
+
+	if _, err := ParsePinnedKey("sha256/47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="); err != nil {
+		t.Errorf("Expected valid pin, got %v", err)
+	}
+}
@@ -52,18 +52,29 @@ func FormatNumberWithCommas(f float64, frac int) string {
 		fracPart = parts[1]
 	}
 
+	lf := getLocaleFormat()
+
 	n := len(intPart)
 	var out strings.Builder
 	for i := 0; i < n; i++ {
 		if i > 0 && (n-i)%3 == 0 {
-			out.WriteByte(',')
+			out.WriteString(lf.Thousands)
 		}
 		out.WriteByte(intPart[i])
 	}
 
 	if fracPart != STRING_EMPTY {
-		out.WriteByte('.')
+		out.WriteString(lf.Decimal)
 		out.WriteString(fracPart)
 	}
 	return out.String()
 }
+
+// Same as strconv.FormatFloat with the 'f' format, using the decimal separator of the active locale
+func FormatDecimal(f float64, frac int) string {
+	return LocalizeDecimal(strconv.FormatFloat(f, 'f', frac, 64))
+}
+
+func LocalizeDecimal(s string) string {
+	return strings.Replace(s, ".", getLocaleFormat().Decimal, 1)
+}
@@ -1,9 +1,9 @@
 package core
 
 import (
-	"fmt"
 	"image"
 	"image/color"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -115,23 +115,8 @@ func MeasureText(text string, fontSize float32, style fyne.TextStyle) float32 {
 }
 
 func FormatShortCurrency(value string) string {
-	num, err := strconv.ParseFloat(strings.Replace(value, STRING_DOLLAR, STRING_EMPTY, 1), 64)
-	if err != nil {
-		return value // fallback if parsing fails
-	}
-
-	switch {
-	case num >= 1_000_000_000_000:
-		return fmt.Sprintf(FMT_SHORT_TRILLION_DOLLAR, num/1_000_000_000_000)
-	case num >= 1_000_000_000:
-		return fmt.Sprintf(FMT_SHORT_BILLION_DOLLAR, num/1_000_000_000)
-	case num >= 1_000_000:
-		return fmt.Sprintf(FMT_SHORT_MILLION_DOLLAR, num/1_000_000)
-	case num >= 1_000:
-		return fmt.Sprintf(FMT_SHORT_THOUSAND_DOLLAR, num/1_000)
-	default:
-		return fmt.Sprintf(FMT_SHORT_DOLLAR, num)
-	}
+	usd, _ := GetFiat(FIAT_USD)
+	return FormatShortFiat(value, usd)
 }
 
 var extractLeadingRegex = regexp.MustCompile(`^\d+`)
@@ -196,3 +181,82 @@ func RasterizeText(dst *image.NRGBA, text string, textStyle fyne.TextStyle, text
 
 	return dst
 }
+
+func RasterizeSparkline(dst *image.NRGBA, values []float64, width int, height int, stroke float32, col color.Color) *image.NRGBA {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	if dst == nil || width != dst.Bounds().Dx() || height != dst.Bounds().Dy() {
+		dst = image.NewNRGBA(image.Rect(0, 0, width, height))
+	} else {
+		for i := range dst.Pix {
+			dst.Pix[i] = 0
+		}
+	}
+
+	// A single column has no room for a segment
+	if len(values) < 2 || width < 2 {
+		return dst
+	}
+
+	low, high := values[0], values[0]
+	for _, v := range values {
+		low = math.Min(low, v)
+		high = math.Max(high, v)
+	}
+
+	radius := math.Max(float64(stroke)/2, 0.5)
+	top := radius
+	span := float64(height) - 2*radius
+	step := float64(width-1) / float64(len(values)-1)
+
+	points := make([][2]float64, len(values))
+	for i, v := range values {
+		y := top + span/2
+		if high-low > EPSILON {
+			y = top + span*(high-v)/(high-low)
+		}
+		points[i] = [2]float64{float64(i) * step, y}
+	}
+
+	r, g, b, a := col.RGBA()
+	line := color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: uint8(a >> 8)}
+	fill := line
+	fill.A = line.A / 3
+
+	// Area below the line
+	for x := 0; x < width; x++ {
+		seg := min(int(float64(x)/step), len(points)-2)
+		p0, p1 := points[seg], points[seg+1]
+		t := (float64(x) - p0[0]) / (p1[0] - p0[0])
+		y := p0[1] + (p1[1]-p0[1])*t
+		for py := int(math.Ceil(y)); py < height; py++ {
+			dst.SetNRGBA(x, py, fill)
+		}
+	}
+
+	// Stroke along the line
+	for i := 0; i < len(points)-1; i++ {
+		p0, p1 := points[i], points[i+1]
+		steps := int(math.Ceil(math.Max(math.Abs(p1[0]-p0[0]), math.Abs(p1[1]-p0[1])))) * 2
+		for s := 0; s <= steps; s++ {
+			t := float64(s) / float64(max(steps, 1))
+			cx := p0[0] + (p1[0]-p0[0])*t
+			cy := p0[1] + (p1[1]-p0[1])*t
+
+			for py := int(math.Floor(cy - radius)); py <= int(math.Ceil(cy+radius)); py++ {
+				for px := int(math.Floor(cx - radius)); px <= int(math.Ceil(cx+radius)); px++ {
+					if px < 0 || py < 0 || px >= width || py >= height {
+						continue
+					}
+					if math.Hypot(float64(px)-cx, float64(py)-cy) <= radius {
+						dst.SetNRGBA(px, py, line)
+					}
+				}
+			}
+		}
+	}
+
+	return dst
+}
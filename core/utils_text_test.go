@@ -94,3 +94,45 @@ func TestSearchableExtractNumber(t *testing.T) {
 		}
 	}
 }
+
+func TestRasterizeSparkline(t *testing.T) {
+	col := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+
+	if img := RasterizeSparkline(nil, []float64{1, 2}, 0, 10, 1, col); img != nil {
+		t.Error("Expected nil image for zero width")
+	}
+
+	img := RasterizeSparkline(nil, []float64{1}, 20, 10, 1, col)
+	if img == nil || img.Bounds().Dx() != 20 || img.Bounds().Dy() != 10 {
+		t.Fatal("Expected blank image with requested bounds for a single value")
+	}
+	for _, p := range img.Pix {
+		if p != 0 {
+			t.Fatal("Expected blank image for a single value")
+		}
+	}
+
+	img = RasterizeSparkline(img, []float64{1, 2, 3}, 20, 10, 2, col)
+	if img.NRGBAAt(0, 9).A != 255 {
+		t.Error("Expected lowest value to be drawn at the bottom left")
+	}
+	if img.NRGBAAt(19, 0).A != 255 {
+		t.Error("Expected highest value to be drawn at the top right")
+	}
+	if img.NRGBAAt(0, 0).A != 0 {
+		t.Error("Expected area above the line to stay transparent")
+	}
+
+	narrow := RasterizeSparkline(nil, []float64{1, 2, 3}, 1, 10, 1, col)
+	if narrow == nil || narrow.Bounds().Dx() != 1 {
+		t.Error("Expected blank image for a single column")
+	}
+
+	reused := RasterizeSparkline(img, []float64{3, 3}, 20, 10, 2, col)
+	if reused != img {
+		t.Error("Expected image with matching bounds to be reused")
+	}
+	if reused.NRGBAAt(10, 5).A != 255 {
+		t.Error("Expected flat series to be drawn through the middle")
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"os"
 	"time"
 
@@ -12,8 +13,41 @@ import (
 
 func main() {
 
-	JC.App = app.NewWithID(JC.AppID)
+	flag.BoolVar(&JC.IsHeadless, "headless", false, "Run without a window, fetching rates and processing watchers only")
+	flag.Parse()
+
+	// Headless never constructs the fyne app, only the core and types layers run
+	if JC.IsHeadless {
+		runHeadless()
+	} else {
+		runWindow()
+	}
+
+	JC.Logln("Received exit signal, Performing cleanup and exiting gracefully.")
+
+	timer := time.NewTimer(2 * time.Second)
+	go func() {
+		<-timer.C
+		JC.Logln("Force exiting after timeout...")
+		os.Exit(1)
+	}()
+
+	appShutdown()
+
+	<-JC.ShutdownCtx.Done()
+
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+
+	}
+}
+
+func runWindow() {
 
+	JC.App = app.NewWithID(JC.AppID)
 	JC.Window = JC.App.NewWindow("JXCrypto Watcher")
 
 	registerBoot()
@@ -45,25 +79,4 @@ func main() {
 	}
 
 	JC.Window.ShowAndRun()
-
-	JC.Logln("Received exit signal, Performing cleanup and exiting gracefully.")
-
-	timer := time.NewTimer(2 * time.Second)
-	go func() {
-		<-timer.C
-		JC.Logln("Force exiting after timeout...")
-		os.Exit(1)
-	}()
-
-	appShutdown()
-
-	<-JC.ShutdownCtx.Done()
-
-	if !timer.Stop() {
-		select {
-		case <-timer.C:
-		default:
-		}
-
-	}
 }
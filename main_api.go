@@ -0,0 +1,593 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+
+	json "github.com/goccy/go-json"
+
+	JA "jxwatcher/apps"
+	JC "jxwatcher/core"
+	JT "jxwatcher/types"
+)
+
+const apiMaxBodySize = 1 << 16
+
+type apiPanel struct {
+	ID         string   `json:"id"`
+	Status     int      `json:"status"`
+	Key        string   `json:"key"`
+	OldKey     string   `json:"old_key"`
+	WatcherKey string   `json:"watcher_key"`
+	Provider   string   `json:"provider,omitempty"`
+	RateSource string   `json:"rate_source,omitempty"`
+	Disputed   bool     `json:"disputed"`
+	CostBasis  float64  `json:"cost_basis,omitempty"`
+	Acquired   string   `json:"acquired,omitempty"`
+	PnL        *float64 `json:"pnl,omitempty"`
+	PnLPercent *float64 `json:"pnl_percent,omitempty"`
+	Title      string   `json:"title,omitempty"`
+	Subtitle   string   `json:"subtitle,omitempty"`
+	Content    string   `json:"content,omitempty"`
+}
+
+type apiPanelRequest struct {
+	Source    int64   `json:"source"`
+	Target    int64   `json:"target"`
+	Value     float64 `json:"value"`
+	Decimals  int64   `json:"decimals"`
+	Provider  string  `json:"provider,omitempty"`
+	CostBasis float64 `json:"cost_basis,omitempty"`
+	Acquired  string  `json:"acquired,omitempty"`
+}
+
+type apiWatcher struct {
+	ID           string           `json:"id"`
+	Key          string           `json:"key"`
+	Active       bool             `json:"active"`
+	Disabled     bool             `json:"disabled"`
+	Sent         int              `json:"sent"`
+	Limit        int              `json:"limit"`
+	Duration     int              `json:"duration"`
+	Timestamp    int              `json:"timestamp"`
+	Logic        int              `json:"logic"`
+	Rules        []JT.WatcherRule `json:"rules"`
+	Descriptions []string         `json:"descriptions"`
+}
+
+type apiWatcherRequest struct {
+	Disabled bool             `json:"disabled"`
+	Logic    int              `json:"logic"`
+	Rules    []JT.WatcherRule `json:"rules"`
+	Limit    int              `json:"limit"`
+	Duration int              `json:"duration"`
+}
+
+type apiTicker struct {
+	Type    string `json:"type"`
+	Title   string `json:"title"`
+	Status  int    `json:"status"`
+	Content string `json:"content,omitempty"`
+}
+
+type apiStatus struct {
+	Ready           bool `json:"ready"`
+	Paused          bool `json:"paused"`
+	Headless        bool `json:"headless"`
+	FetchingCryptos bool `json:"fetching_cryptos"`
+	FetchingRates   bool `json:"fetching_rates"`
+	FetchingTickers bool `json:"fetching_tickers"`
+	ValidConfig     bool `json:"valid_config"`
+	ValidCryptos    bool `json:"valid_cryptos"`
+	ValidPanels     bool `json:"valid_panels"`
+	ValidTickers    bool `json:"valid_tickers"`
+	Network         bool `json:"network"`
+	Panels          int  `json:"panels"`
+}
+
+type apiError struct {
+	Error string `json:"error"`
+}
+
+func registerAPI() {
+
+	port := JT.UseConfig().GetAPIPort()
+	if port == 0 {
+		return
+	}
+
+	token, err := JT.LoadAPIToken()
+	if err != nil {
+		JC.Logln("API server disabled, unable to load the api token:", err)
+		return
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/status", apiGetStatus)
+	mux.HandleFunc("GET /api/panels", apiGetPanels)
+	mux.HandleFunc("POST /api/panels", apiCreatePanel)
+	mux.HandleFunc("GET /api/panels/{id}", apiGetPanel)
+	mux.HandleFunc("PUT /api/panels/{id}", apiUpdatePanel)
+	mux.HandleFunc("DELETE /api/panels/{id}", apiDeletePanel)
+	mux.HandleFunc("PUT /api/panels/{id}/watcher", apiUpdateWatcher)
+	mux.HandleFunc("DELETE /api/panels/{id}/watcher", apiDeleteWatcher)
+	mux.HandleFunc("GET /api/watchers", apiGetWatchers)
+	mux.HandleFunc("GET /api/rates", apiGetRates)
+	mux.HandleFunc("GET /api/tickers", apiGetTickers)
+	mux.HandleFunc("GET /metrics", apiGetMetrics)
+
+	server := &http.Server{
+		Addr:              net.JoinHostPort(JC.API_HOST, strconv.Itoa(port)),
+		Handler:           apiGuard(mux, port, token),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		JC.Logln("API server listening on", server.Addr)
+
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			JC.Logln("API server stopped:", err)
+		}
+	}()
+
+	go func() {
+		<-JC.ShutdownCtx.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+		defer cancel()
+
+		server.Shutdown(ctx)
+	}()
+}
+
+// Only local host names are accepted against DNS rebinding, the bearer token keeps browsers from forging requests
+func apiGuard(next http.Handler, port int, token string) http.Handler {
+	hosts := map[string]bool{
+		net.JoinHostPort(JC.API_HOST, strconv.Itoa(port)): true,
+		net.JoinHostPort("localhost", strconv.Itoa(port)): true,
+	}
+	expected := []byte("Bearer " + token)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !hosts[strings.ToLower(r.Host)] {
+			apiWriteError(w, http.StatusForbidden, "host not allowed")
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="jxwatcher"`)
+			apiWriteError(w, http.StatusUnauthorized, "missing or invalid api token")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func apiGetStatus(w http.ResponseWriter, r *http.Request) {
+	status := JA.UseStatus()
+
+	apiWrite(w, http.StatusOK, apiStatus{
+		Ready:           status.IsReady(),
+		Paused:          status.IsPaused(),
+		Headless:        JC.IsHeadless,
+		FetchingCryptos: status.IsFetchingCryptos(),
+		FetchingRates:   status.IsFetchingRates(),
+		FetchingTickers: status.IsFetchingTickers(),
+		ValidConfig:     status.IsValidConfig(),
+		ValidCryptos:    status.IsValidCrypto(),
+		ValidPanels:     status.IsValidPanels(),
+		ValidTickers:    status.IsValidTickers(),
+		Network:         status.IsGoodNetworkStatus(),
+		Panels:          status.PanelsCount(),
+	})
+}
+
+func apiGetPanels(w http.ResponseWriter, r *http.Request) {
+	panels := []apiPanel{}
+
+	for _, pot := range JT.UsePanelMaps().GetData() {
+		if pdt := JT.UsePanelMaps().GetDataByID(pot.GetID()); pdt != nil {
+			panels = append(panels, newAPIPanel(pdt))
+		}
+	}
+
+	apiWrite(w, http.StatusOK, panels)
+}
+
+func apiGetPanel(w http.ResponseWriter, r *http.Request) {
+	pdt := JT.UsePanelMaps().GetDataByID(r.PathValue("id"))
+	if pdt == nil {
+		apiWriteError(w, http.StatusNotFound, "panel not found")
+		return
+	}
+
+	apiWrite(w, http.StatusOK, newAPIPanel(pdt))
+}
+
+func apiCreatePanel(w http.ResponseWriter, r *http.Request) {
+	if !apiCanWrite(w) {
+		return
+	}
+
+	var req apiPanelRequest
+	if !apiRead(w, r, &req) {
+		return
+	}
+
+	pk, err := req.generateKey()
+	if err != nil {
+		apiWriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var pdt JT.PanelData
+
+	apiRunOnMain(func() {
+		pdt = JT.UsePanelMaps().Append(pk)
+		if pdt == nil {
+			return
+		}
+
+		pdt.SetProvider(req.Provider)
+		pdt.SetStatus(JC.STATE_FETCHING_NEW)
+
+		addPanel(pdt)
+		savePanelForm(pdt)
+	})
+
+	if pdt == nil {
+		apiWriteError(w, http.StatusInternalServerError, JC.NotifyUnableToAddNewPanelPleaseTryAgain)
+		return
+	}
+
+	apiWrite(w, http.StatusCreated, newAPIPanel(pdt))
+}
+
+func apiUpdatePanel(w http.ResponseWriter, r *http.Request) {
+	if !apiCanWrite(w) {
+		return
+	}
+
+	pdt := JT.UsePanelMaps().GetDataByID(r.PathValue("id"))
+	if pdt == nil {
+		apiWriteError(w, http.StatusNotFound, "panel not found")
+		return
+	}
+
+	var req apiPanelRequest
+	if !apiRead(w, r, &req) {
+		return
+	}
+
+	pk, err := req.generateKey()
+	if err != nil {
+		apiWriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	apiRunOnMain(func() {
+		pdt.SetProvider(req.Provider)
+		pdt.Reconfigure(pk)
+		savePanelForm(pdt)
+	})
+
+	apiWrite(w, http.StatusOK, newAPIPanel(pdt))
+}
+
+func apiDeletePanel(w http.ResponseWriter, r *http.Request) {
+	if !apiCanWrite(w) {
+		return
+	}
+
+	uuid := r.PathValue("id")
+	if JT.UsePanelMaps().GetDataByID(uuid) == nil {
+		apiWriteError(w, http.StatusNotFound, "panel not found")
+		return
+	}
+
+	apiRunOnMain(func() {
+		removePanel(uuid)
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func apiGetWatchers(w http.ResponseWriter, r *http.Request) {
+	watchers := []apiWatcher{}
+
+	for _, pot := range JT.UsePanelMaps().GetData() {
+		pdt := JT.UsePanelMaps().GetDataByID(pot.GetID())
+		if pdt == nil || pdt.UseWatcherKey().IsEmpty() {
+			continue
+		}
+
+		watchers = append(watchers, newAPIWatcher(pdt))
+	}
+
+	apiWrite(w, http.StatusOK, watchers)
+}
+
+func apiUpdateWatcher(w http.ResponseWriter, r *http.Request) {
+	if !apiCanWrite(w) {
+		return
+	}
+
+	pdt := JT.UsePanelMaps().GetDataByID(r.PathValue("id"))
+	if pdt == nil {
+		apiWriteError(w, http.StatusNotFound, "panel not found")
+		return
+	}
+
+	var req apiWatcherRequest
+	if !apiRead(w, r, &req) {
+		return
+	}
+
+	if err := req.validate(); err != nil {
+		apiWriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sent := 0
+	if req.Disabled {
+		sent = JC.WATCHER_DISABLED
+	}
+
+	wk := JT.NewWatcherKey()
+	wk.GenerateKeyFromRules(sent, req.Logic, req.Rules, req.Limit, req.Duration, 0)
+
+	apiRunOnMain(func() {
+		pdt.SetWatcherKey(wk.GetRawValue())
+		savePanelForm(pdt)
+	})
+
+	apiWrite(w, http.StatusOK, newAPIWatcher(pdt))
+}
+
+func apiDeleteWatcher(w http.ResponseWriter, r *http.Request) {
+	if !apiCanWrite(w) {
+		return
+	}
+
+	pdt := JT.UsePanelMaps().GetDataByID(r.PathValue("id"))
+	if pdt == nil {
+		apiWriteError(w, http.StatusNotFound, "panel not found")
+		return
+	}
+
+	apiRunOnMain(func() {
+		pdt.SetWatcherKey(JC.STRING_EMPTY)
+		savePanelForm(pdt)
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func apiGetRates(w http.ResponseWriter, r *http.Request) {
+	apiWrite(w, http.StatusOK, JT.UseExchangeCache().Serialize())
+}
+
+func apiGetTickers(w http.ResponseWriter, r *http.Request) {
+	tickers := []apiTicker{}
+
+	for _, tdt := range JT.UseTickerMaps().GetData() {
+		at := apiTicker{
+			Type:   tdt.GetType(),
+			Title:  tdt.GetTitle(),
+			Status: tdt.GetStatus(),
+		}
+
+		if tdt.IsStatus(JC.STATE_LOADED) {
+			at.Content = tdt.FormatContent()
+		}
+
+		tickers = append(tickers, at)
+	}
+
+	apiWrite(w, http.StatusOK, map[string]any{
+		"tickers": tickers,
+		"cache":   JT.UseTickerCache().Serialize(),
+	})
+}
+
+func apiGetMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	if err := JT.WriteMetrics(w); err != nil {
+		JC.Logln("Failed to write metrics:", err)
+	}
+}
+
+func (req *apiPanelRequest) generateKey() (string, error) {
+	maps := JT.UsePanelMaps()
+
+	if req.Value <= 0 {
+		return JC.STRING_EMPTY, errors.New("value must be greater than 0")
+	}
+
+	if req.Decimals < 0 {
+		return JC.STRING_EMPTY, errors.New("decimals must not be negative")
+	}
+
+	if !maps.ValidateId(req.Source) || !maps.ValidateId(req.Target) {
+		return JC.STRING_EMPTY, errors.New("unknown source or target crypto id")
+	}
+
+	if req.Provider != JC.STRING_EMPTY && !JT.UseRateProviders().Has(req.Provider) {
+		return JC.STRING_EMPTY, errors.New("unknown rate provider: " + req.Provider)
+	}
+
+	sid := strconv.FormatInt(req.Source, 10)
+	tid := strconv.FormatInt(req.Target, 10)
+
+	if req.CostBasis < 0 {
+		return JC.STRING_EMPTY, errors.New("cost_basis must not be negative")
+	}
+
+	acquired, ok := JT.ParseAcquiredDate(req.Acquired)
+	if req.Acquired != JC.STRING_EMPTY && !ok {
+		return JC.STRING_EMPTY, errors.New("acquired must be a YYYY-MM-DD date")
+	}
+
+	npk := JT.NewPanelKey()
+
+	npk.GenerateKey(
+		sid,
+		tid,
+		strconv.FormatFloat(req.Value, 'f', -1, 64),
+		maps.GetSymbolById(sid),
+		maps.GetSymbolById(tid),
+		strconv.FormatInt(req.Decimals, 10),
+		JC.ToBigFloat(-1),
+	)
+
+	return npk.SetHolding(req.CostBasis, acquired), nil
+}
+
+func (req *apiWatcherRequest) validate() error {
+	if req.Logic != JC.WATCHER_LOGIC_AND && req.Logic != JC.WATCHER_LOGIC_OR {
+		return errors.New("logic must be 0 (all rules) or 1 (any rule)")
+	}
+
+	if len(req.Rules) == 0 {
+		return errors.New("at least one rule is required")
+	}
+
+	for _, rule := range req.Rules {
+		if !rule.IsValid() {
+			return errors.New("invalid rule: " + rule.FormatDescription())
+		}
+	}
+
+	if req.Limit <= 0 {
+		return errors.New("limit must be greater than 0")
+	}
+
+	if req.Duration <= 0 {
+		return errors.New("duration must be greater than 0")
+	}
+
+	return nil
+}
+
+func newAPIPanel(pdt JT.PanelData) apiPanel {
+	cache := pdt.Serialize()
+
+	ap := apiPanel{
+		ID:         pdt.GetID(),
+		Status:     cache.Status,
+		Key:        cache.Key,
+		OldKey:     cache.OldKey,
+		WatcherKey: cache.WatcherKey,
+		Provider:   pdt.GetProvider(),
+		CostBasis:  pdt.UsePanelKey().GetCostBasisFloat(),
+		Acquired:   pdt.UsePanelKey().GetAcquiredString(),
+	}
+
+	if pdt.IsStatus(JC.STATE_LOADED) {
+		if pkt := pdt.UsePanelKey(); pkt.IsHolding() {
+			pnl := pkt.GetPnLFloat()
+			pct := pkt.GetPnLPercentFloat()
+			ap.PnL = &pnl
+			ap.PnLPercent = &pct
+		}
+
+		ap.RateSource = pdt.GetRateSource()
+		ap.Disputed = pdt.IsRateDisputed()
+		ap.Title = pdt.FormatTitle()
+		ap.Subtitle = pdt.FormatSubtitle()
+		ap.Content = pdt.FormatContent()
+	}
+
+	return ap
+}
+
+func newAPIWatcher(pdt JT.PanelData) apiWatcher {
+	wk := pdt.UseWatcherKey()
+
+	aw := apiWatcher{
+		ID:           pdt.GetID(),
+		Key:          wk.GetRawValue(),
+		Active:       wk.IsActive(),
+		Disabled:     wk.IsDisabled(),
+		Sent:         wk.GetSent(),
+		Limit:        wk.GetLimit(),
+		Duration:     wk.GetDuration(),
+		Timestamp:    wk.GetTimestamp(),
+		Logic:        wk.GetLogic(),
+		Rules:        wk.GetRules(),
+		Descriptions: []string{},
+	}
+
+	for _, rule := range aw.Rules {
+		aw.Descriptions = append(aw.Descriptions, rule.FormatDescription())
+	}
+
+	return aw
+}
+
+func apiCanWrite(w http.ResponseWriter) bool {
+	if !JA.UseStatus().IsReady() || !JT.UsePanelMaps().HasMaps() {
+		apiWriteError(w, http.StatusServiceUnavailable, "app is not ready yet")
+		return false
+	}
+
+	return true
+}
+
+func apiRunOnMain(fn func()) {
+	if JC.IsHeadless {
+		fn()
+		return
+	}
+
+	fyne.DoAndWait(fn)
+}
+
+func apiRead(w http.ResponseWriter, r *http.Request, target any) bool {
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		apiWriteError(w, http.StatusUnsupportedMediaType, "content type must be application/json")
+		return false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, apiMaxBodySize))
+	if err != nil {
+		apiWriteError(w, http.StatusBadRequest, "unable to read request body")
+		return false
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		apiWriteError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return false
+	}
+
+	return true
+}
+
+func apiWrite(w http.ResponseWriter, code int, payload any) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		code = http.StatusInternalServerError
+		data = []byte(`{"error":"unable to encode response"}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+func apiWriteError(w http.ResponseWriter, code int, message string) {
+	apiWrite(w, code, apiError{Error: message})
+}
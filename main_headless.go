@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	JA "jxwatcher/apps"
+	JC "jxwatcher/core"
+	JT "jxwatcher/types"
+)
+
+const headlessStateFile = "headless.json"
+
+type headlessPanelState struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Content  string `json:"content"`
+	Subtitle string `json:"subtitle"`
+	Rate     string `json:"rate"`
+	Status   int    `json:"status"`
+	Watcher  string `json:"watcher"`
+}
+
+type headlessTickerState struct {
+	Type    string `json:"type"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	Status  int    `json:"status"`
+}
+
+type headlessState struct {
+	Timestamp time.Time             `json:"timestamp"`
+	Ready     bool                  `json:"ready"`
+	Network   bool                  `json:"network"`
+	Config    bool                  `json:"config"`
+	Cryptos   bool                  `json:"cryptos"`
+	Panels    []headlessPanelState  `json:"panels"`
+	Tickers   []headlessTickerState `json:"tickers"`
+}
+
+func runHeadless() {
+
+	registerBoot()
+
+	registerUtility()
+
+	registerCache()
+
+	registerFetchers()
+
+	registerWorkers()
+
+	registerDispatcher()
+
+	JC.Logln("Running headless, no window will be created")
+
+	loadAppData()
+
+	registerAPI()
+
+	JA.UseStatus().InitData()
+
+	registerFileWatcher()
+
+	if JT.UseAuthKey().IsLocked() {
+		log.Printf("Headless: auth key vault is locked, set %s to unlock it", JC.AUTH_VAULT_PASSPHRASE_ENV)
+	}
+
+	if !JA.UseStatus().IsValidCrypto() {
+		log.Println("Headless: no valid cryptos map, check cryptos.json and config.json")
+	}
+
+	if !JA.UseStatus().HasError() {
+		JT.UseExchangeCache().SoftReset()
+		JC.UseWorker().Call(JC.ACT_EXCHANGE_UPDATE_RATES, JC.CallImmediate)
+
+		JT.UseTickerCache().SoftReset()
+		JC.UseWorker().Call(JC.ACT_TICKER_UPDATE, JC.CallImmediate)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	<-ctx.Done()
+}
+
+func reportHeadlessState() {
+	status := JA.UseStatus()
+
+	state := headlessState{
+		Timestamp: time.Now(),
+		Ready:     status.IsReady(),
+		Network:   status.IsGoodNetworkStatus(),
+		Config:    status.IsValidConfig(),
+		Cryptos:   status.IsValidCrypto(),
+		Panels:    []headlessPanelState{},
+		Tickers:   []headlessTickerState{},
+	}
+
+	for _, pdt := range JT.UsePanelMaps().GetData() {
+		ps := headlessPanelState{
+			ID:      pdt.GetID(),
+			Rate:    pdt.GetValueString(),
+			Status:  pdt.GetStatus(),
+			Watcher: pdt.UseWatcherKey().GetRawValue(),
+		}
+
+		if pdt.IsStatus(JC.STATE_LOADED) {
+			ps.Title = pdt.FormatTitle()
+			ps.Content = pdt.FormatContent()
+			ps.Subtitle = pdt.FormatSubtitle()
+
+			log.Printf("Headless: %s = %s", ps.Title, ps.Content)
+		}
+
+		state.Panels = append(state.Panels, ps)
+	}
+
+	for _, tdt := range JT.UseTickerMaps().GetData() {
+		ts := headlessTickerState{
+			Type:   tdt.GetType(),
+			Title:  tdt.GetTitle(),
+			Status: tdt.GetStatus(),
+		}
+
+		if tdt.IsStatus(JC.STATE_LOADED) {
+			ts.Content = tdt.FormatContent()
+		}
+
+		state.Tickers = append(state.Tickers, ts)
+	}
+
+	if !JC.SaveFileToStorage(headlessStateFile, state) {
+		JC.Logln("Failed to export headless state")
+	}
+}
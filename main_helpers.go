@@ -30,6 +30,10 @@ func processWatcher() {
 		pot.ProcessWatcher()
 	}
 
+	if JC.IsHeadless {
+		return
+	}
+
 	JA.UseAction().Refresh()
 }
 
@@ -143,7 +147,15 @@ func updateDisplay() bool {
 		}
 	}
 
-	JA.UseLayout().RegisterDisplayUpdate(time.Now())
+	if updateCount != 0 {
+		refreshPortfolio()
+	}
+
+	if JC.IsHeadless {
+		reportHeadlessState()
+	} else {
+		JA.UseLayout().RegisterDisplayUpdate(time.Now())
+	}
 
 	JC.Logf("Panels display updated: %d/%d/%d/%d", len(recentUpdates), updateCount, len(allIDs), len(panels))
 
@@ -192,6 +204,12 @@ func updateTickerDisplay() bool {
 	if JT.UseConfig().CanDoDominance() {
 		tickers = append(tickers, JT.TickerTypeDominance)
 	}
+	if JT.UsePanelMaps().HasHoldings() {
+		tickers = append(tickers, JT.TickerTypePortfolio)
+	}
+	for _, ct := range JT.UseConfig().GetCustomTickers() {
+		tickers = append(tickers, ct.GetType())
+	}
 
 	if len(tickers) == 0 {
 		JC.Logln("Unable to refresh tickers: No configured tickers")
@@ -226,6 +244,10 @@ func updateTickerDisplay() bool {
 		if JA.UseStatus().IsTickerShown() {
 			JC.Notify(JC.NotifyTickerDisplayRefreshedWithNewRates)
 		}
+
+		if JC.IsHeadless {
+			reportHeadlessState()
+		}
 	}
 
 	JC.Logf("Tickers display updated: %d/%d/%d", len(recentUpdates), success, len(tickers))
@@ -251,6 +273,7 @@ func updateRates() bool {
 
 	list := JT.UsePanelMaps().GetData()
 	clean := make(map[string]string)
+	groups := make(map[string]map[string]string)
 	seen := make(map[string]struct{})
 
 	for _, pot := range list {
@@ -271,6 +294,11 @@ func updateRates() bool {
 
 		clean[key] = key
 
+		provider := JT.UseRateProviders().Resolve(pk).GetName()
+		if _, exists := groups[provider]; !exists {
+			groups[provider] = make(map[string]string)
+		}
+		groups[provider][key] = key
 	}
 
 	for _, pot := range list {
@@ -287,6 +315,81 @@ func updateRates() bool {
 		}
 	}
 
+	payloads := make(map[string][]string, 1)
+	tidCount := 0
+
+	for provider, pairs := range groups {
+		jb, count := buildRatePayloads(pairs)
+		tidCount += count
+
+		for _, rk := range jb {
+			payloads[JC.ACT_EXCHANGE_GET_RATES] = append(payloads[JC.ACT_EXCHANGE_GET_RATES], rk+JC.STRING_PIPE+provider)
+		}
+	}
+
+	jb := payloads[JC.ACT_EXCHANGE_GET_RATES]
+
+	if len(jb) == 0 {
+		JC.Logln("Unable to retrieve rates: No valid payload generated")
+		return false
+	}
+
+	JC.Logf("Fetching data: %d request for %d rates", len(jb), tidCount)
+
+	JC.Notify(JC.NotifyFetchingTheLatestExchangeRates)
+
+	JC.UseFetcher().Call(payloads,
+		func(scheduledJobs int) {
+
+			if JC.IsShuttingDown() {
+				return
+			}
+
+			if scheduledJobs > 0 {
+				JA.UseStatus().StartFetchingRates()
+				JT.UseExchangeCache().SoftReset()
+			}
+		},
+		func(results map[string]JC.FetchResultInterface) {
+			defer JA.UseStatus().EndFetchingRates()
+			defer JC.UseWorker().Reset(JC.ACT_EXCHANGE_UPDATE_RATES)
+
+			hasError := JC.STATUS_SKIPPED
+			successCount := 0
+
+			for _, result := range results {
+
+				if JC.IsShuttingDown() {
+					return
+				}
+
+				ns := JC.DetectHTTPResponse(result.Code())
+				hasError = JC.MergeHTTPStatus(hasError, ns)
+
+				if ns == JC.STATUS_SUCCESS {
+					successCount++
+				}
+			}
+
+			processUpdatePanelComplete(hasError)
+
+			JC.Logf("Exchange rate updated: %v/%v", successCount, len(jb))
+
+			if successCount != 0 {
+				updateDisplay()
+			}
+		},
+		func() {
+			JA.UseStatus().EndFetchingRates()
+		})
+
+	runtime.GC()
+
+	return true
+}
+
+// Merges source_target pairs into as few requests as possible, the most shared id becomes the request source
+func buildRatePayloads(clean map[string]string) (map[string]string, int) {
 	wb := make(map[string]int)
 
 	for key := range clean {
@@ -328,11 +431,6 @@ func updateRates() bool {
 		}
 	}
 
-	if len(jb) == 0 {
-		JC.Logln("Unable to retrieve rates: No valid payload generated")
-		return false
-	}
-
 	tidCount := 0
 	for sid, val := range jb {
 		uniq := JC.MakeUniquePayload(sid, val)
@@ -360,66 +458,7 @@ func updateRates() bool {
 		jb[sid] = sid + JC.STRING_PIPE + strings.Join(uniq, ",")
 	}
 
-	payloads := make(map[string][]string, len(jb))
-
-	JC.Logf("Fetching data: %d request for %d rates", len(jb), tidCount)
-
-	for _, rk := range jb {
-		payloads[JC.ACT_EXCHANGE_GET_RATES] = append(payloads[JC.ACT_EXCHANGE_GET_RATES], rk)
-	}
-
-	JC.Notify(JC.NotifyFetchingTheLatestExchangeRates)
-
-	JC.UseFetcher().Call(payloads,
-		func(scheduledJobs int) {
-
-			if JC.IsShuttingDown() {
-				return
-			}
-
-			if scheduledJobs > 0 {
-				JA.UseStatus().StartFetchingRates()
-				JT.UseExchangeCache().SoftReset()
-			}
-		},
-		func(results map[string]JC.FetchResultInterface) {
-			defer JA.UseStatus().EndFetchingRates()
-			defer JC.UseWorker().Reset(JC.ACT_EXCHANGE_UPDATE_RATES)
-
-			hasError := 0
-			successCount := 0
-
-			for _, result := range results {
-
-				if JC.IsShuttingDown() {
-					return
-				}
-
-				ns := detectHTTPResponse(result.Code())
-				if hasError == JC.STATUS_SUCCESS || hasError < ns {
-					hasError = ns
-				}
-
-				if ns == JC.STATUS_SUCCESS {
-					successCount++
-				}
-			}
-
-			processUpdatePanelComplete(hasError)
-
-			JC.Logf("Exchange rate updated: %v/%v", successCount, len(jb))
-
-			if successCount != 0 {
-				updateDisplay()
-			}
-		},
-		func() {
-			JA.UseStatus().EndFetchingRates()
-		})
-
-	runtime.GC()
-
-	return true
+	return jb, tidCount
 }
 
 func updateTickers() bool {
@@ -440,35 +479,23 @@ func updateTickers() bool {
 		return false
 	}
 
-	// Prepare keys and payloads
+	// Prepare keys and payloads, only for tickers whose schedule is due
 	payloads := make(map[string][]string, 8)
 
-	if JT.UseConfig().CanDoCMC100() {
-		payloads[JT.TickerTypeCMC100] = []string{JT.TickerTypeCMC100}
-	}
-	if JT.UseConfig().CanDoFearGreed() {
-		payloads[JT.TickerTypeFearGreed] = []string{JT.TickerTypeFearGreed}
-	}
-	if JT.UseConfig().CanDoMarketCap() {
-		payloads[JT.TickerTypeMarketCap] = []string{JT.TickerTypeMarketCap}
-	}
-	if JT.UseConfig().CanDoAltSeason() {
-		payloads[JT.TickerTypeAltcoinIndex] = []string{JT.TickerTypeAltcoinIndex}
-	}
-	if JT.UseConfig().CanDoRSI() {
-		payloads[JT.TickerTypeRSI] = []string{JT.TickerTypeRSI}
-	}
-	if JT.UseConfig().CanDoETF() {
-		payloads[JT.TickerTypeETF] = []string{JT.TickerTypeETF}
-	}
-	if JT.UseConfig().CanDoDominance() {
-		payloads[JT.TickerTypeDominance] = []string{JT.TickerTypeDominance}
+	for _, tickerType := range tickerFetchTypes() {
+		if canFetchTicker(tickerType) && JT.UseTickerSchedule().IsDue(tickerType) {
+			payloads[tickerType] = []string{tickerType}
+		}
 	}
 
 	if len(payloads) == 0 {
 		return false
 	}
 
+	for tickerType := range payloads {
+		JT.UseTickerSchedule().Postpone(tickerType)
+	}
+
 	if JA.UseStatus().IsTickerShown() {
 		JC.Notify(JC.NotifyFetchingTheLatestTickerData)
 	}
@@ -487,8 +514,13 @@ func updateTickers() bool {
 		func(results map[string]JC.FetchResultInterface) {
 			defer JA.UseStatus().EndFetchingTickers()
 			defer JC.UseWorker().Reset(JC.ACT_TICKER_UPDATE)
+			defer func() {
+				for tickerType := range payloads {
+					JC.UseWorker().Reset(tickerWorkerKey(tickerType))
+				}
+			}()
 
-			hasError := 0
+			hasError := JC.STATUS_SKIPPED
 			successCount := 0
 
 			for _, result := range results {
@@ -496,15 +528,13 @@ func updateTickers() bool {
 					return
 				}
 
-				ns := detectHTTPResponse(result.Code())
+				ns := JC.DetectHTTPResponse(result.Code())
 				switch ns {
 				case JC.STATUS_SUCCESS:
 					successCount++
 				}
 
-				if hasError == 0 || hasError < ns {
-					hasError = ns
-				}
+				hasError = JC.MergeHTTPStatus(hasError, ns)
 			}
 
 			processUpdateTickerComplete(hasError)
@@ -514,6 +544,11 @@ func updateTickers() bool {
 			if successCount > 0 {
 				updateTickerDisplay()
 			}
+
+			// A new fiat rate changes every currency ticker, even when their USD value did not move
+			if result, ok := results[JT.TickerTypeFiatRate]; ok && result.Code() == JC.NETWORKING_SUCCESS {
+				refreshTickersContent()
+			}
 		},
 		func() {
 			JA.UseStatus().EndFetchingTickers()
@@ -524,24 +559,36 @@ func updateTickers() bool {
 	return true
 }
 
-func detectHTTPResponse(rs int64) int {
-
-	switch rs {
-	case JC.NETWORKING_SUCCESS:
-		return JC.STATUS_SUCCESS
-
-	case JC.NETWORKING_ERROR_CONNECTION, JC.NETWORKING_RATE_LIMIT, JC.NETWORKING_ERROR_FIREWALL, JC.NETWORKING_NO_INTERNET:
-		return JC.STATUS_NETWORK_ERROR
+// Fetcher keys of all tickers, Pulse is fetched together with RSI
+func tickerFetchTypes() []string {
+	keys := []string{
+		JT.TickerTypeCMC100,
+		JT.TickerTypeFearGreed,
+		JT.TickerTypeMarketCap,
+		JT.TickerTypeAltcoinIndex,
+		JT.TickerTypeRSI,
+		JT.TickerTypeETF,
+		JT.TickerTypeDominance,
+		JT.TickerTypeFiatRate,
+	}
 
-	case JC.NETWORKING_BAD_CONFIG, JC.NETWORKING_URL_ERROR:
-		return JC.STATUS_CONFIG_ERROR
+	for _, ct := range JT.UseConfig().GetCustomTickers() {
+		keys = append(keys, ct.GetType())
+	}
 
-	case JC.NETWORKING_BAD_DATA_RECEIVED, JC.NETWORKING_DATA_IN_CACHE, JC.NETWORKING_BAD_PAYLOAD, JC.NETWORKING_FAILED_CREATE_FILE:
-		return JC.STATUS_BAD_DATA_RECEIVED
+	return keys
+}
 
+func canFetchTicker(tickerType string) bool {
+	if tickerType == JT.TickerTypeRSI {
+		return JT.CanShowTicker(JT.TickerTypeRSI) || JT.CanShowTicker(JT.TickerTypePulse)
 	}
 
-	return JC.STATUS_SUCCESS
+	return JT.CanShowTicker(tickerType)
+}
+
+func tickerWorkerKey(tickerType string) string {
+	return JC.ACT_TICKER_SCHEDULE + "_" + tickerType
 }
 
 func processUpdatePanelComplete(status int) {
@@ -558,15 +605,9 @@ func processUpdatePanelComplete(status int) {
 		JA.UseStatus().SetNetworkStatus(false)
 		JA.UseStatus().SetConfigStatus(true)
 
-		JT.UsePanelMaps().ChangeStatus(JC.STATE_ERROR, func(pdt JT.PanelData) bool {
-			return pdt.UsePanelKey().IsValueMatchingFloat(0, JC.STRING_LESS) || pdt.IsStatus(JC.STATE_LOADING)
-		})
-
-		fyne.Do(func() {
-			JP.UsePanelGrid().UpdatePanelsContent(func(pdt JT.PanelData) bool {
-				return true
-			})
-		})
+		if JT.UsePanelMaps().ApplyFetchStatus(status) {
+			refreshPanelsContent()
+		}
 
 	case JC.STATUS_CONFIG_ERROR:
 
@@ -574,20 +615,18 @@ func processUpdatePanelComplete(status int) {
 		JA.UseStatus().SetNetworkStatus(true)
 		JA.UseStatus().SetConfigStatus(false)
 
-		JT.UsePanelMaps().ChangeStatus(JC.STATE_ERROR, func(pdt JT.PanelData) bool {
-			return pdt.UsePanelKey().IsValueMatchingFloat(0, JC.STRING_LESS) || pdt.IsStatus(JC.STATE_LOADING)
-		})
-
-		fyne.Do(func() {
-			JP.UsePanelGrid().UpdatePanelsContent(func(pdt JT.PanelData) bool {
-				return true
-			})
-		})
+		if JT.UsePanelMaps().ApplyFetchStatus(status) {
+			refreshPanelsContent()
+		}
 
 	case JC.STATUS_BAD_DATA_RECEIVED:
 
 		JA.UseStatus().SetNetworkStatus(true)
 		JA.UseStatus().SetConfigStatus(true)
+
+	case JC.STATUS_SKIPPED:
+
+		// Throttled by the request quota, the cached values stay on screen
 	}
 }
 
@@ -608,15 +647,9 @@ func processUpdateTickerComplete(status int) {
 		JA.UseStatus().SetNetworkStatus(false)
 		JA.UseStatus().SetConfigStatus(true)
 
-		JT.UseTickerMaps().ChangeStatus(JC.STATE_ERROR, func(pdt JT.TickerData) bool {
-			return !pdt.HasData() || pdt.IsStatus(JC.STATE_LOADING)
-		})
-
-		fyne.Do(func() {
-			JX.UseTickerGrid().UpdateTickersContent(func(pdt JT.TickerData) bool {
-				return true
-			})
-		})
+		if JT.UseTickerMaps().ApplyFetchStatus(status) {
+			refreshTickersContent()
+		}
 
 	case JC.STATUS_CONFIG_ERROR:
 
@@ -625,18 +658,18 @@ func processUpdateTickerComplete(status int) {
 		JA.UseStatus().SetNetworkStatus(true)
 		JA.UseStatus().SetConfigStatus(false)
 
-		JT.UseTickerMaps().ChangeStatus(JC.STATE_ERROR, func(pdt JT.TickerData) bool {
-			return !pdt.HasData() || pdt.IsStatus(JC.STATE_LOADING)
-		})
-
-		JX.UseTickerGrid().UpdateTickersContent(func(pdt JT.TickerData) bool {
-			return true
-		})
+		if JT.UseTickerMaps().ApplyFetchStatus(status) {
+			refreshTickersContent()
+		}
 
 	case JC.STATUS_BAD_DATA_RECEIVED:
 
 		JA.UseStatus().SetNetworkStatus(true)
 		JA.UseStatus().SetConfigStatus(true)
+
+	case JC.STATUS_SKIPPED:
+
+		// Throttled by the request quota, the cached values stay on screen
 	}
 }
 
@@ -658,9 +691,11 @@ func processFetchingCryptosComplete(status int) {
 		JC.Notify(JC.NotifyCryptoMapRegeneratedSuccessfully)
 
 		if JT.UsePanelMaps().RefreshData() {
-			fyne.Do(func() {
-				JP.UsePanelGrid().ForceRefresh()
-			})
+			if !JC.IsHeadless {
+				fyne.Do(func() {
+					JP.UsePanelGrid().ForceRefresh()
+				})
+			}
 
 			JT.UseExchangeCache().SoftReset()
 			JC.UseWorker().Call(JC.ACT_EXCHANGE_UPDATE_RATES, JC.CallQueued)
@@ -689,6 +724,163 @@ func processFetchingCryptosComplete(status int) {
 	}
 }
 
+func refreshPanelsContent() {
+	if JC.IsHeadless {
+		return
+	}
+
+	fyne.Do(func() {
+		JP.UsePanelGrid().UpdatePanelsContent(func(pdt JT.PanelData) bool {
+			return true
+		})
+	})
+}
+
+func refreshTickersContent() {
+	if JC.IsHeadless {
+		return
+	}
+
+	fyne.Do(func() {
+		JX.UseTickerGrid().UpdateTickersContent(func(pdt JT.TickerData) bool {
+			return true
+		})
+	})
+}
+
+func refreshThemedContent() {
+	refreshPanelsContent()
+
+	if JX.UseTickerGrid() != nil {
+		refreshTickersContent()
+	}
+}
+
+func applyThemeMode() {
+	if JC.IsHeadless || JC.UseTheme().GetMode() == JT.UseConfig().GetTheme() {
+		return
+	}
+
+	JC.UseTheme().SetMode(JT.UseConfig().GetTheme())
+
+	fyne.Do(func() {
+		JC.App.Settings().SetTheme(JC.UseTheme())
+	})
+
+	refreshThemedContent()
+}
+
+func applyNetworking() {
+	if err := JC.ConfigureNetworking(JT.UseConfig().GetNetworking()); err != nil {
+		JC.Logln("Failed to apply network settings:", err)
+		JC.Notify(JC.NotifyInvalidNetworkSettings)
+	}
+}
+
+func applyQuota() {
+	JC.UseQuota().SetLimits(JT.UseConfig().GetQuotaLimits())
+}
+
+// Applies the current config to the running app, after saving settings or reloading config.json
+func applyConfiguration(reloadWorkers bool) {
+	applyNetworking()
+	applyQuota()
+	JC.UseLocale().SetLocale(JT.UseConfig().GetLocale())
+	applyThemeMode()
+	syncTray()
+	JA.UseStatus().DetectData()
+	registerCustomTickerFetchers()
+	registerTickerSchedules()
+	JT.UseTickerSchedule().Reset()
+
+	if !JT.UseConfig().IsValidTickers() {
+		return
+	}
+
+	if JT.UseTickerMaps().IsEmpty() {
+		JC.Logln("Rebuilding tickers due to empty ticker list")
+		JT.TickersInit()
+
+		if !JC.IsHeadless {
+			JX.RegisterTickerGrid(openTickerBreakdown)
+		}
+	} else if JT.IsTickerMapsOutdated() {
+		JC.Logln("Rebuilding tickers due to changed ticker visibility")
+		JT.SaveTickers()
+		JT.TickersInit()
+
+		if !JC.IsHeadless {
+			JX.RegisterTickerGrid(openTickerBreakdown)
+		}
+	}
+
+	if reloadWorkers {
+		JC.UseWorker().Reload()
+	}
+
+	refreshTickersContent()
+	refreshPanelsContent()
+
+	JA.UseStatus().SetConfigStatus(true)
+
+	JT.UseTickerCache().SoftReset()
+	JC.UseWorker().Call(JC.ACT_TICKER_UPDATE, JC.CallQueued)
+
+	JT.UseExchangeCache().SoftReset()
+	JC.UseWorker().Call(JC.ACT_EXCHANGE_UPDATE_RATES, JC.CallQueued)
+}
+
+func loadAppData() {
+
+	if !JT.ConfigInit() {
+	}
+
+	JT.AuthKeyInit()
+
+	applyNetworking()
+
+	JC.RegisterQuotaManager().Init()
+	JC.UseQuota().SetOnLow(func() {
+		JC.Notify(JC.NotifyRequestQuotaIsRunningLow)
+	})
+	applyQuota()
+
+	JC.UseLocale().SetLocale(JT.UseConfig().GetLocale())
+
+	if JA.UseSnapshot().LoadCryptos() == JC.NO_SNAPSHOT {
+		JT.CryptosLoaderInit()
+	}
+
+	if JA.UseSnapshot().LoadExchangeData() == JC.NO_SNAPSHOT {
+		JT.UseExchangeCache().Reset()
+	}
+
+	if JA.UseSnapshot().LoadTickerData() == JC.NO_SNAPSHOT {
+		JT.UseTickerCache().Reset()
+	}
+
+	if JA.UseSnapshot().LoadPanels() == JC.NO_SNAPSHOT {
+		JT.PanelsInit()
+	}
+
+	if JA.UseSnapshot().LoadTickers() == JC.NO_SNAPSHOT {
+		JT.TickersInit()
+	}
+
+	JT.UseConfig().PostInit()
+
+	JT.RegisterRateProviders()
+
+	JT.RegisterAlerts().Init()
+
+	JT.RegisterLedger().Init()
+
+	// Custom tickers and their schedules depend on the loaded config
+	registerCustomTickerFetchers()
+
+	registerTickerSchedules()
+}
+
 func validateRatesCache() bool {
 
 	list := JT.UsePanelMaps().GetData()
@@ -723,15 +915,31 @@ func validateRateCache(pot JT.PanelData) bool {
 	return true
 }
 
+func addPanel(npdt JT.PanelData) {
+
+	if !JC.IsHeadless {
+		JP.UsePanelGrid().Add(createPanel(npdt))
+		JP.UsePanelGrid().ForceRefresh()
+	}
+
+	JA.UseStatus().DetectData()
+
+	JC.Notify(JC.NotifyNewPanelCreated)
+}
+
 func removePanel(uuid string) {
 
-	if JP.UsePanelGrid().RemoveByID(uuid) {
+	if JC.IsHeadless || JP.UsePanelGrid().RemoveByID(uuid) {
 		JC.Logf("Removing panel %s", uuid)
 
 		if JT.UsePanelMaps().Remove(uuid) {
-			JP.UsePanelGrid().ForceRefresh()
+			syncPortfolioTicker()
+
+			if !JC.IsHeadless {
+				JP.UsePanelGrid().ForceRefresh()
 
-			JA.UseLayout().RefreshLayout()
+				JA.UseLayout().RefreshLayout()
+			}
 
 			// Prevent UX locking
 			go func() {
@@ -749,12 +957,16 @@ func savePanelForm(pdt JT.PanelData) {
 
 	JC.Notify(JC.NotifySavingPanelSettings)
 
-	JP.UsePanelGrid().ForceRefresh()
+	if !JC.IsHeadless {
+		JP.UsePanelGrid().ForceRefresh()
+	}
 
 	if !JT.UsePanelMaps().ValidatePanel(pdt.Get()) {
 		pdt.SetStatus(JC.STATE_BAD_CONFIG)
 	}
 
+	syncPortfolioTicker()
+
 	// Prevent UX locking
 	go func() {
 
@@ -766,6 +978,8 @@ func savePanelForm(pdt JT.PanelData) {
 			}
 		}
 
+		refreshPortfolio()
+
 		if JT.SavePanels() {
 
 			if pdt.IsStatus(JC.STATE_BAD_CONFIG) {
@@ -781,8 +995,10 @@ func savePanelForm(pdt JT.PanelData) {
 				sid := pkt.GetSourceCoinString()
 				tid := pkt.GetTargetCoinString()
 
+				provider := JT.UseRateProviders().Resolve(pdt).GetName()
+
 				payloads := map[string][]string{}
-				payloads[JC.ACT_EXCHANGE_GET_RATES] = []string{sid + JC.STRING_PIPE + tid}
+				payloads[JC.ACT_EXCHANGE_GET_RATES] = []string{sid + JC.STRING_PIPE + tid + JC.STRING_PIPE + provider}
 
 				JC.UseFetcher().Call(payloads,
 					func(totalScheduled int) {
@@ -791,7 +1007,7 @@ func savePanelForm(pdt JT.PanelData) {
 						for _, result := range results {
 							JT.UseExchangeCache().SoftReset()
 
-							status := detectHTTPResponse(result.Code())
+							status := JC.DetectHTTPResponse(result.Code())
 
 							switch status {
 							case JC.STATUS_SUCCESS:
@@ -799,6 +1015,8 @@ func savePanelForm(pdt JT.PanelData) {
 									pdt.UpdateStatus()
 								}
 
+								refreshPortfolio()
+
 							case JC.STATUS_NETWORK_ERROR, JC.STATUS_CONFIG_ERROR, JC.STATUS_BAD_DATA_RECEIVED:
 								pdt.SetStatus(JC.STATE_ERROR)
 							}
@@ -820,6 +1038,32 @@ func savePanelForm(pdt JT.PanelData) {
 
 }
 
+func syncPortfolioTicker() {
+
+	hasTicker := len(JT.UseTickerMaps().GetDataByType(JT.TickerTypePortfolio)) != 0
+	hasHoldings := JT.CanShowTicker(JT.TickerTypePortfolio)
+
+	if hasTicker == hasHoldings {
+		return
+	}
+
+	if hasHoldings {
+		JT.UseTickerMaps().Add(JT.NewPortfolioTicker())
+	} else {
+		JT.UseTickerMaps().RemoveByType(JT.TickerTypePortfolio)
+	}
+
+	if !JC.IsHeadless {
+		JX.RegisterTickerGrid(openTickerBreakdown)
+	}
+}
+
+func refreshPortfolio() {
+	if JT.UpdatePortfolio() {
+		updateTickerDisplay()
+	}
+}
+
 func openNewPanelForm() {
 	if JA.UseStatus().IsOverlayShown() {
 		return
@@ -834,12 +1078,7 @@ func openNewPanelForm() {
 			savePanelForm(npdt)
 		},
 		func(npdt JT.PanelData) {
-
-			JP.UsePanelGrid().Add(createPanel(npdt))
-			JP.UsePanelGrid().ForceRefresh()
-			JA.UseStatus().DetectData()
-
-			JC.Notify(JC.NotifyNewPanelCreated)
+			addPanel(npdt)
 		},
 		func(layer *fyne.Container) {
 			JA.UseLayout().RegisterOverlay(layer)
@@ -931,30 +1170,81 @@ func openSettingForm() {
 
 			go func() {
 				if JT.ConfigSave() {
+					applyConfiguration(true)
 					JC.Notify(JC.NotifyConfigurationSavedSuccessfully)
-					JA.UseStatus().DetectData()
+				} else {
+					JC.Notify(JC.NotifyFailedToSaveConfiguration)
+				}
+			}()
+		},
+		func(layer *fyne.Container) {
+			JA.UseLayout().RegisterOverlay(layer)
+		},
+		func(layer *fyne.Container) {
+			JA.UseLayout().RemoveOverlay(layer)
+			JA.UseStatus().SetOverlayShownStatus(false)
+		})
 
-					if JT.UseConfig().IsValidTickers() {
-						if JT.UseTickerMaps().IsEmpty() {
-							JC.Logln("Rebuilding tickers due to empty ticker list")
-							JT.TickersInit()
+	if d != nil {
+		d.Show()
+	}
+}
 
-							JX.RegisterTickerGrid()
-						}
+func openVaultForm() {
+
+	if JA.UseStatus().IsOverlayShown() {
+		return
+	}
 
-						JC.UseWorker().Reload()
+	JA.UseStatus().SetOverlayShownStatus(true)
 
-						JA.UseStatus().SetConfigStatus(true)
+	d := JA.NewVaultForm(
+		func() {
+			JC.Notify(JC.NotifyAuthKeyVaultUnlocked)
 
-						JT.UseTickerCache().SoftReset()
-						JC.UseWorker().Call(JC.ACT_TICKER_UPDATE, JC.CallQueued)
+			JT.UseExchangeCache().SoftReset()
+			JC.UseWorker().Call(JC.ACT_EXCHANGE_UPDATE_RATES, JC.CallQueued)
 
-						JT.UseExchangeCache().SoftReset()
-						JC.UseWorker().Call(JC.ACT_EXCHANGE_UPDATE_RATES, JC.CallQueued)
-					}
-				} else {
+			JT.UseTickerCache().SoftReset()
+			JC.UseWorker().Call(JC.ACT_TICKER_UPDATE, JC.CallQueued)
+		},
+		func(layer *fyne.Container) {
+			JA.UseLayout().RegisterOverlay(layer)
+		},
+		func(layer *fyne.Container) {
+			JA.UseLayout().RemoveOverlay(layer)
+			JA.UseStatus().SetOverlayShownStatus(false)
+		})
+
+	if d != nil {
+		d.Show()
+	}
+}
+
+func openLedgerForm() {
+
+	if JA.UseStatus().IsOverlayShown() {
+		return
+	}
+
+	JA.UseStatus().SetOverlayShownStatus(true)
+
+	d := JA.NewLedgerForm(
+		func() {
+			JC.Notify(JC.NotifySavingLedger)
+
+			go func() {
+				if !JT.UseLedger().Save() {
+					JC.Notify(JC.NotifyFailedToSaveLedger)
+					return
+				}
+
+				if !JT.ConfigSave() {
 					JC.Notify(JC.NotifyFailedToSaveConfiguration)
+					return
 				}
+
+				JC.Notify(JC.NotifyLedgerSavedSuccessfully)
 			}()
 		},
 		func(layer *fyne.Container) {
@@ -970,6 +1260,30 @@ func openSettingForm() {
 	}
 }
 
+func openTickerBreakdown(tickerType string) {
+
+	if JA.UseStatus().IsOverlayShown() {
+		return
+	}
+
+	JA.UseStatus().SetOverlayShownStatus(true)
+
+	d := JA.NewTickerBreakdown(tickerType,
+		func(layer *fyne.Container) {
+			JA.UseLayout().RegisterOverlay(layer)
+		},
+		func(layer *fyne.Container) {
+			JA.UseLayout().RemoveOverlay(layer)
+			JA.UseStatus().SetOverlayShownStatus(false)
+		})
+
+	if d != nil {
+		d.Show()
+	} else {
+		JA.UseStatus().SetOverlayShownStatus(false)
+	}
+}
+
 func toggleDraggable() {
 
 	if JA.UseStatus().IsDraggable() {
@@ -979,6 +1293,8 @@ func toggleDraggable() {
 	}
 
 	JP.UsePanelGrid().ForceRefresh()
+	JX.UseTickerGrid().ForceRefresh()
+
 	if JP.UsePanelGrid().HasActiveAction() {
 		JP.UsePanelGrid().GetActiveAction().HideTarget()
 	}
@@ -1034,6 +1350,21 @@ func appShutdown() {
 		debouncer.Destroy()
 	}
 
+	fileWatcher := JC.UseFileWatcher()
+	if fileWatcher != nil {
+		fileWatcher.Destroy()
+	}
+
+	quota := JC.UseQuota()
+	if quota != nil {
+		quota.Destroy()
+	}
+
+	history := JT.UseExchangeHistory()
+	if history != nil {
+		history.Destroy()
+	}
+
 	animDispatcher := JN.UseAnimationDispatcher()
 	if animDispatcher != nil {
 		animDispatcher.Destroy()
@@ -42,6 +42,10 @@ func registerBoot() {
 		}
 	}
 
+	if JC.IsHeadless {
+		return
+	}
+
 	JC.Window.SetOnClosed(func() {
 		JC.Logln("Window was closed")
 
@@ -53,13 +57,29 @@ func registerBoot() {
 
 		JC.App.Quit()
 	})
+
+	JC.Window.SetCloseIntercept(func() {
+		if canCloseToTray() {
+			hideWindowToTray()
+			return
+		}
+
+		JC.Window.Close()
+	})
 }
 
 func registerTheme() {
 	JC.RegisterThemeManager().Init()
-	// Comment this out for now, as we dont have real settings to force DarkTheme
-	// JC.UseTheme().SetVariant(JC.App.Settings().ThemeVariant())
+	JC.UseTheme().SetMode(JT.LoadConfigTheme())
+	JC.UseTheme().LoadPalette()
 	JC.App.Settings().SetTheme(JC.UseTheme())
+
+	// Rasterized texts do not follow system variant changes on their own
+	JC.App.Settings().AddListener(func(fyne.Settings) {
+		if JC.UseTheme().GetMode() == JC.THEME_SYSTEM {
+			refreshThemedContent()
+		}
+	})
 }
 
 func registerAppIcon() {
@@ -74,6 +94,8 @@ func registerFonts() {
 
 func registerUtility() {
 	JC.RegisterDebouncer().Init()
+	JC.RegisterLocaleManager().Init()
+	JC.UseLocale().SetLocale(JT.LoadConfigLocale())
 	JA.RegisterSnapshotManager().Init()
 	JA.RegisterStatusManager().Init()
 }
@@ -83,7 +105,7 @@ func registerActions() {
 	JA.RegisterActionManager().Init()
 
 	// Ticker toggle
-	JA.UseAction().Add(JW.NewActionButton(JC.ACT_TICKER_TOGGLE, JC.STRING_EMPTY, theme.VisibilityOffIcon(), "Hide / Show Tickers", "disabled",
+	JA.UseAction().Add(JW.NewActionButton(JC.ACT_TICKER_TOGGLE, JC.STRING_EMPTY, theme.VisibilityOffIcon(), JC.Translate("Hide / Show Tickers"), "disabled",
 		func(btn JW.ActionButton) {
 			JA.UseStatus().ToggleTickers()
 		},
@@ -122,7 +144,7 @@ func registerActions() {
 		}))
 
 	// Refresh crypto data
-	JA.UseAction().Add(JW.NewActionButton(JC.ACT_CRYPTO_REFRESH_MAP, JC.STRING_EMPTY, theme.ViewRestoreIcon(), "Refresh cryptos data", "disabled",
+	JA.UseAction().Add(JW.NewActionButton(JC.ACT_CRYPTO_REFRESH_MAP, JC.STRING_EMPTY, theme.ViewRestoreIcon(), JC.Translate("Refresh cryptos data"), "disabled",
 		func(btn JW.ActionButton) {
 			payloads := make(map[string][]string, 1)
 			payloads[JC.ACT_CRYPTO_GET_MAP] = []string{JC.ACT_CRYPTO_GET_MAP}
@@ -142,7 +164,7 @@ func registerActions() {
 
 					for _, result := range results {
 
-						status := detectHTTPResponse(result.Code())
+						status := JC.DetectHTTPResponse(result.Code())
 						processFetchingCryptosComplete(status)
 					}
 				},
@@ -190,7 +212,7 @@ func registerActions() {
 		}))
 
 	// Refresh exchange rates
-	JA.UseAction().Add(JW.NewActionButton(JC.ACT_EXCHANGE_REFRESH_RATES, JC.STRING_EMPTY, theme.ViewRefreshIcon(), "Update rates from exchange", "disabled",
+	JA.UseAction().Add(JW.NewActionButton(JC.ACT_EXCHANGE_REFRESH_RATES, JC.STRING_EMPTY, theme.ViewRefreshIcon(), JC.Translate("Update rates from exchange"), "disabled",
 		func(btn JW.ActionButton) {
 			// Open the network status temporarily
 			JA.UseStatus().SetNetworkStatus(true)
@@ -202,6 +224,7 @@ func registerActions() {
 
 			// Force update
 			JT.UseTickerCache().SoftReset()
+			JT.UseTickerSchedule().Reset()
 			JC.UseWorker().Flush(JC.ACT_TICKER_UPDATE)
 			JC.UseWorker().Call(JC.ACT_TICKER_UPDATE, JC.CallDebounced)
 
@@ -261,7 +284,7 @@ func registerActions() {
 		}))
 
 	// Open settings
-	JA.UseAction().Add(JW.NewActionButton(JC.ACT_OPEN_SETTINGS, JC.STRING_EMPTY, theme.SettingsIcon(), "Open settings", "disabled",
+	JA.UseAction().Add(JW.NewActionButton(JC.ACT_OPEN_SETTINGS, JC.STRING_EMPTY, theme.SettingsIcon(), JC.Translate("Open settings"), "disabled",
 		func(btn JW.ActionButton) {
 			openSettingForm()
 		},
@@ -299,8 +322,37 @@ func registerActions() {
 			btn.Enable()
 		}))
 
+	// Open ledger
+	JA.UseAction().Add(JW.NewActionButton(JC.ACT_OPEN_LEDGER, JC.STRING_EMPTY, theme.ListIcon(), JC.Translate("Open ledger"), "disabled",
+		func(btn JW.ActionButton) {
+			openLedgerForm()
+		},
+		func(btn JW.ActionButton) {
+			if !JA.UseStatus().IsReady() {
+				btn.Disable()
+				return
+			}
+
+			if JA.UseStatus().IsOverlayShown() {
+				btn.DisallowActions()
+				return
+			}
+
+			if JA.UseStatus().IsDraggable() {
+				btn.Disable()
+				return
+			}
+
+			if JA.UseStatus().IsFetchingCryptos() {
+				btn.Disable()
+				return
+			}
+
+			btn.Enable()
+		}))
+
 	// Panel drag toggle
-	JA.UseAction().Add(JW.NewActionButton(JC.ACT_PANEL_DRAG, JC.STRING_EMPTY, theme.ContentPasteIcon(), "Enable Reordering", "disabled",
+	JA.UseAction().Add(JW.NewActionButton(JC.ACT_PANEL_DRAG, JC.STRING_EMPTY, theme.ContentPasteIcon(), JC.Translate("Enable Reordering"), "disabled",
 		func(btn JW.ActionButton) {
 			toggleDraggable()
 		},
@@ -333,7 +385,7 @@ func registerActions() {
 				return
 			}
 
-			if JT.UsePanelMaps().TotalData() < 2 {
+			if JT.UsePanelMaps().TotalData() < 2 && JT.UseTickerMaps().TotalData() < 2 {
 				JA.UseStatus().DisallowDragging()
 				btn.Disable()
 				return
@@ -348,7 +400,7 @@ func registerActions() {
 		}))
 
 	// Add new panel
-	JA.UseAction().Add(JW.NewActionButton(JC.ACT_PANEL_ADD, JC.STRING_EMPTY, theme.ContentAddIcon(), "Add new panel", "disabled",
+	JA.UseAction().Add(JW.NewActionButton(JC.ACT_PANEL_ADD, JC.STRING_EMPTY, theme.ContentAddIcon(), JC.Translate("Add new panel"), "disabled",
 		func(btn JW.ActionButton) {
 			openNewPanelForm()
 		},
@@ -395,6 +447,7 @@ func registerWorkers() {
 		func(any) bool {
 			completed := updateRates()
 			processWatcher()
+			updateTray()
 			return completed
 		},
 		func() bool {
@@ -429,9 +482,7 @@ func registerWorkers() {
 	JC.UseWorker().Register(
 		JC.ACT_TICKER_UPDATE, 1,
 		nil,
-		func() int64 {
-			return max(JT.UseConfig().Delay*1000, 30000)
-		},
+		nil,
 		func(any) bool {
 			return updateTickers()
 		},
@@ -448,10 +499,6 @@ func registerWorkers() {
 				JC.Logln("Unable to refresh tickers: Invalid ticker configuration")
 				return false
 			}
-			if !JT.UseTickerCache().ShouldRefresh() {
-				JC.Logln("Unable to refresh tickers: Ticker cache shouldn't be refreshed yet")
-				return false
-			}
 			if !JA.UseStatus().IsTickerShown() {
 				JC.Logln("Unable to refresh tickers: Ticker is not visible")
 				return false
@@ -469,6 +516,12 @@ func registerWorkers() {
 		nil,
 		func(payload any) bool {
 			latest, _ := payload.(string)
+
+			if JC.IsHeadless {
+				JC.Logln("Notification:", latest)
+				return true
+			}
+
 			fyne.Do(func() {
 				JW.UseNotification().SetText(latest)
 			})
@@ -723,6 +776,35 @@ func registerFetchers() {
 		},
 	)
 
+	JC.UseFetcher().Register(
+		JT.TickerTypeFiatRate,
+		JC.NewFetcherUnit(
+			func(ctx context.Context, payload any) (JC.FetchResultInterface, error) {
+				return JC.NewFetchResult(JT.NewFiatRateFetcher().GetRate(ctx, payload)), ctx.Err()
+			},
+		),
+		func() bool {
+			if !JA.UseStatus().IsReady() {
+				JC.Logln("Unable to fetch fiat rate: app is not ready yet")
+				return false
+			}
+			if JA.UseStatus().IsPaused() {
+				JC.Logln("Unable to fetch fiat rate: app is paused")
+				return false
+			}
+			if !JT.NeedsFiatRate() {
+				JC.Logln("Unable to fetch fiat rate: No currency ticker in a non USD fiat")
+				return false
+			}
+			if !JA.UseStatus().IsTickerShown() {
+				JC.Logln("Unable to fetch fiat rate: Ticker is not visible")
+				return false
+			}
+
+			return true
+		},
+	)
+
 	JC.UseFetcher().Register(
 		JC.ACT_EXCHANGE_GET_RATES,
 		JC.NewFetcherUnit(
@@ -753,6 +835,85 @@ func registerFetchers() {
 	)
 }
 
+var customTickerFetchers []string
+
+func registerCustomTickerFetchers() {
+
+	for _, key := range customTickerFetchers {
+		JC.UseFetcher().Deregister(key)
+	}
+
+	customTickerFetchers = []string{}
+
+	for _, ct := range JT.UseConfig().GetCustomTickers() {
+		key := ct.GetType()
+
+		JC.UseFetcher().Register(
+			key,
+			JC.NewFetcherUnit(
+				func(ctx context.Context, payload any) (JC.FetchResultInterface, error) {
+					return JC.NewFetchResult(JT.NewCustomTickerFetcher(ct).GetRate(ctx, payload)), ctx.Err()
+				},
+			),
+			func() bool {
+				if !JA.UseStatus().IsReady() {
+					JC.Logf("Unable to fetch %s: app is not ready yet", key)
+					return false
+				}
+				if JA.UseStatus().IsPaused() {
+					JC.Logf("Unable to fetch %s: app is paused", key)
+					return false
+				}
+				if JT.GetCustomTicker(key) == nil {
+					JC.Logf("Unable to fetch %s: Invalid config", key)
+					return false
+				}
+				if !JA.UseStatus().IsTickerShown() {
+					JC.Logf("Unable to fetch %s: Ticker is not visible", key)
+					return false
+				}
+
+				return true
+			},
+		)
+
+		customTickerFetchers = append(customTickerFetchers, key)
+	}
+}
+
+var tickerScheduleWorkers []string
+
+// Each ticker fetcher wakes up on its own schedule and queues the shared ticker update
+func registerTickerSchedules() {
+
+	for _, key := range tickerScheduleWorkers {
+		JC.UseWorker().Deregister(key)
+	}
+
+	tickerScheduleWorkers = []string{}
+
+	for _, tickerType := range tickerFetchTypes() {
+		key := tickerWorkerKey(tickerType)
+
+		JC.UseWorker().Register(
+			key, 1,
+			nil,
+			func() int64 {
+				return max(JT.UseTickerSchedule().Until(tickerType).Milliseconds(), 10000)
+			},
+			func(any) bool {
+				JC.UseWorker().Call(JC.ACT_TICKER_UPDATE, JC.CallQueued)
+				return true
+			},
+			func() bool {
+				return canFetchTicker(tickerType) && JT.UseTickerSchedule().IsDue(tickerType)
+			},
+		)
+
+		tickerScheduleWorkers = append(tickerScheduleWorkers, key)
+	}
+}
+
 func registerLifecycle() {
 
 	var isAppStarted bool = false
@@ -777,34 +938,13 @@ func registerLifecycle() {
 			// Prevent locking when initialized at first install
 			JC.UseDebouncer().Call("initializing", 1*time.Millisecond, func() {
 
-				if !JT.ConfigInit() {
-				}
-
-				if JA.UseSnapshot().LoadCryptos() == JC.NO_SNAPSHOT {
-					JT.CryptosLoaderInit()
-				}
+				loadAppData()
 
-				if JA.UseSnapshot().LoadExchangeData() == JC.NO_SNAPSHOT {
-					JT.UseExchangeCache().Reset()
-				}
-
-				if JA.UseSnapshot().LoadTickerData() == JC.NO_SNAPSHOT {
-					JT.UseTickerCache().Reset()
-				}
-
-				if JA.UseSnapshot().LoadPanels() == JC.NO_SNAPSHOT {
-					JT.PanelsInit()
-				}
-
-				if JA.UseSnapshot().LoadTickers() == JC.NO_SNAPSHOT {
-					JT.TickersInit()
-				}
-
-				JT.UseConfig().PostInit()
+				registerAPI()
 
 				fyne.Do(func() {
 
-					JS.RegisterTickerGrid()
+					JS.RegisterTickerGrid(openTickerBreakdown)
 					JP.RegisterPanelGrid(createPanel)
 
 					JA.UseStatus().InitData()
@@ -812,6 +952,14 @@ func registerLifecycle() {
 					JP.UsePanelGrid().Refresh()
 					JA.UseLayout().UpdateState()
 
+					registerTray()
+
+					registerFileWatcher()
+
+					if JT.UseAuthKey().IsLocked() {
+						openVaultForm()
+					}
+
 					JC.Logln("App is ready: ", JA.UseStatus().IsReady())
 
 					if !JA.UseStatus().HasError() {
@@ -909,6 +1057,13 @@ func registerDispatcher() {
 	JC.PrintPerfStats("Creating Dispatcher Buffer", time.Now())
 
 	JC.RegisterDispatcher().Init()
+	JC.UseDispatcher().SetKey("core")
+	JC.UseDispatcher().Start()
+
+	if JC.IsHeadless {
+		return
+	}
+
 	JX.RegisterAnimationDispatcher().Init()
 
 	ad := JX.UseAnimationDispatcher()
@@ -930,5 +1085,9 @@ func registerCache() {
 
 	JT.RegisterExchangeCache().Init()
 
+	JT.RegisterExchangeHistory().Init()
+
 	JT.RegisterTickerCache().Init()
+
+	JT.RegisterTickerSchedule().Init()
 }
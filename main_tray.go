@@ -0,0 +1,151 @@
+package main
+
+import (
+	"sync/atomic"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+
+	JA "jxwatcher/apps"
+	JC "jxwatcher/core"
+	JT "jxwatcher/types"
+)
+
+var trayApp desktop.App = nil
+var trayWindowHidden atomic.Bool
+
+// Only desktop drivers provide a system tray, it is registered once config is loaded
+func registerTray() {
+	if JC.IsHeadless || !JT.UseConfig().CanShowTray() {
+		return
+	}
+
+	desk, ok := JC.App.(desktop.App)
+	if !ok {
+		JC.Logln("System tray is not supported by this driver")
+		return
+	}
+
+	trayApp = desk
+	trayApp.SetSystemTrayIcon(fyne.NewStaticResource("jxwatcher.png", appIconData))
+
+	updateTray()
+}
+
+// Registers or removes the tray when the tray setting changes after startup
+func syncTray() {
+	if JC.IsHeadless {
+		return
+	}
+
+	if !JT.UseConfig().CanShowTray() {
+		removeTray()
+		return
+	}
+
+	if trayApp == nil {
+		fyne.Do(registerTray)
+		return
+	}
+
+	updateTray()
+}
+
+// The desktop driver cannot drop the icon, so the menu is emptied and the window brought back
+func removeTray() {
+	if trayApp == nil {
+		return
+	}
+
+	desk := trayApp
+	trayApp = nil
+
+	fyne.Do(func() {
+		desk.SetSystemTrayMenu(fyne.NewMenu(JC.AppID))
+	})
+
+	if trayWindowHidden.Load() {
+		fyne.Do(showWindowFromTray)
+	}
+}
+
+func updateTray() {
+	if trayApp == nil {
+		return
+	}
+
+	items := []*fyne.MenuItem{}
+
+	summaries := JT.GetPanelSummaries(JT.UseConfig().GetTrayPanels())
+	for _, ps := range summaries {
+		item := fyne.NewMenuItem(ps.Format(), showWindowFromTray)
+		item.Checked = ps.Fired
+		items = append(items, item)
+	}
+
+	if len(summaries) == 0 {
+		item := fyne.NewMenuItem(JC.Translate("No panels yet"), nil)
+		item.Disabled = true
+		items = append(items, item)
+	}
+
+	watcher := fyne.NewMenuItem(JC.Translate("No watcher has fired"), nil)
+	if JT.HasFiredWatchers() {
+		watcher.Label = JC.Translate("A watcher has fired")
+	}
+	watcher.Disabled = true
+
+	items = append(items, fyne.NewMenuItemSeparator(), watcher)
+
+	if JC.UseQuota() != nil {
+		quota := fyne.NewMenuItem(JC.UseQuota().GetUsage().Format(), nil)
+		quota.Disabled = true
+		items = append(items, quota)
+	}
+
+	items = append(items, fyne.NewMenuItemSeparator())
+
+	items = append(items, fyne.NewMenuItem(JC.Translate("Refresh Rates"), func() {
+		JA.UseAction().Call(JC.ACT_EXCHANGE_REFRESH_RATES)
+	}))
+
+	if JA.UseStatus().IsPaused() {
+		items = append(items, fyne.NewMenuItem(JC.Translate("Resume Fetching"), func() {
+			JA.UseStatus().Resume()
+			updateTray()
+		}))
+	} else {
+		items = append(items, fyne.NewMenuItem(JC.Translate("Pause Fetching"), func() {
+			JA.UseStatus().Pause()
+			updateTray()
+		}))
+	}
+
+	if trayWindowHidden.Load() {
+		items = append(items, fyne.NewMenuItem(JC.Translate("Show Window"), showWindowFromTray))
+	} else {
+		items = append(items, fyne.NewMenuItem(JC.Translate("Hide Window"), hideWindowToTray))
+	}
+
+	fyne.Do(func() {
+		trayApp.SetSystemTrayMenu(fyne.NewMenu(JC.AppID, items...))
+	})
+}
+
+func showWindowFromTray() {
+	trayWindowHidden.Store(false)
+	JC.Window.Show()
+	JC.Window.RequestFocus()
+	updateTray()
+}
+
+func hideWindowToTray() {
+	trayWindowHidden.Store(true)
+	JC.Window.Hide()
+	updateTray()
+}
+
+// Config is not loaded until the app has started, so closing quits until then
+func canCloseToTray() bool {
+	return trayApp != nil && JT.UseConfig() != nil && JT.UseConfig().CanCloseToTray()
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+
+	JA "jxwatcher/apps"
+	JC "jxwatcher/core"
+	JP "jxwatcher/panels"
+	JT "jxwatcher/types"
+)
+
+// Config files can be managed by external tools, pick up their edits while running
+func registerFileWatcher() {
+	if JC.IsMobile {
+		return
+	}
+
+	JC.RegisterFileWatcher().Init()
+
+	files := map[string]func(data []byte){
+		"config.json": reloadConfig,
+		"panels.json": reloadPanels,
+	}
+
+	for name, fn := range files {
+		if err := JC.UseFileWatcher().Add(filepath.Join(JC.GetUserDirectory(), name), fn); err != nil {
+			JC.Logln("Failed to watch", name, err)
+		}
+	}
+}
+
+func reloadConfig(data []byte) {
+	delayChanged, err := JT.ConfigReload(data)
+	if err != nil {
+		JC.Logln("Ignoring invalid config.json:", err)
+		JC.Notify(JC.NotifyUnableToReloadConfigurationFile)
+		return
+	}
+
+	applyConfiguration(delayChanged)
+
+	JC.Notify(JC.NotifyConfigurationReloadedFromFile)
+}
+
+// Panel maps and their bindings belong to the UI thread, the watcher goroutine only hands over the data
+func reloadPanels(data []byte) {
+	if JC.IsHeadless {
+		applyPanelsFile(data)
+		return
+	}
+
+	fyne.Do(func() {
+		applyPanelsFile(data)
+	})
+}
+
+func applyPanelsFile(data []byte) {
+	diff, err := JT.ReloadPanels(data)
+	if err != nil {
+		JC.Logln("Ignoring invalid panels.json:", err)
+		JC.Notify(JC.NotifyUnableToReloadPanelsFile)
+		return
+	}
+
+	if diff.IsEmpty() {
+		return
+	}
+
+	JC.Logf("Panels reloaded: %d added, %d changed, %d removed", len(diff.Added), len(diff.Changed), len(diff.Removed))
+
+	changed := map[string]bool{}
+	for _, list := range [][]JT.PanelData{diff.Added, diff.Changed} {
+		for _, pdt := range list {
+			if !JT.UsePanelMaps().ValidatePanel(pdt.Get()) {
+				pdt.SetStatus(JC.STATE_BAD_CONFIG)
+			}
+			changed[pdt.GetID()] = true
+		}
+	}
+
+	if JC.IsHeadless {
+		syncPortfolioTicker()
+	} else {
+		for _, uuid := range diff.Removed {
+			JP.UsePanelGrid().RemoveByID(uuid)
+		}
+
+		for _, pdt := range diff.Added {
+			JP.UsePanelGrid().Add(createPanel(pdt))
+		}
+
+		JP.UsePanelGrid().SyncOrder()
+		JP.UsePanelGrid().UpdatePanelsContent(func(pdt JT.PanelData) bool {
+			return pdt != nil && changed[pdt.GetID()]
+		})
+		JP.UsePanelGrid().ForceRefresh()
+
+		JA.UseLayout().RefreshLayout()
+
+		syncPortfolioTicker()
+	}
+
+	refreshPortfolio()
+
+	JA.UseStatus().DetectData()
+	updateTray()
+
+	if len(changed) != 0 {
+		JT.UseExchangeCache().SoftReset()
+		JC.UseWorker().Call(JC.ACT_EXCHANGE_UPDATE_RATES, JC.CallQueued)
+	}
+
+	JC.Notify(JC.NotifyPanelsReloadedFromFile)
+}
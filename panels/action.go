@@ -65,7 +65,7 @@ func NewPanelAction(
 ) *panelAction {
 
 	pa := &panelAction{}
-	pa.watcherBtn = JW.NewActionButton(JC.ACT_WATCHER_EDIT, JC.STRING_EMPTY, theme.CalendarIcon(), "Manage Watcher", JW.ActionStateNormal,
+	pa.watcherBtn = JW.NewActionButton(JC.ACT_WATCHER_EDIT, JC.STRING_EMPTY, theme.CalendarIcon(), JC.Translate("Manage Watcher"), JW.ActionStateNormal,
 		func(JW.ActionButton) {
 			if onWatcherAction != nil {
 				onWatcherAction()
@@ -113,7 +113,7 @@ func NewPanelAction(
 			btn.Enable()
 		})
 
-	pa.editBtn = JW.NewActionButton(JC.ACT_PANEL_EDIT, JC.STRING_EMPTY, theme.DocumentCreateIcon(), "Edit panel", JW.ActionStateNormal,
+	pa.editBtn = JW.NewActionButton(JC.ACT_PANEL_EDIT, JC.STRING_EMPTY, theme.DocumentCreateIcon(), JC.Translate("Edit panel"), JW.ActionStateNormal,
 		func(JW.ActionButton) {
 			if onEdit != nil {
 				onEdit()
@@ -142,7 +142,7 @@ func NewPanelAction(
 			btn.Enable()
 		})
 
-	pa.deleteBtn = JW.NewActionButton(JC.ACT_PANEL_DELETE, JC.STRING_EMPTY, theme.DeleteIcon(), "Delete panel", JW.ActionStateNormal,
+	pa.deleteBtn = JW.NewActionButton(JC.ACT_PANEL_DELETE, JC.STRING_EMPTY, theme.DeleteIcon(), JC.Translate("Delete panel"), JW.ActionStateNormal,
 		func(JW.ActionButton) {
 			if onDelete != nil {
 				onDelete()
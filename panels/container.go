@@ -48,6 +48,30 @@ func (c *panelContainer) RemoveByID(uuid string) bool {
 	return false
 }
 
+// Follows the panel maps order, for when panels were reordered outside the grid
+func (c *panelContainer) SyncOrder() {
+	objects := make([]fyne.CanvasObject, 0, len(c.Objects))
+	placed := make(map[fyne.CanvasObject]bool, len(c.Objects))
+
+	for _, pdt := range JT.UsePanelMaps().GetData() {
+		for _, obj := range c.Objects {
+			if panel, ok := obj.(*panelDisplay); ok && !placed[obj] && panel.GetTag() == pdt.GetID() {
+				objects = append(objects, obj)
+				placed[obj] = true
+				break
+			}
+		}
+	}
+
+	for _, obj := range c.Objects {
+		if !placed[obj] {
+			objects = append(objects, obj)
+		}
+	}
+
+	c.Objects = objects
+}
+
 func (c *panelContainer) ForceRefresh() {
 	c.layout.Reset()
 	c.Refresh()
@@ -1,7 +1,6 @@
 package panels
 
 import (
-	"fmt"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -16,8 +15,11 @@ import (
 	JM "jxwatcher/apps"
 	JC "jxwatcher/core"
 	JT "jxwatcher/types"
+	JW "jxwatcher/widgets"
 )
 
+const panelSparklinePoints = 48
+
 var activeDragging *panelDisplay = nil
 
 type PanelDisplay interface {
@@ -48,6 +50,7 @@ type panelDisplay struct {
 	content         *panelText
 	subtitle        *panelText
 	bottomText      *panelText
+	sparkline       *JW.Sparkline
 	watcherSign     *canvas.Image
 	activeColor     fyne.ThemeColorName
 	onEdit          func()
@@ -97,6 +100,8 @@ func (h *panelDisplay) Show() {
 
 	h.bottomText = NewPanelText(JC.STRING_EMPTY, tc, JC.UseTheme().Size(JC.SizePanelBottomText), fyne.TextAlignCenter, fyne.TextStyle{Bold: false})
 
+	h.sparkline = JW.NewSparkline(JC.UseTheme().GetColor(JC.ColorNamePanelSparkline), JC.UseTheme().Size(JC.SizePanelSparklineStroke))
+
 	res := theme.NewThemedResource(theme.CalendarIcon())
 	res.ColorName = theme.ColorNameForeground
 
@@ -106,9 +111,10 @@ func (h *panelDisplay) Show() {
 	h.watcherSign.Translucency = 1.0
 
 	h.container.Layout.(*panelDisplayLayout).RemoveAll()
-	h.container.Layout.(*panelDisplayLayout).SetContent(h.background, h.title, h.subtitle, h.content, h.bottomText, h.watcherSign, nil)
+	h.container.Layout.(*panelDisplayLayout).SetContent(h.background, h.title, h.subtitle, h.content, h.bottomText, h.sparkline, h.watcherSign, nil)
 	h.container.Objects = []fyne.CanvasObject{
 		h.background,
+		h.sparkline,
 		h.title,
 		h.subtitle,
 		h.content,
@@ -146,12 +152,14 @@ func (h *panelDisplay) Hide() {
 	h.subtitle.Destroy()
 	h.content.Destroy()
 	h.bottomText.Destroy()
+	h.sparkline.Destroy()
 
 	h.background = nil
 	h.title = nil
 	h.subtitle = nil
 	h.content = nil
 	h.bottomText = nil
+	h.sparkline = nil
 	h.watcherSign = nil
 
 	h.removeAction()
@@ -265,6 +273,15 @@ func (h *panelDisplay) updateContent() {
 		}
 	}
 
+	tc := JC.UseTheme().GetColor(theme.ColorNameForeground)
+	h.title.SetTextColor(tc)
+	h.subtitle.SetTextColor(tc)
+	h.bottomText.SetTextColor(tc)
+	h.content.SetTextColor(tc)
+	if h.sparkline != nil {
+		h.sparkline.SetColor(JC.UseTheme().GetColor(JC.ColorNamePanelSparkline))
+	}
+
 	if !JT.UsePanelMaps().ValidatePanel(pkt.Get()) {
 		pkt.SetStatus(JC.STATE_BAD_CONFIG)
 	}
@@ -287,17 +304,17 @@ func (h *panelDisplay) updateContent() {
 
 	switch h.status {
 	case JC.STATE_ERROR:
-		title = "Error loading data"
+		title = JC.Translate("Error loading data")
 		h.activeColor = JC.ColorNameError
 
 	case JC.STATE_FETCHING_NEW:
-		title = "Fetching Rates..."
+		title = JC.Translate("Fetching Rates...")
 
 	case JC.STATE_LOADING:
-		title = "Loading..."
+		title = JC.Translate("Loading...")
 
 	case JC.STATE_BAD_CONFIG:
-		title = "Invalid Panel"
+		title = JC.Translate("Invalid Panel")
 		h.activeColor = JC.ColorNameError
 
 	case JC.STATE_LOADED:
@@ -316,9 +333,20 @@ func (h *panelDisplay) updateContent() {
 			}
 		}
 
+		if color, ok := pkt.GetColor(); ok {
+			h.activeColor = color
+		}
+
 		title = JC.TruncateText(pkt.FormatTitle(), pwidth-20, h.title.textSize, h.title.textStyle)
 		subtitle = JC.TruncateText(pkt.FormatSubtitle(), pwidth-20, h.subtitle.textSize, h.subtitle.textStyle)
-		bottomText = JC.TruncateText(pkt.FormatBottomText(), pwidth-20, h.bottomText.textSize, h.bottomText.textStyle)
+		bottomText = pkt.FormatBottomText()
+		if pnl := pkt.FormatPnL(); pnl != JC.STRING_EMPTY {
+			bottomText = pnl
+		}
+		if source := pkt.FormatRateSource(); source != JC.STRING_EMPTY {
+			bottomText += " · " + source
+		}
+		bottomText = JC.TruncateText(bottomText, pwidth-20, h.bottomText.textSize, h.bottomText.textStyle)
 		content = JC.TruncateText(pkt.FormatContent(), pwidth-20, h.content.textSize, h.content.textStyle)
 	}
 
@@ -327,6 +355,22 @@ func (h *panelDisplay) updateContent() {
 	h.bottomText.SetText(bottomText)
 	h.content.SetText(content)
 
+	if h.sparkline != nil {
+		sparklineVisible := h.sparkline.Visible()
+
+		if h.status == JC.STATE_LOADED && JT.UseConfig().CanShowSparkline() {
+			if pkt.DidChange() || !sparklineVisible {
+				h.sparkline.SetValues(pkt.GetRecentRates(panelSparklinePoints))
+			}
+		} else {
+			h.sparkline.SetValues(nil)
+		}
+
+		if sparklineVisible != h.sparkline.Visible() {
+			h.container.Layout.Layout(h.container.Objects, h.container.Size())
+		}
+	}
+
 	if h.watcherSign != nil {
 		wkt := pkt.UseWatcherKey()
 		opacity := 1.0
@@ -460,12 +504,19 @@ func (h *panelDisplay) panelDrag(ev *fyne.DragEvent) {
 func (h *panelDisplay) snapToNearest() {
 
 	// Convert target position to grid index
-	targetIndex := h.findTargetIndex()
+	targetIndex := JW.FindDropIndex(dragDropZones, h.dragOffset, h.GetTag(), "panel")
 	if targetIndex == -1 {
 		return
 	}
 
-	UsePanelGrid().Objects = h.reorder(targetIndex)
+	UsePanelGrid().Objects = JW.MoveObject(UsePanelGrid().Objects, h, targetIndex)
+
+	if !h.Visible() {
+		h.Show()
+	}
+
+	JA.StartFadeInBackground(h.tag, h.background, 300*time.Millisecond, nil, false)
+
 	UsePanelGrid().ForceRefresh()
 
 	JC.UseDebouncer().Call("panel_drag", 1000*time.Millisecond, func() {
@@ -477,69 +528,12 @@ func (h *panelDisplay) snapToNearest() {
 	})
 }
 
-func (h *panelDisplay) findTargetIndex() int {
-
-	JC.Logln(fmt.Sprintf("Dragging item - Position: (%.2f, %.2f)", h.dragOffset.X, h.dragOffset.Y))
-
-	for i, zone := range dragDropZones {
-
-		JC.Logln(fmt.Sprintf("Checking panel %d — Bounds: [X: %.2f–%.2f, Y: %.2f–%.2f]", i, zone.left, zone.right, zone.top, zone.bottom))
-
-		if h.dragOffset.X >= zone.left &&
-			h.dragOffset.X <= zone.right &&
-			h.dragOffset.Y >= zone.top &&
-			h.dragOffset.Y <= zone.bottom {
-
-			if zone.uuid == h.GetTag() {
-				JC.Logln(fmt.Sprintf("Refusing to drop panel to the old position %d", i))
-				return -1
-			}
-
-			JC.Logln(fmt.Sprintf("Dropped inside panel %d", i))
-
-			return i
-		}
-	}
-
-	JC.Logln("Refuse to drop panel to invalid drop position")
-
-	return -1
-}
-
-func (h *panelDisplay) reorder(targetIndex int) []fyne.CanvasObject {
-	panels := UsePanelGrid().Objects
-	var result []fyne.CanvasObject
-	for _, obj := range panels {
-		if obj != h {
-			result = append(result, obj)
-		}
-	}
-
-	if targetIndex >= len(result) {
-		result = append(result, h)
-	} else {
-		result = append(result[:targetIndex], append([]fyne.CanvasObject{h}, result[targetIndex:]...)...)
-	}
-
-	if !h.Visible() {
-		h.Show()
-	}
-
-	JA.StartFadeInBackground(h.tag, h.background, 300*time.Millisecond, nil, false)
-
-	return result
-}
-
 func (h *panelDisplay) syncData() bool {
 	nd := []JT.PanelData{}
 
-	for _, obj := range UsePanelGrid().Objects {
-		if panel, ok := obj.(*panelDisplay); ok {
-			uuid := panel.GetTag()
-			pdt := JT.UsePanelMaps().GetDataByID(uuid)
-			if pdt != nil {
-				nd = append(nd, pdt)
-			}
+	for _, uuid := range JW.OrderedTags(UsePanelGrid().Objects, (*panelDisplay).GetTag) {
+		if pdt := JT.UsePanelMaps().GetDataByID(uuid); pdt != nil {
+			nd = append(nd, pdt)
 		}
 	}
 
@@ -568,8 +562,11 @@ func (h *panelDisplay) removeAction() {
 
 func NewPanelDisplay(pdt JT.PanelData, onEdit func(pk string, uuid string), onDelete func(uuid string), onWatcherAction func(uuid string)) *panelDisplay {
 
-	uuid := JC.CreateUUID()
-	pdt.SetID(uuid)
+	uuid := pdt.GetID()
+	if uuid == JC.STRING_EMPTY {
+		uuid = JC.CreateUUID()
+		pdt.SetID(uuid)
+	}
 	pv := pdt.UseData()
 
 	pd := &panelDisplay{
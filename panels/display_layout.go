@@ -5,6 +5,7 @@ import (
 	"fyne.io/fyne/v2/canvas"
 
 	JC "jxwatcher/core"
+	JW "jxwatcher/widgets"
 )
 
 var panelDisplayLayoutCachedSize fyne.Size
@@ -15,6 +16,7 @@ type panelDisplayLayout struct {
 	content     *panelText
 	subtitle    *panelText
 	bottomText  *panelText
+	sparkline   *JW.Sparkline
 	watcherSign *canvas.Image
 	action      *panelAction
 }
@@ -40,6 +42,25 @@ func (pl *panelDisplayLayout) Layout(objects []fyne.CanvasObject, size fyne.Size
 		}
 	}
 
+	if pl.sparkline != nil && pl.sparkline.Visible() {
+		inset := JC.UseTheme().Size(JC.SizePanelBorderRadius)
+		height := JC.UseTheme().Size(JC.SizePanelSparkline)
+		if size.Width < JC.UseTheme().Size(JC.SizePanelWidth) {
+			height = JC.UseTheme().Size(JC.SizePanelSparklineSmall)
+		}
+
+		sparkSize := fyne.NewSize(size.Width-2*inset, fyne.Min(height, size.Height/2))
+		sparkPos := fyne.NewPos(inset, size.Height-sparkSize.Height-inset)
+
+		if pl.sparkline.Position() != sparkPos {
+			pl.sparkline.Move(sparkPos)
+		}
+
+		if pl.sparkline.Size() != sparkSize {
+			pl.sparkline.Resize(sparkSize)
+		}
+	}
+
 	centerItems := []fyne.CanvasObject{}
 	sizes := []fyne.Size{}
 	totalHeight := float32(0)
@@ -123,15 +144,16 @@ func (pl *panelDisplayLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
 }
 
 func (pl *panelDisplayLayout) RemoveAll() {
-	pl.SetContent(nil, nil, nil, nil, nil, nil, nil)
+	pl.SetContent(nil, nil, nil, nil, nil, nil, nil, nil)
 }
 
-func (pl *panelDisplayLayout) SetContent(background *canvas.Rectangle, title *panelText, subtitle *panelText, content *panelText, bottomText *panelText, watcherSign *canvas.Image, action *panelAction) {
+func (pl *panelDisplayLayout) SetContent(background *canvas.Rectangle, title *panelText, subtitle *panelText, content *panelText, bottomText *panelText, sparkline *JW.Sparkline, watcherSign *canvas.Image, action *panelAction) {
 	pl.background = background
 	pl.title = title
 	pl.subtitle = subtitle
 	pl.content = content
 	pl.bottomText = bottomText
+	pl.sparkline = sparkline
 	pl.watcherSign = watcherSign
 	pl.action = action
 }
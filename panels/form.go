@@ -1,7 +1,7 @@
 package panels
 
 import (
-	"fmt"
+	"errors"
 	"math"
 	"strconv"
 	"time"
@@ -33,14 +33,14 @@ func NewPanelForm(
 			return nil
 		}
 		if len(s) == 0 {
-			return fmt.Errorf("This field is required")
+			return errors.New(JC.Translate("This field is required"))
 		}
 		value, err := strconv.ParseFloat(s, 64)
 		if err != nil {
-			return fmt.Errorf("No decimals allowed")
+			return errors.New(JC.Translate("No decimals allowed"))
 		}
 		if math.Abs(value) < JC.EPSILON || value <= 0 {
-			return fmt.Errorf("Must larger than zero")
+			return errors.New(JC.Translate("Must larger than zero"))
 		}
 		return nil
 	}
@@ -50,17 +50,17 @@ func NewPanelForm(
 			return nil
 		}
 		if len(s) == 0 {
-			return fmt.Errorf("Please select a cryptocurrency")
+			return errors.New(JC.Translate("Please select a cryptocurrency"))
 		}
 		tid := JT.UsePanelMaps().GetIdByDisplay(s)
 		id, err := strconv.ParseInt(tid, 10, 64)
 		if err != nil || !JT.UsePanelMaps().ValidateId(id) {
-			return fmt.Errorf("Invalid cryptocurrency selected")
+			return errors.New(JC.Translate("Invalid cryptocurrency selected"))
 		}
 		xid := JT.UsePanelMaps().GetIdByDisplay(other)
 		bid, err := strconv.ParseInt(xid, 10, 64)
 		if err == nil && JT.UsePanelMaps().ValidateId(bid) && bid == id {
-			return fmt.Errorf("Source and target must different")
+			return errors.New(JC.Translate("Source and target must different"))
 		}
 		return nil
 	}
@@ -70,17 +70,45 @@ func NewPanelForm(
 			return nil
 		}
 		if len(s) == 0 {
-			return fmt.Errorf("This field cannot be empty")
+			return errors.New(JC.Translate("This field cannot be empty"))
 		}
 		x, err := strconv.ParseInt(s, 10, 64)
 		if err != nil {
-			return fmt.Errorf("No decimals allowed")
+			return errors.New(JC.Translate("No decimals allowed"))
 		}
 		if x < 0 {
-			return fmt.Errorf("Must larger than zero")
+			return errors.New(JC.Translate("Must larger than zero"))
 		}
 		if x > 20 {
-			return fmt.Errorf("Maximum 20 decimal digits")
+			return errors.New(JC.Translate("Maximum 20 decimal digits"))
+		}
+		return nil
+	}
+
+	validateCostBasis := func(s string) error {
+		if !allowValidation || len(s) == 0 {
+			return nil
+		}
+		value, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return errors.New(JC.Translate("Invalid number"))
+		}
+		if value < 0 {
+			return errors.New(JC.Translate("Must not be negative"))
+		}
+		return nil
+	}
+
+	validateAcquired := func(s string) error {
+		if !allowValidation || len(s) == 0 {
+			return nil
+		}
+		ts, ok := JT.ParseAcquiredDate(s)
+		if !ok {
+			return errors.New(JC.Translate("Use YYYY-MM-DD"))
+		}
+		if ts > time.Now().Unix() {
+			return errors.New(JC.Translate("Cannot be in the future"))
 		}
 		return nil
 	}
@@ -96,15 +124,24 @@ func NewPanelForm(
 	se := JW.NewCompletionEntry(cm, cs, pse)
 	te := JW.NewCompletionEntry(cm, cs, pte)
 	de := JW.NewNumericalEntry(false)
+	ce := JW.NewNumericalEntry(true)
+	ae := JW.NewTextEntry()
+
+	ce.SetPlaceHolder(JC.Translate("Average entry price, optional"))
+	ae.SetPlaceHolder("YYYY-MM-DD")
 
-	title := "Adding New Panel"
+	po := append([]string{JC.Translate("Default")}, JT.UseRateProviders().GetNames()...)
+	pe := widget.NewSelect(po, nil)
+	pe.SetSelectedIndex(0)
+
+	title := JC.Translate("Adding New Panel")
 
 	if panelKey != JC.ACT_PANEL_NEW {
 
 		pkt := JT.UsePanelMaps().GetDataByID(uuid)
 		pko := pkt.UsePanelKey()
 
-		title = "Editing Panel"
+		title = JC.Translate("Editing Panel")
 
 		ve.SetDefaultValue(
 			strconv.FormatFloat(
@@ -121,6 +158,15 @@ func NewPanelForm(
 
 		de.SetDefaultValue(pko.GetDecimalsString())
 
+		if pkt.GetProvider() != JC.STRING_EMPTY {
+			pe.SetSelected(pkt.GetProvider())
+		}
+
+		if pko.IsHolding() {
+			ce.SetDefaultValue(pko.GetCostBasisString())
+			ae.SetDefaultValue(pko.GetAcquiredString())
+		}
+
 	} else {
 		de.SetText("6")
 	}
@@ -133,12 +179,17 @@ func NewPanelForm(
 		return validateCoin(s, se.Text)
 	}
 	de.Validator = validateDecimals
+	ce.Validator = validateCostBasis
+	ae.Validator = validateAcquired
 
 	fi := []*widget.FormItem{
-		widget.NewFormItem("Source Amount", ve),
-		widget.NewFormItem("From Cryptocurrency", se),
-		widget.NewFormItem("To Cryptocurrency", te),
-		widget.NewFormItem("Decimal Precision", de),
+		widget.NewFormItem(JC.Translate("Source Amount"), ve),
+		widget.NewFormItem(JC.Translate("From Cryptocurrency"), se),
+		widget.NewFormItem(JC.Translate("To Cryptocurrency"), te),
+		widget.NewFormItem(JC.Translate("Decimal Precision"), de),
+		widget.NewFormItem(JC.Translate("Rate Provider"), pe),
+		widget.NewFormItem(JC.Translate("Cost Basis"), ce),
+		widget.NewFormItem(JC.Translate("Acquired"), ae),
 	}
 
 	parent := JW.NewDialogForm(title, fi, nil, nil, pop, nil,
@@ -160,6 +211,12 @@ func NewPanelForm(
 			if de.Validate() != nil {
 				hasError = true
 			}
+			if ce.Validate() != nil {
+				hasError = true
+			}
+			if ae.Validate() != nil {
+				hasError = true
+			}
 
 			if hasError {
 				return false
@@ -168,6 +225,11 @@ func NewPanelForm(
 			npk := JT.NewPanelKey()
 			var ns JT.PanelData
 
+			provider := JC.STRING_EMPTY
+			if pe.SelectedIndex() > 0 {
+				provider = pe.Selected
+			}
+
 			sid := JT.UsePanelMaps().GetIdByDisplay(se.Text)
 			tid := JT.UsePanelMaps().GetIdByDisplay(te.Text)
 			bid := JT.UsePanelMaps().GetSymbolById(sid)
@@ -183,6 +245,10 @@ func NewPanelForm(
 				JC.ToBigFloat(-1),
 			))
 
+			costBasis, _ := strconv.ParseFloat(ce.Text, 64)
+			acquired, _ := JT.ParseAcquiredDate(ae.Text)
+			npk.SetHolding(costBasis, acquired)
+
 			if panelKey == JC.ACT_PANEL_NEW {
 				ns = JT.UsePanelMaps().Append(npk.GetRawValue())
 
@@ -191,6 +257,7 @@ func NewPanelForm(
 					return false
 				}
 
+				ns.SetProvider(provider)
 				ns.SetStatus(JC.STATE_FETCHING_NEW)
 
 				if onNew != nil {
@@ -204,18 +271,8 @@ func NewPanelForm(
 					return false
 				}
 
-				pkt := ns.UsePanelKey()
-
-				if pkt.GetSourceCoinInt() != npk.GetSourceCoinInt() || pkt.GetTargetCoinInt() != npk.GetTargetCoinInt() {
-					ns.SetStatus(JC.STATE_LOADING)
-					ns.Set(npk.GetRawValue())
-					ns.Update(npk.GetRawValue())
-				} else {
-					opk := ns.GetOldKey()
-					npk.UpdateValue(pkt.GetValueFloat())
-					ns.Set(npk.GetRawValue())
-					ns.SetOldKey(opk)
-				}
+				ns.SetProvider(provider)
+				ns.Reconfigure(npk.GetRawValue())
 			}
 
 			if onSave != nil {
@@ -8,21 +8,14 @@ import (
 	JA "jxwatcher/apps"
 	JC "jxwatcher/core"
 	JT "jxwatcher/types"
+	JW "jxwatcher/widgets"
 )
 
-var dragDropZones []*panelDropZone
+var dragDropZones []*JW.DropZone
 var panelGrid *panelContainer = &panelContainer{}
 
 type CreatePanelFunc func(JT.PanelData) fyne.CanvasObject
 
-type panelDropZone struct {
-	top    float32
-	left   float32
-	bottom float32
-	right  float32
-	uuid   string
-}
-
 func RegisterPanelGrid(createPanel CreatePanelFunc) {
 	JC.PrintPerfStats("Generating Panels", time.Now())
 
@@ -69,7 +62,7 @@ func RegisterPanelGrid(createPanel CreatePanelFunc) {
 	)
 
 	// Global dummy panel for placeholder
-	dragDropZones = []*panelDropZone{}
+	dragDropZones = []*JW.DropZone{}
 
 	JA.UseLayout().UseScroll().OnScrolled = layout.OnScrolled
 }
@@ -7,6 +7,7 @@ import (
 
 	JA "jxwatcher/apps"
 	JT "jxwatcher/types"
+	JW "jxwatcher/widgets"
 )
 
 type panelGridLayout struct {
@@ -59,7 +60,7 @@ func (g *panelGridLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
 	g.colCount = 1
 	g.rowCount = 0
 	g.dynCellSize = g.minCellSize
-	dragDropZones = []*panelDropZone{}
+	dragDropZones = []*JW.DropZone{}
 
 	sw := size.Width
 
@@ -125,15 +126,7 @@ func (g *panelGridLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
 			g.rowCount++
 		}
 
-		dz := panelDropZone{
-			left:   x,
-			right:  x + g.dynCellSize.Width,
-			top:    y,
-			bottom: y + g.dynCellSize.Height,
-			uuid:   child.(*panelDisplay).GetTag(),
-		}
-
-		dragDropZones = append(dragDropZones, &dz)
+		dragDropZones = append(dragDropZones, JW.NewDropZone(fyne.NewPos(x, y), g.dynCellSize, child.(*panelDisplay).GetTag()))
 
 		pos := fyne.NewPos(x, y)
 
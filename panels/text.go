@@ -75,6 +75,16 @@ func (p *panelText) SetText(t string) {
 	p.Refresh()
 }
 
+// Base color changes need a new raster, SetColor only tints the current one
+func (p *panelText) SetTextColor(col color.Color) {
+	if p.color == col {
+		return
+	}
+	p.color = col
+	p.rasterize()
+	p.Refresh()
+}
+
 func (p *panelText) SetAlpha(a uint8) {
 	if p.img == nil {
 		return
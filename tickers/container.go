@@ -26,6 +26,15 @@ func (c *tickerContainer) Remove(obj fyne.CanvasObject) {
 	}
 }
 
+func (c *tickerContainer) ForceRefresh() {
+	if c.layout == nil {
+		return
+	}
+
+	c.layout.Reset()
+	c.Refresh()
+}
+
 func (c *tickerContainer) CreateRenderer() fyne.WidgetRenderer {
 	return &tickerContainerLayout{
 		container: c,
@@ -1,8 +1,6 @@
 package tickers
 
 import (
-	"strconv"
-	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -14,8 +12,10 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	JA "jxwatcher/animations"
+	JM "jxwatcher/apps"
 	JC "jxwatcher/core"
 	JT "jxwatcher/types"
+	JW "jxwatcher/widgets"
 )
 
 type tickerDisplay struct {
@@ -27,6 +27,9 @@ type tickerDisplay struct {
 	content    *tickerText
 	status     *tickerText
 	state      int
+	dragging   bool
+	dragOffset fyne.Position
+	onTap      func(tickerType string)
 }
 
 func (h *tickerDisplay) GetTag() string {
@@ -37,10 +40,93 @@ func (h *tickerDisplay) CreateRenderer() fyne.WidgetRenderer {
 	return widget.NewSimpleRenderer(h.container)
 }
 
+func (h *tickerDisplay) Tapped(event *fyne.PointEvent) {
+	if JM.UseStatus().IsDraggable() || h.dragging || h.onTap == nil {
+		return
+	}
+
+	pkt := JT.UseTickerMaps().GetDataByID(h.GetTag())
+	if pkt == nil {
+		return
+	}
+
+	h.onTap(pkt.GetType())
+}
+
 func (h *tickerDisplay) Cursor() desktop.Cursor {
+	if JM.UseStatus().IsDraggable() {
+		return desktop.PointerCursor
+	}
+
 	return desktop.DefaultCursor
 }
 
+func (h *tickerDisplay) Dragged(ev *fyne.DragEvent) {
+	if !JM.UseStatus().IsDraggable() {
+		return
+	}
+
+	if !h.dragging {
+		h.dragging = true
+		h.background.FillColor = JC.UseTheme().GetColor(JC.ColorNamePanelPlaceholder)
+		canvas.Refresh(h.background)
+	}
+
+	h.dragOffset = h.Position().Add(ev.Position)
+}
+
+func (h *tickerDisplay) DragEnd() {
+	if !h.dragging {
+		return
+	}
+
+	h.dragging = false
+
+	// Force the background to be recalculated
+	h.background.FillColor = nil
+	h.updateContent()
+
+	if !JM.UseStatus().IsDraggable() {
+		return
+	}
+
+	h.snapToNearest()
+}
+
+func (h *tickerDisplay) snapToNearest() {
+
+	targetIndex := JW.FindDropIndex(dragDropZones, h.dragOffset, h.GetTag(), "ticker")
+	if targetIndex == -1 {
+		return
+	}
+
+	UseTickerGrid().Objects = JW.MoveObject(UseTickerGrid().Objects, h, targetIndex)
+	JA.StartFadeInBackground(h.tag, h.background, 300*time.Millisecond, nil, false)
+	UseTickerGrid().ForceRefresh()
+
+	JC.UseDebouncer().Call("ticker_drag", 1000*time.Millisecond, func() {
+		if h.syncData() {
+			if JT.SaveTickers() {
+				JC.Notify(JC.NotifyTickersHaveBeenReorderedAndUpdated)
+			}
+		}
+	})
+}
+
+func (h *tickerDisplay) syncData() bool {
+	nd := []JT.TickerData{}
+
+	for _, uuid := range JW.OrderedTags(UseTickerGrid().Objects, (*tickerDisplay).GetTag) {
+		if tdt := JT.UseTickerMaps().GetDataByID(uuid); tdt != nil {
+			nd = append(nd, tdt)
+		}
+	}
+
+	JT.UseTickerMaps().SetData(nd)
+
+	return true
+}
+
 func (h *tickerDisplay) updateContent() {
 
 	pkt := JT.UseTickerMaps().GetDataByID(h.GetTag())
@@ -49,6 +135,11 @@ func (h *tickerDisplay) updateContent() {
 		return
 	}
 
+	tc := JC.UseTheme().GetColor(theme.ColorNameForeground)
+	h.title.SetTextColor(tc)
+	h.status.SetTextColor(tc)
+	h.content.SetTextColor(tc)
+
 	title := JC.STRING_EMPTY
 	status := JC.STRING_EMPTY
 	content := JC.STRING_EMPTY
@@ -59,11 +150,11 @@ func (h *tickerDisplay) updateContent() {
 
 	switch h.state {
 	case JC.STATE_ERROR:
-		status = "Error loading data"
+		status = JC.Translate("Error loading data")
 		background = JC.UseTheme().GetColor(JC.ColorNameError)
 
 	case JC.STATE_LOADING:
-		status = "Loading..."
+		status = JC.Translate("Loading...")
 		background = JC.UseTheme().GetColor(JC.ColorNameTickerBG)
 
 	default:
@@ -75,113 +166,8 @@ func (h *tickerDisplay) updateContent() {
 			isNewContent = true
 		}
 
-		if pkt.IsType(JT.TickerTypeAltcoinIndex) {
-			percentage, _ := strconv.ParseInt(pkt.Get(), 10, 64)
-			switch {
-			case percentage >= 75:
-				background = JC.UseTheme().GetColor(JC.ColorNameBlue)
-			case percentage >= 50:
-				background = JC.UseTheme().GetColor(JC.ColorNameLightPurple)
-			case percentage >= 25:
-				background = JC.UseTheme().GetColor(JC.ColorNameLightOrange)
-			default:
-				background = JC.UseTheme().GetColor(JC.ColorNameOrange)
-			}
-		}
-
-		if pkt.IsType(JT.TickerTypeFearGreed) {
-			index, _ := strconv.ParseInt(pkt.Get(), 10, 64)
-			switch {
-			case index >= 75:
-				background = JC.UseTheme().GetColor(JC.ColorNameGreen)
-			case index >= 55:
-				background = JC.UseTheme().GetColor(JC.ColorNameTeal)
-			case index >= 45:
-				background = JC.UseTheme().GetColor(JC.ColorNameYellow)
-			case index >= 25:
-				background = JC.UseTheme().GetColor(JC.ColorNameOrange)
-			default:
-				background = JC.UseTheme().GetColor(JC.ColorNameRed)
-			}
-		}
-
-		if pkt.IsType(JT.TickerTypeMarketCap) {
-			raw := JT.UseTickerCache().Get(JT.TickerTypeMarketCap24hChange)
-			index, _ := strconv.ParseFloat(raw, 64)
-			if index > 0 {
-				background = JC.UseTheme().GetColor(JC.ColorNameGreen)
-			} else if index < 0 {
-				background = JC.UseTheme().GetColor(JC.ColorNameRed)
-			}
-		}
-
-		if pkt.IsType(JT.TickerTypeCMC100) {
-			raw := JT.UseTickerCache().Get(JT.TickerTypeCMC10024hChange)
-			index, _ := strconv.ParseFloat(raw, 64)
-			if index >= 0 {
-				background = JC.UseTheme().GetColor(JC.ColorNameGreen)
-			} else if index < 0 {
-				background = JC.UseTheme().GetColor(JC.ColorNameRed)
-			}
-		}
-
-		if pkt.IsType(JT.TickerTypeRSI) {
-			raw := JT.UseTickerCache().Get(JT.TickerTypeRSI)
-			index, _ := strconv.ParseFloat(raw, 64)
-			switch {
-			case index >= 70:
-				background = JC.UseTheme().GetColor(JC.ColorNameGreen)
-			case index >= 55:
-				background = JC.UseTheme().GetColor(JC.ColorNameDarkGreen)
-			case index >= 45:
-				background = JC.UseTheme().GetColor(JC.ColorNameDarkGrey)
-			case index >= 30:
-				background = JC.UseTheme().GetColor(JC.ColorNameDarkRed)
-			default:
-				background = JC.UseTheme().GetColor(JC.ColorNameRed)
-			}
-		}
-
-		if pkt.IsType(JT.TickerTypePulse) {
-			raw := JT.UseTickerCache().Get(JT.TickerTypePulse)
-			pulseValue, err := strconv.ParseFloat(strings.TrimSuffix(raw, JC.STRING_PERCENTAGE), 64)
-			if err == nil {
-				switch {
-				case pulseValue > 0:
-					background = JC.UseTheme().GetColor(JC.ColorNameGreen)
-				case pulseValue < 0:
-					background = JC.UseTheme().GetColor(JC.ColorNameRed)
-				default:
-					background = JC.UseTheme().GetColor(JC.ColorNameDarkGrey)
-				}
-			}
-		}
-
-		if pkt.IsType(JT.TickerTypeETF) {
-			raw := JT.UseTickerCache().Get(JT.TickerTypeETF)
-			etfValue, err := strconv.ParseFloat(raw, 64)
-			if err == nil {
-				switch {
-				case etfValue > 0:
-					background = JC.UseTheme().GetColor(JC.ColorNameGreen)
-				case etfValue < 0:
-					background = JC.UseTheme().GetColor(JC.ColorNameRed)
-				default:
-					background = JC.UseTheme().GetColor(JC.ColorNameDarkGrey)
-				}
-			}
-		}
-
-		if pkt.IsType(JT.TickerTypeDominance) {
-			raw := JT.UseTickerCache().Get(JT.TickerTypeDominance)
-			btcDom, err := strconv.ParseFloat(raw, 64)
-			if err == nil {
-				if btcDom >= 50 {
-					background = JC.UseTheme().GetColor(JC.ColorNameGreen)
-				} else {
-					background = JC.UseTheme().GetColor(JC.ColorNameRed)
-				}
-			}
+		if color, ok := JT.GetTickerColor(pkt); ok {
+			background = JC.UseTheme().GetColor(color)
 		}
 	}
 
@@ -203,7 +189,7 @@ func (h *tickerDisplay) updateContent() {
 	}
 }
 
-func NewtickerDisplay(tdt JT.TickerData) *tickerDisplay {
+func NewtickerDisplay(tdt JT.TickerData, onTap func(tickerType string)) *tickerDisplay {
 	uuid := JC.CreateUUID()
 	tdt.SetID(uuid)
 
@@ -233,6 +219,7 @@ func NewtickerDisplay(tdt JT.TickerData) *tickerDisplay {
 		title:      tl.title,
 		content:    tl.content,
 		status:     tl.status,
+		onTap:      onTap,
 	}
 
 	tk.ExtendBaseWidget(tk)
@@ -9,18 +9,20 @@ import (
 	JA "jxwatcher/apps"
 	JC "jxwatcher/core"
 	JT "jxwatcher/types"
+	JW "jxwatcher/widgets"
 )
 
+var dragDropZones []*JW.DropZone
 var tickerGrid *tickerContainer = &tickerContainer{}
 
-func RegisterTickerGrid() {
+func RegisterTickerGrid(onTap func(tickerType string)) {
 	JC.PrintPerfStats("Generating Tickers", time.Now())
 
 	list := JT.UseTickerMaps().GetData()
 	p := []*tickerDisplay{}
 
 	for _, pot := range list {
-		ticker := NewtickerDisplay(pot)
+		ticker := NewtickerDisplay(pot, onTap)
 		ticker.Resize(fyne.NewSize(JC.UseTheme().Size(JC.SizeTickerWidth), JC.UseTheme().Size(JC.SizeTickerHeight)))
 
 		p = append(p, ticker)
@@ -31,7 +33,9 @@ func RegisterTickerGrid() {
 		tickers[i] = p[i]
 	}
 
-	tickerGrid := NewTickerContainer(
+	dragDropZones = []*JW.DropZone{}
+
+	tickerGrid = NewTickerContainer(
 		&tickerGridLayout{
 			minCellSize: fyne.NewSize(JC.UseTheme().Size(JC.SizeTickerWidth), JC.UseTheme().Size(JC.SizeTickerHeight)),
 			dynCellSize: fyne.NewSize(JC.UseTheme().Size(JC.SizeTickerWidth), JC.UseTheme().Size(JC.SizeTickerHeight)),
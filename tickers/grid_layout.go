@@ -6,6 +6,7 @@ import (
 	"fyne.io/fyne/v2"
 
 	JA "jxwatcher/apps"
+	JW "jxwatcher/widgets"
 )
 
 type tickerGridLayout struct {
@@ -42,6 +43,7 @@ func (g *tickerGridLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
 	g.colCount = 1
 	g.rowCount = 0
 	g.dynCellSize = g.minCellSize
+	dragDropZones = []*JW.DropZone{}
 
 	if g.minCellSize.Width > g.cWidth {
 		g.minCellSize.Width = g.cWidth - hPad
@@ -97,6 +99,10 @@ func (g *tickerGridLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
 			g.rowCount++
 		}
 
+		if ticker, ok := child.(*tickerDisplay); ok {
+			dragDropZones = append(dragDropZones, JW.NewDropZone(fyne.NewPos(x, y), g.dynCellSize, ticker.GetTag()))
+		}
+
 		pos := fyne.NewPos(x, y)
 		if child.Position() != pos {
 			child.Move(pos)
@@ -151,3 +157,7 @@ func (g *tickerGridLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
 
 	return g.minSize
 }
+
+func (g *tickerGridLayout) Reset() {
+	g.cWidth = 0
+}
@@ -72,6 +72,16 @@ func (s *tickerText) SetText(t string) {
 	s.Refresh()
 }
 
+// Base color changes need a new raster, SetColor only tints the current one
+func (s *tickerText) SetTextColor(col color.Color) {
+	if s.color == col {
+		return
+	}
+	s.color = col
+	s.rasterize()
+	s.Refresh()
+}
+
 func (s *tickerText) SetAlpha(a uint8) {
 	JC.SetImageAlpha(s.img.Image.(*image.NRGBA), a)
 	s.img.Refresh()
@@ -0,0 +1,366 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/buger/jsonparser"
+
+	JC "jxwatcher/core"
+)
+
+const alertRouteDefault = "*"
+
+var alertsStorage *alertsType = nil
+
+type AlertSink interface {
+	GetName() string
+	Send(ctx context.Context, alert *alertType) error
+}
+
+type alertType struct {
+	Title        string    `json:"title"`
+	Message      string    `json:"message"`
+	SourceId     int64     `json:"source_id"`
+	SourceSymbol string    `json:"source_symbol"`
+	TargetId     int64     `json:"target_id"`
+	TargetSymbol string    `json:"target_symbol"`
+	Rate         string    `json:"rate"`
+	Rules        []string  `json:"rules"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+type alertSinkConfigType struct {
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	URL      string            `json:"url,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Command  string            `json:"command,omitempty"`
+	Args     []string          `json:"args,omitempty"`
+	Path     string            `json:"path,omitempty"`
+	Host     string            `json:"host,omitempty"`
+	Port     int64             `json:"port,omitempty"`
+	Username string            `json:"username,omitempty"`
+	Password string            `json:"password,omitempty"`
+	From     string            `json:"from,omitempty"`
+	To       []string          `json:"to,omitempty"`
+}
+
+type alertsType struct {
+	mu      sync.RWMutex
+	sinks   map[string]AlertSink
+	routes  map[string][]string
+	retries int
+	backoff time.Duration
+	timeout time.Duration
+	submit  func(fn func()) bool
+}
+
+func (a *alertType) GetRouteKeys() []string {
+	return []string{
+		fmt.Sprintf("%d-%d", a.SourceId, a.TargetId),
+		a.SourceSymbol + "/" + a.TargetSymbol,
+		alertRouteDefault,
+	}
+}
+
+func (a *alertType) ToJSON() []byte {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return []byte("{}")
+	}
+	return data
+}
+
+func (a *alertsType) Init() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.sinks = make(map[string]AlertSink)
+	a.routes = make(map[string][]string)
+
+	if a.backoff == 0 {
+		a.backoff = 2 * time.Second
+	}
+
+	if a.timeout == 0 {
+		a.timeout = 30 * time.Second
+	}
+
+	if !JC.IsHeadless {
+		desktop := NewDesktopAlertSink()
+		a.sinks[desktop.GetName()] = desktop
+	}
+
+	cfg := UseConfig()
+	if cfg == nil {
+		return
+	}
+
+	for _, sc := range cfg.GetAlertSinks() {
+		sink, err := NewAlertSink(sc)
+		if err != nil {
+			JC.Logln("Ignoring alert sink:", err)
+			continue
+		}
+
+		a.sinks[sink.GetName()] = sink
+	}
+
+	for key, names := range cfg.GetAlertRoutes() {
+		a.routes[key] = names
+	}
+
+	a.retries = cfg.GetAlertRetries()
+}
+
+func (a *alertsType) Add(sink AlertSink) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.sinks[sink.GetName()] = sink
+}
+
+func (a *alertsType) SetRoute(key string, names []string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.routes[key] = names
+}
+
+func (a *alertsType) Resolve(alert *alertType) []AlertSink {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	sinks := []AlertSink{}
+
+	for _, key := range alert.GetRouteKeys() {
+		names, ok := a.routes[key]
+		if !ok {
+			continue
+		}
+
+		for _, name := range names {
+			if sink, ok := a.sinks[name]; ok {
+				sinks = append(sinks, sink)
+			} else {
+				JC.Logln("Unknown alert sink in route", key, name)
+			}
+		}
+
+		return sinks
+	}
+
+	// No route configured, deliver to every sink
+	for _, sink := range a.sinks {
+		sinks = append(sinks, sink)
+	}
+
+	return sinks
+}
+
+// Dispatch reports false when no sink accepted the alert, the caller must not count it as sent
+func (a *alertsType) Dispatch(alert *alertType) bool {
+	sinks := a.Resolve(alert)
+	accepted := false
+
+	if len(sinks) == 0 {
+		JC.Logln("No alert sink to deliver to, dropping alert:", alert.Message)
+	}
+
+	for _, sink := range sinks {
+		if a.deliver(sink, alert, 0) {
+			accepted = true
+		}
+	}
+
+	return accepted
+}
+
+func (a *alertsType) deliver(sink AlertSink, alert *alertType, attempt int) bool {
+	if JC.IsShuttingDown() {
+		return false
+	}
+
+	a.mu.RLock()
+	submit := a.submit
+	retries := a.retries
+	backoff := a.backoff
+	timeout := a.timeout
+	a.mu.RUnlock()
+
+	if submit == nil {
+		submit = func(fn func()) bool {
+			if d := JC.UseDispatcher(); d != nil {
+				return d.Submit(fn)
+			}
+			go fn()
+			return true
+		}
+	}
+
+	queued := submit(func() {
+		ctx, cancel := context.WithTimeout(JC.ShutdownCtx, timeout)
+		defer cancel()
+
+		err := sink.Send(ctx, alert)
+		if err == nil {
+			return
+		}
+
+		if attempt >= retries {
+			JC.Logf("Alert delivery to %s failed after %d attempts: %v", sink.GetName(), attempt+1, err)
+			return
+		}
+
+		delay := backoff << attempt
+		JC.Logf("Alert delivery to %s failed, retrying in %v: %v", sink.GetName(), delay, err)
+
+		time.AfterFunc(delay, func() {
+			a.deliver(sink, alert, attempt+1)
+		})
+	})
+
+	if queued {
+		return true
+	}
+
+	// A dropped first attempt is left to the caller, the watcher fires again on the next update
+	if attempt == 0 || attempt >= retries {
+		JC.Logf("Alert delivery to %s dropped after %d attempts: dispatcher is busy", sink.GetName(), attempt+1)
+		return false
+	}
+
+	time.AfterFunc(backoff<<attempt, func() {
+		a.deliver(sink, alert, attempt+1)
+	})
+
+	return true
+}
+
+func (c *alertSinkConfigType) parseJSON(data []byte) {
+	if val, err := jsonparser.GetString(data, "name"); err == nil {
+		c.Name = val
+	}
+	if val, err := jsonparser.GetString(data, "type"); err == nil {
+		c.Type = val
+	}
+	if val, err := jsonparser.GetString(data, "url"); err == nil {
+		c.URL = val
+	}
+	if val, err := jsonparser.GetString(data, "command"); err == nil {
+		c.Command = val
+	}
+	if val, err := jsonparser.GetString(data, "path"); err == nil {
+		c.Path = val
+	}
+	if val, err := jsonparser.GetString(data, "host"); err == nil {
+		c.Host = val
+	}
+	if val, err := jsonparser.GetInt(data, "port"); err == nil {
+		c.Port = val
+	}
+	if val, err := jsonparser.GetString(data, "username"); err == nil {
+		c.Username = val
+	}
+	if val, err := jsonparser.GetString(data, "password"); err == nil {
+		c.Password = val
+	}
+	if val, err := jsonparser.GetString(data, "from"); err == nil {
+		c.From = val
+	}
+
+	jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		if c.Headers == nil {
+			c.Headers = make(map[string]string)
+		}
+		if val, err := jsonparser.ParseString(value); err == nil {
+			c.Headers[string(key)] = val
+		}
+		return nil
+	}, "headers")
+
+	jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		if val, err := jsonparser.ParseString(value); err == nil {
+			c.Args = append(c.Args, val)
+		}
+	}, "args")
+
+	jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		if val, err := jsonparser.ParseString(value); err == nil {
+			c.To = append(c.To, val)
+		}
+	}, "to")
+}
+
+func (c *alertSinkConfigType) GetAddress() string {
+	port := c.Port
+	if port <= 0 {
+		port = 25
+	}
+
+	return c.Host + ":" + strconv.FormatInt(port, 10)
+}
+
+func NewAlertSink(c alertSinkConfigType) (AlertSink, error) {
+	if strings.TrimSpace(c.Name) == JC.STRING_EMPTY {
+		return nil, fmt.Errorf("alert sink without name")
+	}
+
+	switch c.Type {
+	case JC.ALERT_SINK_DESKTOP:
+		return &desktopAlertSink{name: c.Name}, nil
+
+	case JC.ALERT_SINK_WEBHOOK:
+		if c.URL == JC.STRING_EMPTY {
+			return nil, fmt.Errorf("webhook sink %s requires url", c.Name)
+		}
+		return &webhookAlertSink{name: c.Name, url: c.URL, headers: c.Headers}, nil
+
+	case JC.ALERT_SINK_COMMAND:
+		if c.Command == JC.STRING_EMPTY {
+			return nil, fmt.Errorf("command sink %s requires command", c.Name)
+		}
+		return &commandAlertSink{name: c.Name, command: c.Command, args: c.Args}, nil
+
+	case JC.ALERT_SINK_LOG:
+		path := c.Path
+		if path == JC.STRING_EMPTY {
+			path = "alerts.jsonl"
+		}
+		return &logAlertSink{name: c.Name, path: path}, nil
+
+	case JC.ALERT_SINK_SMTP:
+		if c.Host == JC.STRING_EMPTY || c.From == JC.STRING_EMPTY || len(c.To) == 0 {
+			return nil, fmt.Errorf("smtp sink %s requires host, from and to", c.Name)
+		}
+		return &smtpAlertSink{
+			name:     c.Name,
+			address:  c.GetAddress(),
+			host:     c.Host,
+			username: c.Username,
+			password: c.Password,
+			from:     c.From,
+			to:       c.To,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown alert sink type %q for %s", c.Type, c.Name)
+}
+
+func RegisterAlerts() *alertsType {
+	if alertsStorage == nil {
+		alertsStorage = &alertsType{}
+	}
+	return alertsStorage
+}
+
+func UseAlerts() *alertsType {
+	return alertsStorage
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type commandAlertSink struct {
+	name    string
+	command string
+	args    []string
+}
+
+func (s *commandAlertSink) GetName() string {
+	return s.name
+}
+
+func (s *commandAlertSink) Send(ctx context.Context, alert *alertType) error {
+	cmd := exec.CommandContext(ctx, s.command, s.args...)
+	cmd.Env = append(os.Environ(), s.buildEnv(alert)...)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("command %s failed: %w (%s)", s.name, err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+func (s *commandAlertSink) buildEnv(alert *alertType) []string {
+	return []string{
+		"JXWATCHER_ALERT_TITLE=" + alert.Title,
+		"JXWATCHER_ALERT_MESSAGE=" + alert.Message,
+		"JXWATCHER_ALERT_SOURCE_ID=" + strconv.FormatInt(alert.SourceId, 10),
+		"JXWATCHER_ALERT_SOURCE_SYMBOL=" + alert.SourceSymbol,
+		"JXWATCHER_ALERT_TARGET_ID=" + strconv.FormatInt(alert.TargetId, 10),
+		"JXWATCHER_ALERT_TARGET_SYMBOL=" + alert.TargetSymbol,
+		"JXWATCHER_ALERT_RATE=" + alert.Rate,
+		"JXWATCHER_ALERT_RULES=" + strings.Join(alert.Rules, "; "),
+		"JXWATCHER_ALERT_TIMESTAMP=" + alert.Timestamp.UTC().Format(time.RFC3339),
+		"JXWATCHER_ALERT_JSON=" + string(alert.ToJSON()),
+	}
+}
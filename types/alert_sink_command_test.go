@@ -0,0 +1,41 @@
+package types
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCommandAlertSinkSend(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a posix shell")
+	}
+
+	out := filepath.Join(t.TempDir(), "alert.txt")
+	sink := &commandAlertSink{
+		name:    "cmd",
+		command: "sh",
+		args:    []string{"-c", `printf "%s|%s|%s" "$JXWATCHER_ALERT_SOURCE_SYMBOL" "$JXWATCHER_ALERT_RATE" "$JXWATCHER_ALERT_TITLE" > "$0"`, out},
+	}
+
+	if err := sink.Send(context.Background(), newTestAlert()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("Expected command output: %v", err)
+	}
+	if string(content) != "BTC|100500|Rate Alert" {
+		t.Errorf("Unexpected command output %q", content)
+	}
+
+	failing := &commandAlertSink{name: "cmd", command: "sh", args: []string{"-c", "echo nope; exit 3"}}
+	err = failing.Send(context.Background(), newTestAlert())
+	if err == nil || !strings.Contains(err.Error(), "nope") {
+		t.Errorf("Expected failing command error with output, got %v", err)
+	}
+}
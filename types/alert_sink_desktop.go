@@ -0,0 +1,32 @@
+package types
+
+import (
+	"context"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+
+	JC "jxwatcher/core"
+)
+
+type desktopAlertSink struct {
+	name string
+}
+
+func (s *desktopAlertSink) GetName() string {
+	return s.name
+}
+
+func (s *desktopAlertSink) Send(ctx context.Context, alert *alertType) error {
+	if JC.App == nil {
+		return fmt.Errorf("desktop notification unavailable")
+	}
+
+	JC.App.SendNotification(fyne.NewNotification(alert.Title, alert.Message))
+
+	return nil
+}
+
+func NewDesktopAlertSink() *desktopAlertSink {
+	return &desktopAlertSink{name: JC.ALERT_SINK_DESKTOP}
+}
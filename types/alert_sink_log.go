@@ -0,0 +1,25 @@
+package types
+
+import (
+	"context"
+	"fmt"
+
+	JC "jxwatcher/core"
+)
+
+type logAlertSink struct {
+	name string
+	path string
+}
+
+func (s *logAlertSink) GetName() string {
+	return s.name
+}
+
+func (s *logAlertSink) Send(ctx context.Context, alert *alertType) error {
+	if !JC.AppendFileToStorage(s.path, string(alert.ToJSON())+"\n") {
+		return fmt.Errorf("failed to append alert to %s", s.path)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/buger/jsonparser"
+
+	JC "jxwatcher/core"
+)
+
+func TestLogAlertSinkSend(t *testing.T) {
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	path := "alerts_test_" + t.Name() + ".jsonl"
+	t.Cleanup(func() {
+		os.Remove(strings.TrimPrefix(JC.BuildPathRelatedToUserDirectory([]string{path}), "file://"))
+	})
+
+	sink := &logAlertSink{name: "log", path: path}
+	for range 2 {
+		if err := sink.Send(context.Background(), newTestAlert()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	content, ok := JC.LoadFileFromStorage(path)
+	if !ok {
+		t.Fatal("Expected alert log to exist")
+	}
+
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, got %d", len(lines))
+	}
+	if val, _ := jsonparser.GetString([]byte(lines[1]), "message"); val != newTestAlert().Message {
+		t.Errorf("Unexpected logged message %q", val)
+	}
+}
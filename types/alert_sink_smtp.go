@@ -0,0 +1,69 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+	"time"
+
+	JC "jxwatcher/core"
+)
+
+type smtpAlertSink struct {
+	name     string
+	address  string
+	host     string
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+func (s *smtpAlertSink) GetName() string {
+	return s.name
+}
+
+func (s *smtpAlertSink) Send(ctx context.Context, alert *alertType) error {
+	var auth smtp.Auth
+	if s.username != JC.STRING_EMPTY {
+		auth = smtp.PlainAuth(JC.STRING_EMPTY, s.username, s.password, s.host)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(s.address, auth, s.from, s.to, s.buildMessage(alert))
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("smtp %s failed: %w", s.name, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *smtpAlertSink) buildMessage(alert *alertType) []byte {
+	var b strings.Builder
+
+	b.WriteString("From: " + s.from + "\r\n")
+	b.WriteString("To: " + strings.Join(s.to, ", ") + "\r\n")
+	b.WriteString("Subject: " + encodeHeader(alert.Title+": "+alert.SourceSymbol+" to "+alert.TargetSymbol) + "\r\n")
+	b.WriteString("Date: " + alert.Timestamp.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(alert.Message + "\r\n")
+
+	return []byte(b.String())
+}
+
+// Line breaks would let a symbol inject extra headers
+func encodeHeader(value string) string {
+	value = strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+	return mime.QEncoding.Encode("utf-8", value)
+}
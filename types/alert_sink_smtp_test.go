@@ -0,0 +1,115 @@
+package types
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+)
+
+func startTestSMTPServer(t *testing.T) (string, chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan string, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) {
+			conn.Write([]byte(line + "\r\n"))
+		}
+
+		reply("220 localhost ESMTP")
+
+		var data strings.Builder
+		inData := false
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					received <- data.String()
+					reply("250 OK")
+					continue
+				}
+				data.WriteString(line)
+				continue
+			}
+
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "DATA"):
+				inData = true
+				reply("354 End data with <CR><LF>.<CR><LF>")
+			case strings.HasPrefix(command, "QUIT"):
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), received
+}
+
+func TestSMTPAlertSinkSend(t *testing.T) {
+	address, received := startTestSMTPServer(t)
+
+	sink := &smtpAlertSink{
+		name:    "mail",
+		address: address,
+		host:    "127.0.0.1",
+		from:    "watcher@localhost",
+		to:      []string{"user@localhost"},
+	}
+
+	if err := sink.Send(context.Background(), newTestAlert()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	message := <-received
+	if !strings.Contains(message, "Subject: Rate Alert: BTC to USDT") {
+		t.Errorf("Expected subject in message, got %q", message)
+	}
+	if !strings.Contains(message, newTestAlert().Message) {
+		t.Errorf("Expected alert body in message, got %q", message)
+	}
+}
+
+func TestSMTPAlertSinkSubjectHeader(t *testing.T) {
+	sink := &smtpAlertSink{from: "alerts@example.com", to: []string{"me@example.com"}}
+
+	alert := newTestAlert()
+	alert.SourceSymbol = "BTC\r\nBcc: victim@example.com"
+
+	message := string(sink.buildMessage(alert))
+	if strings.Contains(message, "\r\nBcc:") {
+		t.Errorf("Expected line breaks stripped from subject, got %q", message)
+	}
+
+	alert = newTestAlert()
+	alert.TargetSymbol = "€URO"
+
+	message = string(sink.buildMessage(alert))
+	if !strings.Contains(message, "Subject: =?utf-8?q?") {
+		t.Errorf("Expected non ascii subject to be encoded, got %q", message)
+	}
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"context"
+	"fmt"
+
+	JC "jxwatcher/core"
+)
+
+type webhookAlertSink struct {
+	name    string
+	url     string
+	headers map[string]string
+}
+
+func (s *webhookAlertSink) GetName() string {
+	return s.name
+}
+
+func (s *webhookAlertSink) Send(ctx context.Context, alert *alertType) error {
+	code := JC.PostRequest(ctx, s.url, alert.ToJSON(), s.headers)
+	if code != JC.NETWORKING_SUCCESS {
+		return fmt.Errorf("webhook %s returned code %d", s.name, code)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/buger/jsonparser"
+)
+
+func TestWebhookAlertSinkSend(t *testing.T) {
+	var body []byte
+	var token string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		token = r.Header.Get("X-Token")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink := &webhookAlertSink{name: "hook", url: server.URL, headers: map[string]string{"X-Token": "abc"}}
+	if err := sink.Send(context.Background(), newTestAlert()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if token != "abc" {
+		t.Errorf("Expected header to be forwarded, got %q", token)
+	}
+	if val, _ := jsonparser.GetString(body, "source_symbol"); val != "BTC" {
+		t.Errorf("Expected source_symbol BTC, got %q", val)
+	}
+	if val, _ := jsonparser.GetString(body, "rate"); val != "100500" {
+		t.Errorf("Expected rate 100500, got %q", val)
+	}
+}
+
+func TestWebhookAlertSinkFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sink := &webhookAlertSink{name: "hook", url: server.URL}
+	if err := sink.Send(context.Background(), newTestAlert()); err == nil {
+		t.Error("Expected error on server failure")
+	}
+}
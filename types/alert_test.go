@@ -0,0 +1,250 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	JC "jxwatcher/core"
+)
+
+type alertTestSink struct {
+	mu    sync.Mutex
+	name  string
+	fails int
+	calls int
+	done  chan struct{}
+}
+
+func (s *alertTestSink) GetName() string {
+	return s.name
+}
+
+func (s *alertTestSink) Send(ctx context.Context, alert *alertType) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.calls <= s.fails {
+		return fmt.Errorf("failure %d", s.calls)
+	}
+
+	if s.done != nil {
+		close(s.done)
+		s.done = nil
+	}
+
+	return nil
+}
+
+func (s *alertTestSink) getCalls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func newTestAlerts() *alertsType {
+	a := &alertsType{
+		backoff: time.Millisecond,
+		timeout: time.Second,
+		submit: func(fn func()) bool {
+			go fn()
+			return true
+		},
+	}
+	a.Init()
+	a.retries = 3
+
+	return a
+}
+
+func newTestAlert() *alertType {
+	return &alertType{
+		Title:        "Rate Alert",
+		Message:      "BTC to USDT rates crossed above 100,000",
+		SourceId:     1,
+		SourceSymbol: "BTC",
+		TargetId:     825,
+		TargetSymbol: "USDT",
+		Rate:         "100500",
+		Rules:        []string{"rates crossed above 100,000"},
+		Timestamp:    time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestAlertsResolveRoutes(t *testing.T) {
+	a := newTestAlerts()
+	a.Add(&alertTestSink{name: "hook"})
+	a.Add(&alertTestSink{name: "mail"})
+
+	if sinks := a.Resolve(newTestAlert()); len(sinks) != 3 {
+		t.Errorf("Expected all 3 sinks without routes, got %d", len(sinks))
+	}
+
+	a.SetRoute("*", []string{"desktop"})
+	a.SetRoute("BTC/USDT", []string{"hook", "missing"})
+
+	sinks := a.Resolve(newTestAlert())
+	if len(sinks) != 1 || sinks[0].GetName() != "hook" {
+		t.Errorf("Expected symbol route to pick hook, got %v", sinks)
+	}
+
+	a.SetRoute("1-825", []string{"mail"})
+	sinks = a.Resolve(newTestAlert())
+	if len(sinks) != 1 || sinks[0].GetName() != "mail" {
+		t.Errorf("Expected id route to take precedence, got %v", sinks)
+	}
+
+	other := newTestAlert()
+	other.SourceId = 2
+	other.SourceSymbol = "ETH"
+	sinks = a.Resolve(other)
+	if len(sinks) != 1 || sinks[0].GetName() != "desktop" {
+		t.Errorf("Expected default route, got %v", sinks)
+	}
+}
+
+func TestAlertsDispatchRetries(t *testing.T) {
+	a := newTestAlerts()
+
+	done := make(chan struct{})
+	sink := &alertTestSink{name: "flaky", fails: 2, done: done}
+	a.Add(sink)
+	a.SetRoute("*", []string{"flaky"})
+
+	a.Dispatch(newTestAlert())
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected alert to be delivered after retries")
+	}
+
+	if calls := sink.getCalls(); calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestAlertsDispatchGivesUp(t *testing.T) {
+	a := newTestAlerts()
+	a.retries = 1
+
+	sink := &alertTestSink{name: "broken", fails: 10}
+	a.Add(sink)
+	a.SetRoute("*", []string{"broken"})
+
+	a.Dispatch(newTestAlert())
+	time.Sleep(100 * time.Millisecond)
+
+	if calls := sink.getCalls(); calls != 2 {
+		t.Errorf("Expected 2 attempts before giving up, got %d", calls)
+	}
+}
+
+func TestAlertsDispatchDropped(t *testing.T) {
+	a := newTestAlerts()
+	a.submit = func(fn func()) bool {
+		return false
+	}
+
+	sink := &alertTestSink{name: "hook"}
+	a.Add(sink)
+	a.SetRoute("*", []string{"hook"})
+
+	if a.Dispatch(newTestAlert()) {
+		t.Error("Expected dropped alert not to be reported as sent")
+	}
+	if calls := sink.getCalls(); calls != 0 {
+		t.Errorf("Expected no delivery, got %d", calls)
+	}
+
+	a.submit = func(fn func()) bool {
+		go fn()
+		return true
+	}
+
+	if !a.Dispatch(newTestAlert()) {
+		t.Error("Expected queued alert to be reported as sent")
+	}
+}
+
+func TestAlertsDispatchWithoutSinks(t *testing.T) {
+	previous := JC.IsHeadless
+	JC.IsHeadless = true
+	defer func() { JC.IsHeadless = previous }()
+
+	a := newTestAlerts()
+
+	if a.Dispatch(newTestAlert()) {
+		t.Error("Expected alert without sinks not to be reported as sent")
+	}
+}
+
+func TestNewAlertSinkValidation(t *testing.T) {
+	tests := []struct {
+		config alertSinkConfigType
+		valid  bool
+	}{
+		{alertSinkConfigType{Name: "hook", Type: JC.ALERT_SINK_WEBHOOK, URL: "http://localhost"}, true},
+		{alertSinkConfigType{Name: "hook", Type: JC.ALERT_SINK_WEBHOOK}, false},
+		{alertSinkConfigType{Name: "cmd", Type: JC.ALERT_SINK_COMMAND, Command: "notify-send"}, true},
+		{alertSinkConfigType{Name: "cmd", Type: JC.ALERT_SINK_COMMAND}, false},
+		{alertSinkConfigType{Name: "log", Type: JC.ALERT_SINK_LOG}, true},
+		{alertSinkConfigType{Name: "mail", Type: JC.ALERT_SINK_SMTP, Host: "localhost", From: "a@b", To: []string{"c@d"}}, true},
+		{alertSinkConfigType{Name: "mail", Type: JC.ALERT_SINK_SMTP, Host: "localhost"}, false},
+		{alertSinkConfigType{Name: "", Type: JC.ALERT_SINK_LOG}, false},
+		{alertSinkConfigType{Name: "x", Type: "pigeon"}, false},
+	}
+
+	for _, tt := range tests {
+		_, err := NewAlertSink(tt.config)
+		if (err == nil) != tt.valid {
+			t.Errorf("Expected valid=%v for %+v, got %v", tt.valid, tt.config, err)
+		}
+	}
+}
+
+func TestConfigParseAlerts(t *testing.T) {
+	raw := []byte(`{
+		"alert_retries": 5,
+		"alert_sinks": [
+			{"name": "hook", "type": "webhook", "url": "http://localhost/hook", "headers": {"X-Token": "abc"}},
+			{"name": "cmd", "type": "command", "command": "/bin/echo", "args": ["a", "b"]},
+			{"name": "mail", "type": "smtp", "host": "localhost", "port": 2525, "from": "a@b", "to": ["c@d", "e@f"]}
+		],
+		"alert_routes": {
+			"*": ["desktop"],
+			"BTC/USDT": ["hook", "mail"]
+		}
+	}`)
+
+	cfg := &configType{}
+	if err := cfg.parseJSON(raw); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.GetAlertRetries() != 5 {
+		t.Errorf("Expected 5 retries, got %d", cfg.GetAlertRetries())
+	}
+
+	sinks := cfg.GetAlertSinks()
+	if len(sinks) != 3 {
+		t.Fatalf("Expected 3 sinks, got %d", len(sinks))
+	}
+	if sinks[0].Headers["X-Token"] != "abc" {
+		t.Errorf("Expected webhook header, got %v", sinks[0].Headers)
+	}
+	if len(sinks[1].Args) != 2 || sinks[1].Args[1] != "b" {
+		t.Errorf("Expected command args, got %v", sinks[1].Args)
+	}
+	if sinks[2].GetAddress() != "localhost:2525" || len(sinks[2].To) != 2 {
+		t.Errorf("Unexpected smtp config %+v", sinks[2])
+	}
+
+	routes := cfg.GetAlertRoutes()
+	if len(routes["BTC/USDT"]) != 2 || routes["*"][0] != "desktop" {
+		t.Errorf("Unexpected routes %v", routes)
+	}
+}
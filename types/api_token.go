@@ -0,0 +1,74 @@
+package types
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2/storage"
+
+	JC "jxwatcher/core"
+)
+
+const apiTokenFile = "api.token"
+
+func GetAPITokenPath() string {
+	fileURI, err := storage.ParseURI(JC.BuildPathRelatedToUserDirectory([]string{apiTokenFile}))
+	if err != nil {
+		JC.Logln("Error parsing URI for", apiTokenFile, err)
+		return JC.STRING_EMPTY
+	}
+	return fileURI.Path()
+}
+
+// LoadAPIToken prefers api_token from config.json, otherwise the token in api.token is generated on first use
+func LoadAPIToken() (string, error) {
+	if token := UseConfig().GetAPIToken(); token != JC.STRING_EMPTY {
+		return token, nil
+	}
+
+	return loadAPITokenFile(GetAPITokenPath())
+}
+
+func loadAPITokenFile(path string) (string, error) {
+	if path == JC.STRING_EMPTY {
+		return JC.STRING_EMPTY, fmt.Errorf("no path to store the api token")
+	}
+
+	content, err := os.ReadFile(path)
+	if err == nil {
+		if token := strings.TrimSpace(string(content)); token != JC.STRING_EMPTY {
+			return token, nil
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return JC.STRING_EMPTY, err
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return JC.STRING_EMPTY, err
+	}
+
+	token := hex.EncodeToString(raw)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return JC.STRING_EMPTY, err
+	}
+
+	if err := os.WriteFile(path, []byte(token+"\n"), 0600); err != nil {
+		return JC.STRING_EMPTY, err
+	}
+
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(path, 0600); err != nil {
+		return JC.STRING_EMPTY, err
+	}
+
+	JC.Logln("Generated API token in", path)
+
+	return token, nil
+}
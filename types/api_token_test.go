@@ -0,0 +1,63 @@
+package types
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+type apiTokenNullWriter struct{}
+
+func (apiTokenNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func apiTokenTurnOffLogs() {
+	log.SetOutput(apiTokenNullWriter{})
+}
+
+func apiTokenTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func TestLoadAPITokenFile(t *testing.T) {
+	apiTokenTurnOffLogs()
+	defer apiTokenTurnOnLogs()
+
+	path := filepath.Join(t.TempDir(), "api.token")
+
+	token, err := loadAPITokenFile(path)
+	if err != nil || len(token) != 64 {
+		t.Fatalf("Expected generated token, got %q (%v)", token, err)
+	}
+
+	if runtime.GOOS != "windows" {
+		if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+			t.Errorf("Expected api.token with 0600 permissions, got %v (%v)", info.Mode().Perm(), err)
+		}
+	}
+
+	again, err := loadAPITokenFile(path)
+	if err != nil || again != token {
+		t.Errorf("Expected stored token to be reused, got %q (%v)", again, err)
+	}
+}
+
+func TestLoadAPITokenPrefersConfig(t *testing.T) {
+	configMu.Lock()
+	previous := configStorage
+	configStorage = &configType{APIToken: "from-config"}
+	configMu.Unlock()
+
+	defer func() {
+		configMu.Lock()
+		configStorage = previous
+		configMu.Unlock()
+	}()
+
+	if token, err := LoadAPIToken(); err != nil || token != "from-config" {
+		t.Errorf("Expected token from config, got %q (%v)", token, err)
+	}
+}
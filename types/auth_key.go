@@ -0,0 +1,428 @@
+package types
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2/storage"
+
+	"github.com/buger/jsonparser"
+
+	json "github.com/goccy/go-json"
+
+	JC "jxwatcher/core"
+)
+
+var authKeyStorage *authKeyType = &authKeyType{}
+
+var authVaultIterations = 600000
+
+// A crafted vault must not stall the app deriving its key
+const authVaultMaxIterations = 10000000
+
+type authKeyType struct {
+	mu         sync.RWMutex
+	key        string
+	source     string
+	locked     bool
+	passphrase string
+	keyPath    string
+	vaultPath  string
+}
+
+type authVaultType struct {
+	Version    int    `json:"version"`
+	Iterations int    `json:"iterations"`
+	Salt       string `json:"salt"`
+	Nonce      string `json:"nonce"`
+	Data       string `json:"data"`
+}
+
+func (a *authKeyType) Init() {
+	a.mu.Lock()
+	a.keyPath = UseConfig().GetAuthKeyFile()
+	if a.keyPath == JC.STRING_EMPTY {
+		a.keyPath = a.resolvePath("auth.key")
+	}
+	a.vaultPath = a.resolvePath("auth.vault")
+	a.mu.Unlock()
+
+	a.Load()
+}
+
+// Load resolves the key, the environment wins over the vault and the vault over the key file
+func (a *authKeyType) Load() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.key = JC.STRING_EMPTY
+	a.source = JC.AUTH_KEY_SOURCE_NONE
+	a.locked = false
+
+	if key := strings.TrimSpace(os.Getenv(JC.AUTH_KEY_ENV)); key != JC.STRING_EMPTY {
+		a.key = key
+		a.source = JC.AUTH_KEY_SOURCE_ENV
+		return
+	}
+
+	if a.vaultPath != JC.STRING_EMPTY {
+		if _, err := os.Stat(a.vaultPath); err == nil {
+			a.source = JC.AUTH_KEY_SOURCE_VAULT
+
+			passphrase := a.passphrase
+			if passphrase == JC.STRING_EMPTY {
+				passphrase = os.Getenv(JC.AUTH_VAULT_PASSPHRASE_ENV)
+			}
+
+			if passphrase == JC.STRING_EMPTY {
+				a.locked = true
+				return
+			}
+
+			key, err := a.openVault(passphrase)
+			if err != nil {
+				JC.Logln("Failed to unlock auth key vault:", err)
+				a.locked = true
+				return
+			}
+
+			a.key = key
+			a.passphrase = passphrase
+			return
+		}
+	}
+
+	key, err := a.readKeyFile()
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			JC.Logln("Ignoring auth key file:", err)
+		}
+		return
+	}
+
+	if key != JC.STRING_EMPTY {
+		a.key = key
+		a.source = JC.AUTH_KEY_SOURCE_FILE
+	}
+}
+
+func (a *authKeyType) Unlock(passphrase string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.source != JC.AUTH_KEY_SOURCE_VAULT {
+		return fmt.Errorf("no auth key vault to unlock")
+	}
+
+	key, err := a.openVault(passphrase)
+	if err != nil {
+		return err
+	}
+
+	a.key = key
+	a.passphrase = passphrase
+	a.locked = false
+
+	return nil
+}
+
+// Set stores the key in the vault when a passphrase is known, otherwise in the key file
+func (a *authKeyType) Set(key string, passphrase string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key = strings.TrimSpace(key)
+
+	if key == a.key && passphrase == JC.STRING_EMPTY {
+		return nil
+	}
+
+	if a.source == JC.AUTH_KEY_SOURCE_ENV {
+		return fmt.Errorf("auth key is set by %s", JC.AUTH_KEY_ENV)
+	}
+
+	if a.source == JC.AUTH_KEY_SOURCE_VAULT && passphrase == JC.STRING_EMPTY {
+		if a.locked {
+			return fmt.Errorf("auth key vault is locked")
+		}
+		passphrase = a.passphrase
+	}
+
+	if key == JC.STRING_EMPTY {
+		for _, path := range []string{a.keyPath, a.vaultPath} {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+
+		a.key = JC.STRING_EMPTY
+		a.source = JC.AUTH_KEY_SOURCE_NONE
+		a.passphrase = JC.STRING_EMPTY
+		return nil
+	}
+
+	if passphrase != JC.STRING_EMPTY {
+		if err := a.writeVault(key, passphrase); err != nil {
+			return err
+		}
+
+		// The plain copy would defeat the vault
+		if err := os.Remove(a.keyPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			JC.Logln("Failed to remove auth key file:", err)
+		}
+
+		a.source = JC.AUTH_KEY_SOURCE_VAULT
+		a.passphrase = passphrase
+	} else {
+		if err := a.writeSecretFile(a.keyPath, []byte(key)); err != nil {
+			return err
+		}
+
+		a.source = JC.AUTH_KEY_SOURCE_FILE
+	}
+
+	a.key = key
+	a.locked = false
+
+	return nil
+}
+
+// Migrate moves a key left in config.json to the key file, unless another source already provides one.
+// A locked vault provides no key yet, so the legacy key stays in use and in config.json until it is unlocked.
+func (a *authKeyType) Migrate(legacy string) bool {
+	legacy = strings.TrimSpace(legacy)
+	if legacy == JC.STRING_EMPTY {
+		return false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.locked {
+		a.key = legacy
+		JC.Logln("Auth key vault is locked, using auth_key from config.json until it is unlocked")
+		return false
+	}
+
+	if a.source != JC.AUTH_KEY_SOURCE_NONE {
+		JC.Logln("Dropping auth_key from config.json, the key is provided by", a.source)
+		return true
+	}
+
+	a.key = legacy
+
+	if err := a.writeSecretFile(a.keyPath, []byte(legacy)); err != nil {
+		JC.Logln("Failed to move auth_key out of config.json:", err)
+		return false
+	}
+
+	a.source = JC.AUTH_KEY_SOURCE_FILE
+	JC.Logln("Moved auth_key from config.json to", a.keyPath)
+
+	return true
+}
+
+func (a *authKeyType) Get() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.key
+}
+
+func (a *authKeyType) GetSource() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.source
+}
+
+func (a *authKeyType) IsLocked() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.locked
+}
+
+func (a *authKeyType) SetHeader(req *http.Request) {
+	if key := a.Get(); key != JC.STRING_EMPTY {
+		req.Header.Set("Authorization", key)
+	}
+}
+
+func (a *authKeyType) resolvePath(name string) string {
+	fileURI, err := storage.ParseURI(JC.BuildPathRelatedToUserDirectory([]string{name}))
+	if err != nil {
+		JC.Logln("Error parsing URI for", name, err)
+		return JC.STRING_EMPTY
+	}
+	return fileURI.Path()
+}
+
+func (a *authKeyType) readKeyFile() (string, error) {
+	if a.keyPath == JC.STRING_EMPTY {
+		return JC.STRING_EMPTY, os.ErrNotExist
+	}
+
+	info, err := os.Stat(a.keyPath)
+	if err != nil {
+		return JC.STRING_EMPTY, err
+	}
+
+	// Windows has no unix permission bits to check
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return JC.STRING_EMPTY, fmt.Errorf("%s must have 0600 permissions, found %#o", a.keyPath, info.Mode().Perm())
+	}
+
+	content, err := os.ReadFile(a.keyPath)
+	if err != nil {
+		return JC.STRING_EMPTY, err
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+func (a *authKeyType) writeSecretFile(path string, content []byte) error {
+	if path == JC.STRING_EMPTY {
+		return fmt.Errorf("no path to store the auth key")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		return err
+	}
+
+	// WriteFile keeps the mode of an existing file
+	return os.Chmod(path, 0600)
+}
+
+func (a *authKeyType) writeVault(key string, passphrase string) error {
+	salt := make([]byte, 16)
+	rand.Read(salt)
+
+	gcm, err := a.vaultCipher(passphrase, salt, authVaultIterations)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	rand.Read(nonce)
+
+	vault := authVaultType{
+		Version:    1,
+		Iterations: authVaultIterations,
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Data:       base64.StdEncoding.EncodeToString(gcm.Seal(nil, nonce, []byte(key), nil)),
+	}
+
+	content, err := json.MarshalIndent(vault, JC.STRING_EMPTY, "  ")
+	if err != nil {
+		return err
+	}
+
+	return a.writeSecretFile(a.vaultPath, content)
+}
+
+func (a *authKeyType) openVault(passphrase string) (string, error) {
+	content, err := os.ReadFile(a.vaultPath)
+	if err != nil {
+		return JC.STRING_EMPTY, err
+	}
+
+	iterations, err := jsonparser.GetInt(content, "iterations")
+	if err != nil || iterations <= 0 {
+		return JC.STRING_EMPTY, fmt.Errorf("invalid auth key vault")
+	}
+
+	if iterations > authVaultMaxIterations {
+		return JC.STRING_EMPTY, fmt.Errorf("auth key vault iterations %d exceed the maximum of %d", iterations, authVaultMaxIterations)
+	}
+
+	fields := map[string][]byte{}
+	for _, name := range []string{"salt", "nonce", "data"} {
+		val, err := jsonparser.GetString(content, name)
+		if err != nil {
+			return JC.STRING_EMPTY, fmt.Errorf("invalid auth key vault")
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(val)
+		if err != nil {
+			return JC.STRING_EMPTY, fmt.Errorf("invalid auth key vault")
+		}
+
+		fields[name] = decoded
+	}
+
+	gcm, err := a.vaultCipher(passphrase, fields["salt"], int(iterations))
+	if err != nil {
+		return JC.STRING_EMPTY, err
+	}
+
+	if len(fields["nonce"]) != gcm.NonceSize() {
+		return JC.STRING_EMPTY, fmt.Errorf("invalid auth key vault")
+	}
+
+	plain, err := gcm.Open(nil, fields["nonce"], fields["data"], nil)
+	if err != nil {
+		return JC.STRING_EMPTY, fmt.Errorf("wrong passphrase or damaged auth key vault")
+	}
+
+	return string(plain), nil
+}
+
+func (a *authKeyType) vaultCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	secret, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// AuthKeyInit resolves the key and moves any auth_key left in config.json out of it
+func AuthKeyInit() {
+	authKeyStorage = &authKeyType{}
+	authKeyStorage.Init()
+
+	migrateConfigAuthKey()
+}
+
+func migrateConfigAuthKey() {
+	configMu.RLock()
+	legacy := configStorage.AuthKey
+	configMu.RUnlock()
+
+	// Keep the key in config.json until it is safely stored elsewhere
+	if !UseAuthKey().Migrate(legacy) {
+		return
+	}
+
+	configMu.Lock()
+	if configStorage.AuthKey == legacy {
+		configStorage.AuthKey = JC.STRING_EMPTY
+	}
+	configMu.Unlock()
+
+	ConfigSave()
+}
+
+func UseAuthKey() *authKeyType {
+	return authKeyStorage
+}
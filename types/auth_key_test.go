@@ -0,0 +1,319 @@
+package types
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	json "github.com/goccy/go-json"
+
+	JC "jxwatcher/core"
+)
+
+type authKeyNullWriter struct{}
+
+func (authKeyNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func authKeyTurnOffLogs() {
+	log.SetOutput(authKeyNullWriter{})
+}
+
+func authKeyTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func newTestAuthKey(t *testing.T) *authKeyType {
+	t.Setenv(JC.AUTH_KEY_ENV, "")
+	t.Setenv(JC.AUTH_VAULT_PASSPHRASE_ENV, "")
+
+	previous := authVaultIterations
+	authVaultIterations = 1000
+	t.Cleanup(func() {
+		authVaultIterations = previous
+	})
+
+	dir := t.TempDir()
+	return &authKeyType{
+		keyPath:   filepath.Join(dir, "auth.key"),
+		vaultPath: filepath.Join(dir, "auth.vault"),
+	}
+}
+
+func TestAuthKeyEnvironmentWins(t *testing.T) {
+	authKeyTurnOffLogs()
+	defer authKeyTurnOnLogs()
+
+	ak := newTestAuthKey(t)
+	os.WriteFile(ak.keyPath, []byte("file-key"), 0600)
+	t.Setenv(JC.AUTH_KEY_ENV, "env-key")
+
+	ak.Load()
+
+	if ak.Get() != "env-key" || ak.GetSource() != JC.AUTH_KEY_SOURCE_ENV {
+		t.Errorf("Expected key from environment, got %q from %q", ak.Get(), ak.GetSource())
+	}
+
+	if err := ak.Set("other-key", ""); err == nil {
+		t.Error("Expected Set to fail when the key comes from the environment")
+	}
+}
+
+func TestAuthKeyFileRequiresPrivatePermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("No unix permissions on windows")
+	}
+
+	authKeyTurnOffLogs()
+	defer authKeyTurnOnLogs()
+
+	ak := newTestAuthKey(t)
+	os.WriteFile(ak.keyPath, []byte("file-key\n"), 0644)
+	os.Chmod(ak.keyPath, 0644)
+
+	ak.Load()
+	if ak.Get() != JC.STRING_EMPTY || ak.GetSource() != JC.AUTH_KEY_SOURCE_NONE {
+		t.Errorf("Expected world readable key file to be ignored, got %q", ak.Get())
+	}
+
+	os.Chmod(ak.keyPath, 0600)
+
+	ak.Load()
+	if ak.Get() != "file-key" || ak.GetSource() != JC.AUTH_KEY_SOURCE_FILE {
+		t.Errorf("Expected key from file, got %q from %q", ak.Get(), ak.GetSource())
+	}
+}
+
+func TestAuthKeySetWritesPrivateFile(t *testing.T) {
+	authKeyTurnOffLogs()
+	defer authKeyTurnOnLogs()
+
+	ak := newTestAuthKey(t)
+
+	if err := ak.Set(" new-key ", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(ak.keyPath)
+	if string(content) != "new-key" {
+		t.Errorf("Expected trimmed key in file, got %q", string(content))
+	}
+
+	if runtime.GOOS != "windows" {
+		if info, _ := os.Stat(ak.keyPath); info.Mode().Perm() != 0600 {
+			t.Errorf("Expected 0600 permissions, got %#o", info.Mode().Perm())
+		}
+	}
+
+	if err := ak.Set("", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(ak.keyPath); !os.IsNotExist(err) {
+		t.Error("Expected key file removed after clearing the key")
+	}
+}
+
+func TestAuthKeyVault(t *testing.T) {
+	authKeyTurnOffLogs()
+	defer authKeyTurnOnLogs()
+
+	ak := newTestAuthKey(t)
+	ak.Set("plain-key", "")
+
+	if err := ak.Set("vault-key", "secret"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ak.GetSource() != JC.AUTH_KEY_SOURCE_VAULT {
+		t.Errorf("Expected vault source, got %q", ak.GetSource())
+	}
+	if _, err := os.Stat(ak.keyPath); !os.IsNotExist(err) {
+		t.Error("Expected plain key file removed once the vault is used")
+	}
+
+	content, _ := os.ReadFile(ak.vaultPath)
+	if strings.Contains(string(content), "vault-key") {
+		t.Error("Expected vault to not contain the plain key")
+	}
+
+	reopened := &authKeyType{keyPath: ak.keyPath, vaultPath: ak.vaultPath}
+	reopened.Load()
+
+	if !reopened.IsLocked() || reopened.Get() != JC.STRING_EMPTY {
+		t.Error("Expected vault to be locked without a passphrase")
+	}
+	if err := reopened.Unlock("wrong"); err == nil {
+		t.Error("Expected wrong passphrase to fail")
+	}
+	if err := reopened.Unlock("secret"); err != nil || reopened.Get() != "vault-key" {
+		t.Errorf("Expected vault to unlock, got %q %v", reopened.Get(), err)
+	}
+
+	// Editing the key keeps it in the unlocked vault
+	if err := reopened.Set("rotated-key", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t.Setenv(JC.AUTH_VAULT_PASSPHRASE_ENV, "secret")
+	fromEnv := &authKeyType{keyPath: ak.keyPath, vaultPath: ak.vaultPath}
+	fromEnv.Load()
+
+	if fromEnv.IsLocked() || fromEnv.Get() != "rotated-key" {
+		t.Errorf("Expected vault unlocked from environment, got %q", fromEnv.Get())
+	}
+}
+
+func TestAuthKeyLockedVaultRefusesSet(t *testing.T) {
+	authKeyTurnOffLogs()
+	defer authKeyTurnOnLogs()
+
+	ak := newTestAuthKey(t)
+	ak.Set("vault-key", "secret")
+
+	locked := &authKeyType{keyPath: ak.keyPath, vaultPath: ak.vaultPath}
+	locked.Load()
+
+	if err := locked.Set("other-key", ""); err == nil {
+		t.Error("Expected Set to fail on a locked vault")
+	}
+}
+
+func TestAuthKeyVaultRejectsExcessiveIterations(t *testing.T) {
+	authKeyTurnOffLogs()
+	defer authKeyTurnOnLogs()
+
+	ak := newTestAuthKey(t)
+	ak.Set("vault-key", "secret")
+
+	content, _ := os.ReadFile(ak.vaultPath)
+	content = []byte(strings.Replace(string(content), `"iterations": 1000`, `"iterations": 9000000000`, 1))
+	os.WriteFile(ak.vaultPath, content, 0600)
+
+	if _, err := ak.openVault("secret"); err == nil {
+		t.Error("Expected vault with excessive iterations to be rejected")
+	}
+}
+
+func TestAuthKeyLockedVaultKeepsLegacyKey(t *testing.T) {
+	authKeyTurnOffLogs()
+	defer authKeyTurnOnLogs()
+
+	ak := newTestAuthKey(t)
+	ak.Set("vault-key", "secret")
+
+	locked := &authKeyType{keyPath: ak.keyPath, vaultPath: ak.vaultPath}
+	locked.Load()
+
+	if locked.Migrate("legacy-key") {
+		t.Error("Expected legacy key to stay in config while the vault is locked")
+	}
+	if !locked.IsLocked() || locked.Get() != "legacy-key" {
+		t.Errorf("Expected legacy key in use on a locked vault, got %q", locked.Get())
+	}
+
+	if err := locked.Unlock("secret"); err != nil || locked.Get() != "vault-key" {
+		t.Errorf("Expected vault key after unlock, got %q %v", locked.Get(), err)
+	}
+}
+
+func TestMigrateConfigAuthKey(t *testing.T) {
+	authKeyTurnOffLogs()
+	defer authKeyTurnOnLogs()
+
+	previousConfig := configStorage
+	previousKey := authKeyStorage
+	defer func() {
+		configStorage = previousConfig
+		authKeyStorage = previousKey
+	}()
+
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	authKeyStorage = newTestAuthKey(t)
+	configStorage = &configType{AuthKey: "legacy-key"}
+
+	migrateConfigAuthKey()
+
+	if UseAuthKey().Get() != "legacy-key" || UseAuthKey().GetSource() != JC.AUTH_KEY_SOURCE_FILE {
+		t.Errorf("Expected legacy key in key file, got %q from %q", UseAuthKey().Get(), UseAuthKey().GetSource())
+	}
+
+	content, _ := json.Marshal(configStorage)
+	if strings.Contains(string(content), `"auth_key":`) || strings.Contains(string(content), "legacy-key") {
+		t.Errorf("Expected config json without the key, got %s", string(content))
+	}
+
+	if UseAuthKey().Migrate(JC.STRING_EMPTY) {
+		t.Error("Expected nothing to migrate without a legacy key")
+	}
+}
+
+func TestMigrateConfigAuthKeyKeepsKeyOnFailure(t *testing.T) {
+	authKeyTurnOffLogs()
+	defer authKeyTurnOnLogs()
+
+	previousConfig := configStorage
+	previousKey := authKeyStorage
+	defer func() {
+		configStorage = previousConfig
+		authKeyStorage = previousKey
+	}()
+
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	vault := newTestAuthKey(t)
+	vault.Set("vault-key", "secret")
+
+	locked := &authKeyType{keyPath: vault.keyPath, vaultPath: vault.vaultPath}
+	locked.Load()
+
+	// A file where the key directory should be makes the write fail
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	os.WriteFile(blocker, []byte{}, 0600)
+	unwritable := &authKeyType{keyPath: filepath.Join(blocker, "auth.key")}
+
+	tests := map[string]*authKeyType{
+		"locked vault": locked,
+		"failed write": unwritable,
+	}
+
+	for name, ak := range tests {
+		authKeyStorage = ak
+		configStorage = &configType{AuthKey: "legacy-key"}
+
+		migrateConfigAuthKey()
+		ConfigSave()
+
+		if UseConfig().AuthKey != "legacy-key" {
+			t.Errorf("%s: expected legacy key to stay in config, got %q", name, UseConfig().AuthKey)
+		}
+
+		content, _ := JC.LoadFileFromStorage("config.json")
+		if !strings.Contains(content, `"auth_key": "legacy-key"`) {
+			t.Errorf("%s: expected saved config to keep the key, got %s", name, content)
+		}
+	}
+}
+
+func TestAuthKeySetHeader(t *testing.T) {
+	ak := &authKeyType{}
+	req, _ := http.NewRequest("GET", "https://example.com", nil)
+
+	ak.SetHeader(req)
+	if req.Header.Get("Authorization") != JC.STRING_EMPTY {
+		t.Error("Expected no header without a key")
+	}
+
+	ak.key = "abc"
+	ak.SetHeader(req)
+	if req.Header.Get("Authorization") != "abc" {
+		t.Errorf("Expected Authorization header, got %q", req.Header.Get("Authorization"))
+	}
+}
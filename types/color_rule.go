@@ -0,0 +1,118 @@
+package types
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/buger/jsonparser"
+
+	JC "jxwatcher/core"
+)
+
+type colorRuleType struct {
+	Min   *float64 `json:"min,omitempty"`
+	Max   *float64 `json:"max,omitempty"`
+	Sign  string   `json:"sign,omitempty"`
+	Color string   `json:"color"`
+}
+
+type colorRulesType []colorRuleType
+
+func (r *colorRuleType) IsValid() bool {
+	if !JC.IsColorName(r.Color) {
+		return false
+	}
+
+	switch r.Sign {
+	case JC.STRING_EMPTY, JC.COLOR_RULE_POSITIVE, JC.COLOR_RULE_NEGATIVE, JC.COLOR_RULE_ZERO:
+	default:
+		return false
+	}
+
+	if r.Min != nil && r.Max != nil && *r.Min >= *r.Max {
+		return false
+	}
+
+	return true
+}
+
+// Min is inclusive and max exclusive, a rule without any condition matches everything
+func (r *colorRuleType) Match(val float64) bool {
+	if r.Min != nil && val < *r.Min {
+		return false
+	}
+
+	if r.Max != nil && val >= *r.Max {
+		return false
+	}
+
+	switch r.Sign {
+	case JC.COLOR_RULE_POSITIVE:
+		return val > 0
+	case JC.COLOR_RULE_NEGATIVE:
+		return val < 0
+	case JC.COLOR_RULE_ZERO:
+		return val == 0
+	}
+
+	return true
+}
+
+// First matching rule wins
+func (rs colorRulesType) Evaluate(val float64) (fyne.ThemeColorName, bool) {
+	for i := range rs {
+		if rs[i].Match(val) {
+			return fyne.ThemeColorName(rs[i].Color), true
+		}
+	}
+
+	return JC.STRING_EMPTY, false
+}
+
+func ParseColorRules(data []byte) (colorRulesType, error) {
+	rules := colorRulesType{}
+
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		rule := colorRuleType{}
+
+		if val, e := jsonparser.GetFloat(value, "min"); e == nil {
+			rule.Min = &val
+		}
+		if val, e := jsonparser.GetFloat(value, "max"); e == nil {
+			rule.Max = &val
+		}
+		if val, e := jsonparser.GetString(value, "sign"); e == nil {
+			rule.Sign = val
+		}
+		if val, e := jsonparser.GetString(value, "color"); e == nil {
+			rule.Color = val
+		}
+
+		if !rule.IsValid() {
+			JC.Logln("Ignoring invalid color rule:", string(value))
+			return
+		}
+
+		rules = append(rules, rule)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func NewColorRule(min *float64, max *float64, sign string, color fyne.ThemeColorName) colorRuleType {
+	return colorRuleType{Min: min, Max: max, Sign: sign, Color: string(color)}
+}
+
+func colorBand(min float64, color fyne.ThemeColorName) colorRuleType {
+	return NewColorRule(&min, nil, JC.STRING_EMPTY, color)
+}
+
+func colorSign(sign string, color fyne.ThemeColorName) colorRuleType {
+	return NewColorRule(nil, nil, sign, color)
+}
+
+func colorFallback(color fyne.ThemeColorName) colorRuleType {
+	return NewColorRule(nil, nil, JC.STRING_EMPTY, color)
+}
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	JC "jxwatcher/core"
+)
+
+type colorRuleNullWriter struct{}
+
+func (colorRuleNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func colorRuleTurnOffLogs() {
+	log.SetOutput(colorRuleNullWriter{})
+}
+
+func colorRuleTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func TestColorRuleIsValid(t *testing.T) {
+	min := 10.0
+	max := 5.0
+
+	tests := []struct {
+		name  string
+		rule  colorRuleType
+		valid bool
+	}{
+		{"band", colorBand(50, JC.ColorNameGreen), true},
+		{"sign", colorSign(JC.COLOR_RULE_NEGATIVE, JC.ColorNameRed), true},
+		{"fallback", colorFallback(JC.ColorNameTickerBG), true},
+		{"unknown color", NewColorRule(nil, nil, JC.STRING_EMPTY, "purple"), false},
+		{"unknown sign", NewColorRule(nil, nil, "up", JC.ColorNameGreen), false},
+		{"min above max", NewColorRule(&min, &max, JC.STRING_EMPTY, JC.ColorNameGreen), false},
+	}
+
+	for _, tt := range tests {
+		if tt.rule.IsValid() != tt.valid {
+			t.Errorf("%s: expected valid=%v", tt.name, tt.valid)
+		}
+	}
+}
+
+func TestColorRulesEvaluate(t *testing.T) {
+	min := 10.0
+	max := 20.0
+
+	rules := colorRulesType{
+		NewColorRule(&min, &max, JC.STRING_EMPTY, JC.ColorNameBlue),
+		colorSign(JC.COLOR_RULE_POSITIVE, JC.ColorNameGreen),
+		colorSign(JC.COLOR_RULE_ZERO, JC.ColorNameDarkGrey),
+	}
+
+	tests := []struct {
+		val      float64
+		expected string
+		matched  bool
+	}{
+		{10, "blue", true},
+		{19.99, "blue", true},
+		{20, "green", true},
+		{5, "green", true},
+		{0, "darkGrey", true},
+		{-1, JC.STRING_EMPTY, false},
+	}
+
+	for _, tt := range tests {
+		color, ok := rules.Evaluate(tt.val)
+		if ok != tt.matched || string(color) != tt.expected {
+			t.Errorf("Evaluate(%v) = %q %v, expected %q %v", tt.val, color, ok, tt.expected, tt.matched)
+		}
+	}
+
+	if _, ok := (colorRulesType{}).Evaluate(1); ok {
+		t.Error("Expected no match without rules")
+	}
+}
+
+func TestParseColorRules(t *testing.T) {
+	colorRuleTurnOffLogs()
+	defer colorRuleTurnOnLogs()
+
+	rules, err := ParseColorRules([]byte(`[
+		{"min": 70, "color": "green"},
+		{"min": 30, "max": 70, "color": "darkGrey"},
+		{"sign": "sideways", "color": "red"},
+		{"min": 5, "max": 1, "color": "red"},
+		{"color": "magenta"},
+		{"color": "red"}
+	]`))
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(rules) != 3 {
+		t.Fatalf("Expected invalid rules to be dropped, got %d rules", len(rules))
+	}
+
+	if rules[1].Min == nil || *rules[1].Min != 30 || rules[1].Max == nil || *rules[1].Max != 70 {
+		t.Errorf("Unexpected bounds %+v", rules[1])
+	}
+
+	if color, _ := rules.Evaluate(10); color != JC.ColorNameRed {
+		t.Errorf("Expected fallback red, got %s", color)
+	}
+
+	if _, err := ParseColorRules([]byte(`{"color": "red"}`)); err == nil {
+		t.Error("Expected error for non array rules")
+	}
+}
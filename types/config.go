@@ -1,12 +1,19 @@
 package types
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/buger/jsonparser"
 
+	json "github.com/goccy/go-json"
+
 	JC "jxwatcher/core"
 )
 
@@ -23,9 +30,56 @@ type configType struct {
 	RSIEndpoint       string `json:"rsi_endpoint"`
 	ETFEndpoint       string `json:"etf_endpoint"`
 	DominanceEndpoint string `json:"dominance_endpoint"`
-	AuthKey           string `json:"auth_key"`
+	AuthKey           string `json:"auth_key,omitempty"`
 	Delay             int64  `json:"delay"`
 	Version           string `json:"version"`
+
+	AuthKeyFile string `json:"auth_key_file"`
+
+	HistoryRetention          int64 `json:"history_retention"`
+	HistoryResolution         int64 `json:"history_resolution"`
+	HistoryDownsampleAfter    int64 `json:"history_downsample_after"`
+	HistoryDownsampleInterval int64 `json:"history_downsample_interval"`
+
+	Sparkline bool `json:"sparkline"`
+
+	AlertSinks   []alertSinkConfigType `json:"alert_sinks"`
+	AlertRoutes  map[string][]string   `json:"alert_routes"`
+	AlertRetries int64                 `json:"alert_retries"`
+
+	APIPort  int64  `json:"api_port"`
+	APIToken string `json:"api_token"`
+
+	RateProvider string                       `json:"rate_provider"`
+	RateSymbols  map[string]map[string]string `json:"rate_symbols"`
+
+	ExchangeEndpointSecondary string  `json:"exchange_endpoint_secondary"`
+	RateConsensus             string  `json:"rate_consensus"`
+	RateConsensusTolerance    float64 `json:"rate_consensus_tolerance"`
+
+	LedgerMethod string `json:"ledger_method"`
+
+	DisplayFiat string `json:"display_fiat"`
+
+	Locale string `json:"locale"`
+
+	Theme string `json:"theme"`
+
+	Tray        bool  `json:"tray"`
+	TrayPanels  int64 `json:"tray_panels"`
+	CloseToTray bool  `json:"close_to_tray"`
+
+	CustomTickers []customTickerConfigType `json:"custom_tickers"`
+
+	Proxy         string              `json:"proxy"`
+	ProxyUsername string              `json:"proxy_username"`
+	ProxyPassword string              `json:"proxy_password"`
+	CABundle      string              `json:"ca_bundle"`
+	PinnedKeys    map[string][]string `json:"pinned_keys"`
+
+	QuotaPerMinute int64 `json:"quota_per_minute"`
+	QuotaDaily     int64 `json:"quota_daily"`
+	QuotaMonthly   int64 `json:"quota_monthly"`
 }
 
 func (c *configType) update() bool {
@@ -96,15 +150,149 @@ func (c *configType) parseJSON(data []byte) error {
 	if val, err := jsonparser.GetString(data, "dominance_endpoint"); err == nil {
 		c.DominanceEndpoint = val
 	}
+	// Only read to move keys from older configs out of the file
 	if val, err := jsonparser.GetString(data, "auth_key"); err == nil {
 		c.AuthKey = val
 	}
+	if val, err := jsonparser.GetString(data, "auth_key_file"); err == nil {
+		c.AuthKeyFile = val
+	}
 	if val, err := jsonparser.GetString(data, "version"); err == nil {
 		c.Version = val
 	}
 	if val, err := jsonparser.GetInt(data, "delay"); err == nil {
 		c.Delay = val
 	}
+	if val, err := jsonparser.GetInt(data, "history_retention"); err == nil {
+		c.HistoryRetention = val
+	}
+	if val, err := jsonparser.GetInt(data, "history_resolution"); err == nil {
+		c.HistoryResolution = val
+	}
+	if val, err := jsonparser.GetInt(data, "history_downsample_after"); err == nil {
+		c.HistoryDownsampleAfter = val
+	}
+	if val, err := jsonparser.GetInt(data, "history_downsample_interval"); err == nil {
+		c.HistoryDownsampleInterval = val
+	}
+	if val, err := jsonparser.GetBoolean(data, "sparkline"); err == nil {
+		c.Sparkline = val
+	}
+	if val, err := jsonparser.GetInt(data, "alert_retries"); err == nil {
+		c.AlertRetries = val
+	}
+	if val, err := jsonparser.GetInt(data, "api_port"); err == nil {
+		c.APIPort = val
+	}
+	if val, err := jsonparser.GetString(data, "api_token"); err == nil {
+		c.APIToken = val
+	}
+	if val, err := jsonparser.GetString(data, "rate_provider"); err == nil {
+		c.RateProvider = val
+	}
+	if val, err := jsonparser.GetString(data, "exchange_endpoint_secondary"); err == nil {
+		c.ExchangeEndpointSecondary = val
+	}
+	if val, err := jsonparser.GetString(data, "rate_consensus"); err == nil {
+		c.RateConsensus = val
+	}
+	if val, err := jsonparser.GetFloat(data, "rate_consensus_tolerance"); err == nil {
+		c.RateConsensusTolerance = val
+	}
+	if val, err := jsonparser.GetString(data, "ledger_method"); err == nil {
+		c.LedgerMethod = val
+	}
+	if val, err := jsonparser.GetString(data, "display_fiat"); err == nil {
+		c.DisplayFiat = val
+	}
+	if val, err := jsonparser.GetString(data, "locale"); err == nil {
+		c.Locale = val
+	}
+	if val, err := jsonparser.GetString(data, "theme"); err == nil {
+		c.Theme = val
+	}
+	if val, err := jsonparser.GetBoolean(data, "tray"); err == nil {
+		c.Tray = val
+	}
+	if val, err := jsonparser.GetInt(data, "tray_panels"); err == nil {
+		c.TrayPanels = val
+	}
+	if val, err := jsonparser.GetBoolean(data, "close_to_tray"); err == nil {
+		c.CloseToTray = val
+	}
+	if val, err := jsonparser.GetString(data, "proxy"); err == nil {
+		c.Proxy = val
+	}
+	if val, err := jsonparser.GetString(data, "proxy_username"); err == nil {
+		c.ProxyUsername = val
+	}
+	if val, err := jsonparser.GetString(data, "proxy_password"); err == nil {
+		c.ProxyPassword = val
+	}
+	if val, err := jsonparser.GetString(data, "ca_bundle"); err == nil {
+		c.CABundle = val
+	}
+	if val, err := jsonparser.GetInt(data, "quota_per_minute"); err == nil {
+		c.QuotaPerMinute = val
+	}
+	if val, err := jsonparser.GetInt(data, "quota_daily"); err == nil {
+		c.QuotaDaily = val
+	}
+	if val, err := jsonparser.GetInt(data, "quota_monthly"); err == nil {
+		c.QuotaMonthly = val
+	}
+
+	c.AlertSinks = []alertSinkConfigType{}
+	jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		sink := alertSinkConfigType{}
+		sink.parseJSON(value)
+		c.AlertSinks = append(c.AlertSinks, sink)
+	}, "alert_sinks")
+
+	c.AlertRoutes = make(map[string][]string)
+	jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		names := []string{}
+		jsonparser.ArrayEach(value, func(name []byte, dataType jsonparser.ValueType, offset int, err error) {
+			if val, err := jsonparser.ParseString(name); err == nil {
+				names = append(names, val)
+			}
+		})
+		c.AlertRoutes[string(key)] = names
+		return nil
+	}, "alert_routes")
+
+	c.PinnedKeys = make(map[string][]string)
+	jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		pins := []string{}
+		jsonparser.ArrayEach(value, func(pin []byte, dataType jsonparser.ValueType, offset int, err error) {
+			if val, err := jsonparser.ParseString(pin); err == nil {
+				pins = append(pins, val)
+			}
+		})
+		c.PinnedKeys[string(key)] = pins
+		return nil
+	}, "pinned_keys")
+
+	c.CustomTickers = []customTickerConfigType{}
+	jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		ticker := customTickerConfigType{}
+		ticker.parseJSON(value)
+		c.CustomTickers = append(c.CustomTickers, ticker)
+	}, "custom_tickers")
+
+	c.RateSymbols = make(map[string]map[string]string)
+	jsonparser.ObjectEach(data, func(provider []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		symbols := make(map[string]string)
+		jsonparser.ObjectEach(value, func(symbol []byte, code []byte, dataType jsonparser.ValueType, offset int) error {
+			if val, err := jsonparser.ParseString(code); err == nil {
+				symbols[string(symbol)] = val
+			}
+			return nil
+		})
+		c.RateSymbols[string(provider)] = symbols
+		return nil
+	}, "rate_symbols")
+
 	return nil
 }
 
@@ -141,26 +329,58 @@ func (c *configType) save() bool {
 	return JC.SaveFileToStorage("config.json", configStorage)
 }
 
+func defaultConfig() configType {
+	return configType{
+		DataEndpoint:      "https://s3.coinmarketcap.com/generated/core/crypto/cryptos.json",
+		ExchangeEndpoint:  "https://api.coinmarketcap.com/data-api/v3/tools/price-conversion",
+		AltSeasonEndpoint: "https://api.coinmarketcap.com/data-api/v3/altcoin-season/chart",
+		FearGreedEndpoint: "https://api.coinmarketcap.com/data-api/v3/fear-greed/chart",
+		CMC100Endpoint:    "https://api.coinmarketcap.com/data-api/v3/top100/supplement",
+		MarketCapEndpoint: "https://api.coinmarketcap.com/data-api/v4/global-metrics/quotes/historical",
+		RSIEndpoint:       "https://api.coinmarketcap.com/data-api/v3/cryptocurrency/rsi/heatmap/overall",
+		ETFEndpoint:       "https://api.coinmarketcap.com/data-api/v3/etf/overview/netflow/chart",
+		DominanceEndpoint: "https://api.coinmarketcap.com/data-api/v3/global-metrics/dominance/overview",
+		Version:           "1.9.0",
+		Delay:             60,
+
+		HistoryRetention:          90,
+		HistoryResolution:         60,
+		HistoryDownsampleAfter:    7,
+		HistoryDownsampleInterval: 900,
+
+		Sparkline: true,
+
+		AlertSinks:   []alertSinkConfigType{},
+		AlertRoutes:  map[string][]string{},
+		AlertRetries: 3,
+
+		RateProvider: JC.RATE_PROVIDER_CMC,
+		RateSymbols:  map[string]map[string]string{},
+
+		RateConsensusTolerance: 1,
+
+		LedgerMethod: JC.LEDGER_METHOD_FIFO,
+
+		DisplayFiat: JC.FIAT_USD,
+
+		Theme: JC.THEME_DARK,
+
+		Tray:       true,
+		TrayPanels: 5,
+
+		CustomTickers: []customTickerConfigType{},
+
+		PinnedKeys: map[string][]string{},
+	}
+}
+
 func (c *configType) check() *configType {
 	configMu.Lock()
 	defer configMu.Unlock()
 
 	exists, _ := JC.FileExists(JC.BuildPathRelatedToUserDirectory([]string{"config.json"}))
 	if !exists {
-		data := configType{
-			DataEndpoint:      "https://s3.coinmarketcap.com/generated/core/crypto/cryptos.json",
-			ExchangeEndpoint:  "https://api.coinmarketcap.com/data-api/v3/tools/price-conversion",
-			AltSeasonEndpoint: "https://api.coinmarketcap.com/data-api/v3/altcoin-season/chart",
-			FearGreedEndpoint: "https://api.coinmarketcap.com/data-api/v3/fear-greed/chart",
-			CMC100Endpoint:    "https://api.coinmarketcap.com/data-api/v3/top100/supplement",
-			MarketCapEndpoint: "https://api.coinmarketcap.com/data-api/v4/global-metrics/quotes/historical",
-			RSIEndpoint:       "https://api.coinmarketcap.com/data-api/v3/cryptocurrency/rsi/heatmap/overall",
-			ETFEndpoint:       "https://api.coinmarketcap.com/data-api/v3/etf/overview/netflow/chart",
-			DominanceEndpoint: "https://api.coinmarketcap.com/data-api/v3/global-metrics/dominance/overview",
-			AuthKey:           "",
-			Version:           "1.8.1",
-			Delay:             60,
-		}
+		data := defaultConfig()
 
 		if !JC.SaveFileToStorage("config.json", data) {
 			JC.Logln("Failed to create config.json with default values")
@@ -176,15 +396,47 @@ func (c *configType) check() *configType {
 }
 
 func (c *configType) PostInit() {
+	if c.migrate() {
+		c.save()
+	}
+}
+
+// Brings an older config up to the current version, returns whether anything changed
+func (c *configType) migrate() bool {
+	migrated := false
+
 	if c.IsVersionLessThan("1.7.0") {
 		JC.Logln("Updating old config to 1.8.0")
 		c.Version = "1.8.0"
-		c.save()
+		migrated = true
 	} else if c.IsVersionLessThan("1.8.1") {
 		JC.Logln("Updating old config to 1.8.1")
 		c.Version = "1.8.1"
-		c.save()
+		migrated = true
 	}
+
+	if c.IsVersionLessThan("1.9.0") {
+		JC.Logln("Updating old config to 1.9.0")
+		c.Version = "1.9.0"
+		c.HistoryRetention = 90
+		c.HistoryResolution = 60
+		c.HistoryDownsampleAfter = 7
+		c.HistoryDownsampleInterval = 900
+		c.Sparkline = true
+		c.AlertRetries = 3
+		c.RateProvider = JC.RATE_PROVIDER_CMC
+		c.RateConsensusTolerance = 1
+		c.LedgerMethod = JC.LEDGER_METHOD_FIFO
+		c.DisplayFiat = JC.FIAT_USD
+		c.Theme = JC.THEME_DARK
+		c.Tray = true
+		c.TrayPanels = 5
+		c.CustomTickers = []customTickerConfigType{}
+		c.PinnedKeys = map[string][]string{}
+		migrated = true
+	}
+
+	return migrated
 }
 
 func (c *configType) IsVersionLessThan(target string) bool {
@@ -221,6 +473,11 @@ func (c *configType) IsValid() bool {
 func (c *configType) IsValidTickers() bool {
 	configMu.RLock()
 	defer configMu.RUnlock()
+	for _, ct := range c.CustomTickers {
+		if ct.IsValid() {
+			return true
+		}
+	}
 	return c.CMC100Endpoint != JC.STRING_EMPTY || c.FearGreedEndpoint != JC.STRING_EMPTY || c.MarketCapEndpoint != JC.STRING_EMPTY || c.AltSeasonEndpoint != JC.STRING_EMPTY || c.RSIEndpoint != JC.STRING_EMPTY || c.ETFEndpoint != JC.STRING_EMPTY || c.DominanceEndpoint != JC.STRING_EMPTY
 }
 
@@ -266,6 +523,297 @@ func (c *configType) CanDoDominance() bool {
 	return c.DominanceEndpoint != JC.STRING_EMPTY
 }
 
+func (c *configType) CanShowSparkline() bool {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return c.Sparkline
+}
+
+func (c *configType) GetHistoryRetention() time.Duration {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return time.Duration(max(c.HistoryRetention, 0)) * 24 * time.Hour
+}
+
+func (c *configType) GetHistoryResolution() time.Duration {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return time.Duration(max(c.HistoryResolution, 0)) * time.Second
+}
+
+func (c *configType) GetHistoryDownsampleAfter() time.Duration {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return time.Duration(max(c.HistoryDownsampleAfter, 0)) * 24 * time.Hour
+}
+
+func (c *configType) GetHistoryDownsampleInterval() time.Duration {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return time.Duration(max(c.HistoryDownsampleInterval, 0)) * time.Second
+}
+
+func (c *configType) GetAlertSinks() []alertSinkConfigType {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return append([]alertSinkConfigType{}, c.AlertSinks...)
+}
+
+func (c *configType) GetAlertRoutes() map[string][]string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	routes := make(map[string][]string, len(c.AlertRoutes))
+	for key, names := range c.AlertRoutes {
+		routes[key] = append([]string{}, names...)
+	}
+	return routes
+}
+
+func (c *configType) GetAlertRetries() int {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return int(max(c.AlertRetries, 0))
+}
+
+func (c *configType) GetRateProvider() string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	if c.RateProvider == JC.STRING_EMPTY {
+		return JC.RATE_PROVIDER_CMC
+	}
+	return c.RateProvider
+}
+
+func (c *configType) GetRateSymbols() map[string]map[string]string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	symbols := make(map[string]map[string]string, len(c.RateSymbols))
+	for provider, codes := range c.RateSymbols {
+		symbols[provider] = make(map[string]string, len(codes))
+		for symbol, code := range codes {
+			symbols[provider][symbol] = code
+		}
+	}
+	return symbols
+}
+
+func (c *configType) GetExchangeEndpoints() []string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	endpoints := []string{}
+	for _, endpoint := range []string{c.ExchangeEndpoint, c.ExchangeEndpointSecondary} {
+		if endpoint != JC.STRING_EMPTY {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+func (c *configType) GetRateConsensus() string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return c.RateConsensus
+}
+
+func (c *configType) GetRateConsensusTolerance() float64 {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return max(c.RateConsensusTolerance, 0)
+}
+
+func (c *configType) CanDoRateConsensus() bool {
+	return c.GetRateConsensus() != JC.STRING_EMPTY && c.GetRateConsensusTolerance() > 0
+}
+
+func (c *configType) GetLedgerMethod() string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	switch c.LedgerMethod {
+	case JC.LEDGER_METHOD_LIFO, JC.LEDGER_METHOD_AVERAGE:
+		return c.LedgerMethod
+	}
+	return JC.LEDGER_METHOD_FIFO
+}
+
+// Unknown currencies fall back to USD
+func (c *configType) GetDisplayFiat() JC.FiatType {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	if fiat, ok := JC.GetFiat(c.DisplayFiat); ok {
+		return fiat
+	}
+
+	fiat, _ := JC.GetFiat(JC.FIAT_USD)
+	return fiat
+}
+
+// Empty means the system language
+func (c *configType) GetLocale() string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	return c.Locale
+}
+
+// Unknown modes fall back to dark
+func (c *configType) GetTheme() string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	switch c.Theme {
+	case JC.THEME_SYSTEM, JC.THEME_LIGHT:
+		return c.Theme
+	}
+	return JC.THEME_DARK
+}
+
+func (c *configType) CanShowTray() bool {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	return c.Tray && !JC.IsMobile
+}
+
+func (c *configType) GetTrayPanels() int {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	if c.TrayPanels <= 0 {
+		return 5
+	}
+	return int(c.TrayPanels)
+}
+
+// Closing only hides the window when the tray is there to bring it back
+func (c *configType) CanCloseToTray() bool {
+	return c.CloseToTray && c.CanShowTray()
+}
+
+// Only valid entries with unique ids, invalid ones are logged and skipped
+func (c *configType) GetCustomTickers() []customTickerConfigType {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	seen := map[string]bool{}
+	tickers := []customTickerConfigType{}
+	for _, ct := range c.CustomTickers {
+		if !ct.IsValid() || seen[ct.ID] {
+			JC.Logln("Ignoring invalid or duplicated custom ticker:", ct.ID)
+			continue
+		}
+		seen[ct.ID] = true
+		tickers = append(tickers, ct)
+	}
+	return tickers
+}
+
+func (c *configType) GetAuthKeyFile() string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	return ExpandConfigPath(c.AuthKeyFile)
+}
+
+func (c *configType) GetNetworking() JC.NetworkingConfigType {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	pins := make(map[string][]string, len(c.PinnedKeys))
+	for host, keys := range c.PinnedKeys {
+		pins[host] = append([]string{}, keys...)
+	}
+
+	return JC.NetworkingConfigType{
+		Proxy:         strings.TrimSpace(c.Proxy),
+		ProxyUsername: c.ProxyUsername,
+		ProxyPassword: c.ProxyPassword,
+		CABundle:      ExpandConfigPath(c.CABundle),
+		PinnedKeys:    pins,
+	}
+}
+
+// Negative limits are treated as unlimited
+func (c *configType) GetQuotaLimits() (int64, int64, int64) {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	return max(c.QuotaPerMinute, 0), max(c.QuotaDaily, 0), max(c.QuotaMonthly, 0)
+}
+
+// Pinned keys are edited as one "host sha256/key" pair per line
+func ParsePinnedKeys(text string) (map[string][]string, error) {
+	pins := make(map[string][]string)
+
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("expected host and key in %q", strings.TrimSpace(line))
+		}
+		if _, err := JC.ParsePinnedKey(fields[1]); err != nil {
+			return nil, err
+		}
+
+		host := strings.ToLower(fields[0])
+		pins[host] = append(pins[host], fields[1])
+	}
+
+	return pins, nil
+}
+
+func FormatPinnedKeys(pins map[string][]string) string {
+	hosts := make([]string, 0, len(pins))
+	for host := range pins {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	lines := []string{}
+	for _, host := range hosts {
+		for _, key := range pins[host] {
+			lines = append(lines, host+" "+key)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func ExpandConfigPath(path string) string {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+
+	return path
+}
+
+func (c *configType) GetAPIPort() int {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	if c.APIPort <= 0 || c.APIPort > 65535 {
+		return 0
+	}
+	return int(c.APIPort)
+}
+
+func (c *configType) GetAPIToken() string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+
+	return strings.TrimSpace(c.APIToken)
+}
+
 func ConfigInit() bool {
 	configMu.Lock()
 	configStorage = &configType{}
@@ -274,6 +822,66 @@ func ConfigInit() bool {
 	return UseConfig().check().load()
 }
 
+// ConfigReload applies config.json edited outside the app, the current config is kept when it is invalid
+func ConfigReload(data []byte) (bool, error) {
+	if !json.Valid(data) {
+		return false, fmt.Errorf("config.json is not valid json")
+	}
+
+	// Keys left out of the file fall back to their defaults, as they would on a fresh start
+	next := defaultConfig()
+	if err := next.parseJSON(data); err != nil {
+		return false, err
+	}
+
+	next.update()
+	next.migrate()
+
+	if !next.IsValid() {
+		return false, fmt.Errorf("config.json requires data_endpoint and exchange_endpoint")
+	}
+
+	// Replace in place, callers may hold the pointer from UseConfig()
+	configMu.Lock()
+	delayChanged := configStorage.Delay != next.Delay
+	keyFileChanged := configStorage.AuthKeyFile != next.AuthKeyFile
+	*configStorage = next
+	configMu.Unlock()
+
+	if keyFileChanged {
+		UseAuthKey().Init()
+	}
+
+	migrateConfigAuthKey()
+
+	JC.Logln("Configuration Reloaded")
+
+	return delayChanged, nil
+}
+
+// The UI chrome is built before the full config is loaded
+func LoadConfigLocale() string {
+	return loadConfigString("locale")
+}
+
+func LoadConfigTheme() string {
+	return loadConfigString("theme")
+}
+
+func loadConfigString(key string) string {
+	content, ok := JC.LoadFileFromStorage("config.json")
+	if !ok {
+		return JC.STRING_EMPTY
+	}
+
+	val, err := jsonparser.GetString([]byte(content), key)
+	if err != nil {
+		return JC.STRING_EMPTY
+	}
+
+	return val
+}
+
 func UseConfig() *configType {
 	return configStorage
 }
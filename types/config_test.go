@@ -3,6 +3,7 @@ package types
 import (
 	"log"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 
@@ -258,7 +259,25 @@ func TestConfigParseJSON(t *testing.T) {
 		"etf_endpoint": "https://etf",
 		"dominance_endpoint": "https://dominance",
 		"version": "1.9.0",
-		"delay": 42
+		"delay": 42,
+		"api_port": 8899,
+		"api_token": " secret-token ",
+		"rate_provider": "kraken",
+		"rate_symbols": {"coingecko": {"SCRT": "secret"}},
+		"exchange_endpoint_secondary": "https://mirror",
+		"rate_consensus": "binance",
+		"rate_consensus_tolerance": 0.5,
+		"ledger_method": "lifo",
+		"display_fiat": "eur",
+		"locale": "de",
+		"tray": true,
+		"tray_panels": 3,
+		"close_to_tray": true,
+		"custom_tickers": [
+			{"id": "gas", "title": "Gas", "endpoint": "https://gas", "value_path": ["data", "fast"], "interval": 30},
+			{"id": "gas", "endpoint": "https://gas2", "value_path": ["fast"]},
+			{"id": "Bad ID", "endpoint": "https://bad", "value_path": ["value"]}
+		]
 	}`)
 
 	cfg := &configType{}
@@ -301,6 +320,245 @@ func TestConfigParseJSON(t *testing.T) {
 	if cfg.Delay != 42 {
 		t.Errorf("Expected Delay=42, got %d", cfg.Delay)
 	}
+	if cfg.GetAPIPort() != 8899 {
+		t.Errorf("Expected APIPort=8899, got %d", cfg.GetAPIPort())
+	}
+	if cfg.GetAPIToken() != "secret-token" {
+		t.Errorf("Expected APIToken=secret-token, got %q", cfg.GetAPIToken())
+	}
+
+	cfg.APIPort = 70000
+	if cfg.GetAPIPort() != 0 {
+		t.Errorf("Expected out of range APIPort to be disabled, got %d", cfg.GetAPIPort())
+	}
+	if cfg.GetRateProvider() != "kraken" {
+		t.Errorf("Expected RateProvider=kraken, got %s", cfg.GetRateProvider())
+	}
+	if cfg.GetRateSymbols()["coingecko"]["SCRT"] != "secret" {
+		t.Errorf("Expected rate symbol override to be parsed, got %v", cfg.GetRateSymbols())
+	}
+	if endpoints := cfg.GetExchangeEndpoints(); len(endpoints) != 2 || endpoints[1] != "https://mirror" {
+		t.Errorf("Expected primary and secondary exchange endpoints, got %v", endpoints)
+	}
+	if !cfg.CanDoRateConsensus() || cfg.GetRateConsensusTolerance() != 0.5 {
+		t.Error("Expected rate consensus to be enabled")
+	}
+
+	cfg.RateConsensusTolerance = 0
+	if cfg.CanDoRateConsensus() {
+		t.Error("Expected rate consensus to be disabled without tolerance")
+	}
+	if cfg.GetLedgerMethod() != "lifo" {
+		t.Errorf("Expected LedgerMethod=lifo, got %s", cfg.GetLedgerMethod())
+	}
+
+	cfg.LedgerMethod = "random"
+	if cfg.GetLedgerMethod() != "fifo" {
+		t.Errorf("Expected unknown ledger method to fall back to fifo, got %s", cfg.GetLedgerMethod())
+	}
+	if cfg.GetDisplayFiat().Code != "EUR" {
+		t.Errorf("Expected DisplayFiat=EUR, got %s", cfg.GetDisplayFiat().Code)
+	}
+
+	if cfg.GetLocale() != "de" {
+		t.Errorf("Expected Locale=de, got %s", cfg.GetLocale())
+	}
+
+	cfg.DisplayFiat = "XYZ"
+	if cfg.GetDisplayFiat().Code != JC.FIAT_USD {
+		t.Errorf("Expected unknown fiat to fall back to USD, got %s", cfg.GetDisplayFiat().Code)
+	}
+
+	if !cfg.CanShowTray() || cfg.GetTrayPanels() != 3 || !cfg.CanCloseToTray() {
+		t.Errorf("Expected tray with 3 panels and close to tray, got %v %d %v", cfg.CanShowTray(), cfg.GetTrayPanels(), cfg.CanCloseToTray())
+	}
+	cfg.Tray = false
+	cfg.TrayPanels = 0
+	if cfg.CanCloseToTray() || cfg.GetTrayPanels() != 5 {
+		t.Errorf("Expected no close to tray without a tray and default panel count, got %v %d", cfg.CanCloseToTray(), cfg.GetTrayPanels())
+	}
+	if len(cfg.CustomTickers) != 3 {
+		t.Errorf("Expected 3 parsed custom tickers, got %d", len(cfg.CustomTickers))
+	}
+	if cts := cfg.GetCustomTickers(); len(cts) != 1 || cts[0].Endpoint != "https://gas" || cts[0].Interval != 30 {
+		t.Errorf("Expected only the first valid custom ticker, got %v", cts)
+	}
 
 	configTurnOnLogs()
 }
+
+func TestConfigParseJSONTheme(t *testing.T) {
+	cfg := &configType{}
+	if err := cfg.parseJSON([]byte(`{"theme": "system"}`)); err != nil {
+		t.Fatalf("Unexpected error parsing JSON: %v", err)
+	}
+
+	if cfg.GetTheme() != JC.THEME_SYSTEM {
+		t.Errorf("Expected Theme=system, got %s", cfg.GetTheme())
+	}
+
+	cfg.Theme = "neon"
+	if cfg.GetTheme() != JC.THEME_DARK {
+		t.Errorf("Expected unknown theme to fall back to dark, got %s", cfg.GetTheme())
+	}
+}
+
+func TestConfigParseJSONAuthKey(t *testing.T) {
+	cfg := &configType{}
+	if err := cfg.parseJSON([]byte(`{"auth_key_file": "/secrets/cmc.key"}`)); err != nil {
+		t.Fatalf("Unexpected error parsing JSON: %v", err)
+	}
+
+	if cfg.GetAuthKeyFile() != "/secrets/cmc.key" {
+		t.Errorf("Expected auth key file /secrets/cmc.key, got %s", cfg.GetAuthKeyFile())
+	}
+	if cfg.AuthKey != "" {
+		t.Errorf("Expected no auth key without auth_key, got %s", cfg.AuthKey)
+	}
+
+	if err := cfg.parseJSON([]byte(`{"auth_key": "legacy-key"}`)); err != nil {
+		t.Fatalf("Unexpected error parsing JSON: %v", err)
+	}
+	if cfg.AuthKey != "legacy-key" {
+		t.Errorf("Expected legacy auth key to be read for migration, got %s", cfg.AuthKey)
+	}
+}
+
+func TestConfigParseJSONNetworking(t *testing.T) {
+	raw := []byte(`{
+		"proxy": " socks5://127.0.0.1:1080 ",
+		"proxy_username": "user",
+		"proxy_password": "secret",
+		"ca_bundle": "/certs/corp.pem",
+		"pinned_keys": {"pro-api.coinmarketcap.com": ["sha256/47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="]}
+	}`)
+
+	cfg := &configType{}
+	if err := cfg.parseJSON(raw); err != nil {
+		t.Fatalf("Unexpected error parsing JSON: %v", err)
+	}
+
+	network := cfg.GetNetworking()
+	if network.Proxy != "socks5://127.0.0.1:1080" || network.ProxyUsername != "user" || network.ProxyPassword != "secret" || network.CABundle != "/certs/corp.pem" {
+		t.Errorf("Unexpected networking config %+v", network)
+	}
+	if len(network.PinnedKeys["pro-api.coinmarketcap.com"]) != 1 {
+		t.Errorf("Expected one pinned key, got %v", network.PinnedKeys)
+	}
+	if err := network.Validate(); err == nil {
+		t.Error("Expected missing ca bundle to be rejected")
+	}
+}
+
+func TestConfigParseJSONQuota(t *testing.T) {
+	cfg := &configType{}
+	if err := cfg.parseJSON([]byte(`{"quota_per_minute": 30, "quota_daily": -5, "quota_monthly": 10000}`)); err != nil {
+		t.Fatalf("Unexpected error parsing JSON: %v", err)
+	}
+
+	if perMinute, daily, monthly := cfg.GetQuotaLimits(); perMinute != 30 || daily != 0 || monthly != 10000 {
+		t.Errorf("Expected quota limits 30/0/10000, got %d/%d/%d", perMinute, daily, monthly)
+	}
+}
+
+func reloadTestConfig(t *testing.T) {
+	configTurnOffLogs()
+	t.Cleanup(configTurnOnLogs)
+
+	previous := configStorage
+	t.Cleanup(func() {
+		configStorage = previous
+	})
+
+	configStorage = &configType{
+		DataEndpoint:     "https://data",
+		ExchangeEndpoint: "https://exchange",
+		Delay:            60,
+		Theme:            JC.THEME_DARK,
+	}
+}
+
+func TestConfigReload(t *testing.T) {
+	reloadTestConfig(t)
+	held := UseConfig()
+
+	changed, err := ConfigReload([]byte(`{"data_endpoint":"https://data","exchange_endpoint":"https://exchange","delay":60,"theme":"light"}`))
+	if err != nil {
+		t.Fatalf("Unexpected reload error: %v", err)
+	}
+	if changed {
+		t.Error("Expected delay to be unchanged")
+	}
+	if held.GetTheme() != JC.THEME_LIGHT {
+		t.Errorf("Expected reloaded theme on held config, got %s", held.GetTheme())
+	}
+
+	changed, err = ConfigReload([]byte(`{"data_endpoint":"https://data","exchange_endpoint":"https://exchange","delay":120}`))
+	if err != nil || !changed {
+		t.Errorf("Expected delay change to be reported, got %v %v", changed, err)
+	}
+	if UseConfig().Delay != 120 {
+		t.Errorf("Expected delay 120, got %d", UseConfig().Delay)
+	}
+}
+
+func TestConfigReloadInvalidKeepsConfig(t *testing.T) {
+	reloadTestConfig(t)
+
+	invalid := []string{
+		`{"data_endpoint":"https://data",`,
+		`{"data_endpoint":"","exchange_endpoint":"https://exchange","delay":30}`,
+	}
+	for _, data := range invalid {
+		if _, err := ConfigReload([]byte(data)); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+
+	if UseConfig().Delay != 60 || UseConfig().DataEndpoint != "https://data" {
+		t.Error("Expected current config to be kept after invalid reload")
+	}
+}
+
+func TestConfigReloadDefaults(t *testing.T) {
+	reloadTestConfig(t)
+
+	if _, err := ConfigReload([]byte(`{"data_endpoint":"https://data","exchange_endpoint":"https://exchange"}`)); err != nil {
+		t.Fatalf("Unexpected reload error: %v", err)
+	}
+	if !UseConfig().Tray || !UseConfig().CanShowSparkline() || UseConfig().GetAlertRetries() != 3 {
+		t.Error("Expected keys missing from the file to keep their defaults")
+	}
+
+	if _, err := ConfigReload([]byte(`{"data_endpoint":"https://data","exchange_endpoint":"https://exchange","version":"1.8.1","alert_retries":0}`)); err != nil {
+		t.Fatalf("Unexpected reload error: %v", err)
+	}
+	if UseConfig().Version != "1.9.0" || UseConfig().GetAlertRetries() != 3 {
+		t.Errorf("Expected old config to be migrated, got version %s retries %d", UseConfig().Version, UseConfig().GetAlertRetries())
+	}
+}
+
+func TestPinnedKeysText(t *testing.T) {
+	text := "Pro-API.coinmarketcap.com sha256/47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=\n\n" +
+		"pro-api.coinmarketcap.com 47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=\n" +
+		"*.example.com sha256/47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="
+
+	pins, err := ParsePinnedKeys(text)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(pins["pro-api.coinmarketcap.com"]) != 2 || len(pins["*.example.com"]) != 1 {
+		t.Errorf("Unexpected pinned keys %v", pins)
+	}
+
+	formatted := FormatPinnedKeys(pins)
+	if !strings.HasPrefix(formatted, "*.example.com sha256/") || strings.Count(formatted, "\n") != 2 {
+		t.Errorf("Unexpected formatted pinned keys %q", formatted)
+	}
+
+	for _, invalid := range []string{"example.com", "example.com abc", "example.com a b"} {
+		if _, err := ParsePinnedKeys(invalid); err == nil {
+			t.Errorf("Expected %q to be rejected", invalid)
+		}
+	}
+}
@@ -46,7 +46,7 @@ func (c *cryptosLoaderType) create() *cryptosLoaderType {
 	case JC.NETWORKING_FAILED_CREATE_FILE:
 		JC.Logln("Failed to create cryptos.json with new values")
 		return nil
-	case JC.NETWORKING_BAD_DATA_RECEIVED, JC.NETWORKING_ERROR_CONNECTION, JC.NETWORKING_URL_ERROR:
+	case JC.NETWORKING_BAD_DATA_RECEIVED, JC.NETWORKING_ERROR_CONNECTION, JC.NETWORKING_URL_ERROR, JC.NETWORKING_TLS_ERROR, JC.NETWORKING_PROXY_ERROR, JC.NETWORKING_RATE_LIMIT, JC.NETWORKING_QUOTA_EXCEEDED:
 		JC.Logln("Failed to create cryptos.json due to networking error ", status)
 		return nil
 	}
@@ -159,9 +159,7 @@ func (c *cryptosLoaderType) GetCryptos(ctx context.Context, payload any) int64 {
 		ctx,
 		UseConfig().DataEndpoint,
 		func(url url.Values, req *http.Request) {
-			if UseConfig().AuthKey != JC.STRING_EMPTY {
-				req.Header.Set("Authorization", UseConfig().AuthKey)
-			}
+			UseAuthKey().SetHeader(req)
 		},
 		func(cctx context.Context, resp *http.Response) int64 {
 
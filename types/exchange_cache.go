@@ -24,6 +24,9 @@ type exchangeDataSnapshot struct {
 	TargetId     int64     `json:"target_id"`
 	TargetAmount string    `json:"target_amount"`
 	Timestamp    time.Time `json:"timestamp"`
+	Provider     string    `json:"provider,omitempty"`
+	Consensus    string    `json:"consensus,omitempty"`
+	Deviation    float64   `json:"deviation,omitempty"`
 }
 
 type exchangeDataCacheType struct {
@@ -93,6 +96,9 @@ func (ec *exchangeDataCacheType) Get(ck string) *exchangeDataType {
 			TargetId:     ex.TargetId,
 			TargetAmount: revRate,
 			Timestamp:    ex.Timestamp,
+			Provider:     ex.Provider,
+			Consensus:    ex.Consensus,
+			Deviation:    ex.Deviation,
 		}
 
 		return &rex
@@ -115,6 +121,10 @@ func (ec *exchangeDataCacheType) Insert(ex *exchangeDataType) {
 
 	ec.UseData().Store(ck, *ex)
 	ec.UpdatedAt(&ex.Timestamp)
+
+	if UseExchangeHistory() != nil {
+		UseExchangeHistory().Record(ck, ex.TargetAmount, ex.Timestamp)
+	}
 }
 
 func (ec *exchangeDataCacheType) Serialize() exchangeDataCacheSnapshot {
@@ -144,6 +154,9 @@ func (ec *exchangeDataCacheType) Serialize() exchangeDataCacheSnapshot {
 					TargetId:     ex.TargetId,
 					TargetAmount: raw,
 					Timestamp:    ex.Timestamp,
+					Provider:     ex.Provider,
+					Consensus:    ex.Consensus,
+					Deviation:    ex.Deviation,
 				})
 			}
 		}
@@ -183,6 +196,9 @@ func (ec *exchangeDataCacheType) Hydrate(snapshot exchangeDataCacheSnapshot) {
 				TargetId:     snap.TargetId,
 				TargetAmount: f,
 				Timestamp:    snap.Timestamp,
+				Provider:     snap.Provider,
+				Consensus:    snap.Consensus,
+				Deviation:    snap.Deviation,
 			}
 
 			ck := ec.CreateKeyFromExchangeData(&ex)
@@ -197,6 +213,21 @@ func (ec *exchangeDataCacheType) CreateKeyFromExchangeData(ex *exchangeDataType)
 	return ec.CreateKeyFromInt(ex.SourceId, ex.TargetId)
 }
 
+// Price of one source in the target, from the pair itself or its reverse
+func (ec *exchangeDataCacheType) GetRate(sid, tid int64) (float64, bool) {
+	if sid == tid {
+		return 1, true
+	}
+
+	ex := ec.Get(ec.CreateKeyFromInt(sid, tid))
+	if ex == nil || ex.TargetAmount == nil || ex.TargetAmount.Sign() <= 0 {
+		return 0, false
+	}
+
+	price, _ := ex.TargetAmount.Float64()
+	return price, true
+}
+
 func (ec *exchangeDataCacheType) CreateKeyFromString(sid, tid string) string {
 	return fmt.Sprintf("%s-%s", sid, tid)
 }
@@ -13,4 +13,7 @@ type exchangeDataType struct {
 	TargetId     int64
 	TargetAmount *big.Float
 	Timestamp    time.Time
+	Provider     string
+	Consensus    string
+	Deviation    float64
 }
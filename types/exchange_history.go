@@ -0,0 +1,475 @@
+package types
+
+import (
+	"bufio"
+	"fmt"
+	"math/big"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/buger/jsonparser"
+
+	JC "jxwatcher/core"
+)
+
+const exchangeHistorySegmentLayout = "2006-01-02"
+const exchangeHistorySegmentExtension = ".jsonl"
+
+const ExchangeHistoryRecentPoints = 48
+
+// Watchers measure changes over at most this window, so it is kept in memory
+const ExchangeHistoryWindow = 24 * time.Hour
+
+var exchangeHistoryStorage *exchangeHistoryType = nil
+
+type exchangeHistoryPoint struct {
+	Rate      *big.Float
+	Timestamp time.Time
+}
+
+type exchangeHistoryCandle struct {
+	Open      *big.Float
+	High      *big.Float
+	Low       *big.Float
+	Close     *big.Float
+	Count     int
+	Timestamp time.Time
+}
+
+type exchangeHistoryEntry struct {
+	ck    string
+	point exchangeHistoryPoint
+	done  chan struct{}
+}
+
+type exchangeHistoryType struct {
+	mu        sync.Mutex
+	io        sync.Mutex
+	directory string
+	lastWrite map[string]time.Time
+	lastPrune time.Time
+	recent    map[string][]exchangeHistoryPoint
+	queue     chan exchangeHistoryEntry
+}
+
+func (eh *exchangeHistoryType) Init() {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	if eh.directory == JC.STRING_EMPTY {
+		eh.directory = "history"
+	}
+
+	eh.lastWrite = make(map[string]time.Time)
+	eh.lastPrune = eh.loadLastPrune()
+
+	if eh.queue == nil {
+		eh.recent = make(map[string][]exchangeHistoryPoint)
+		eh.queue = make(chan exchangeHistoryEntry, 512)
+		go eh.worker(eh.queue)
+	}
+}
+
+// Record only queues the point, the worker appends it to disk off the insert path
+func (eh *exchangeHistoryType) Record(ck string, rate *big.Float, ts time.Time) bool {
+	if ck == JC.STRING_EMPTY || rate == nil || rate.Sign() <= 0 || rate.IsInf() || ts.IsZero() {
+		return false
+	}
+
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	if last, ok := eh.lastWrite[ck]; ok {
+		if !ts.After(last) || ts.Sub(last) < UseConfig().GetHistoryResolution() {
+			return false
+		}
+	}
+
+	select {
+	case eh.queue <- exchangeHistoryEntry{ck: ck, point: exchangeHistoryPoint{Rate: rate, Timestamp: ts}}:
+	default:
+		JC.Logln("History queue is full, dropping rate for", ck)
+		return false
+	}
+
+	eh.lastWrite[ck] = ts
+	eh.remember(ck, exchangeHistoryPoint{Rate: rate, Timestamp: ts})
+
+	return true
+}
+
+// Flush waits until every queued point is written
+func (eh *exchangeHistoryType) Flush() {
+	done := make(chan struct{})
+	eh.queue <- exchangeHistoryEntry{done: done}
+	<-done
+}
+
+func (eh *exchangeHistoryType) Destroy() {
+	eh.Flush()
+}
+
+func (eh *exchangeHistoryType) worker(queue chan exchangeHistoryEntry) {
+	eh.seed()
+
+	for entry := range queue {
+		if entry.done != nil {
+			close(entry.done)
+			continue
+		}
+
+		eh.write(entry)
+	}
+}
+
+func (eh *exchangeHistoryType) write(entry exchangeHistoryEntry) {
+	line := fmt.Sprintf("{\"timestamp\":%d,\"rate\":\"%s\"}\n", entry.point.Timestamp.UnixMilli(), entry.point.Rate.Text('g', -1))
+
+	eh.io.Lock()
+	ok := JC.AppendFileToStorage(eh.segmentPath(entry.ck, entry.point.Timestamp), line)
+	eh.io.Unlock()
+
+	if !ok {
+		JC.Logln("Failed to record history for", entry.ck)
+		return
+	}
+
+	now := time.Now()
+
+	eh.mu.Lock()
+	since := eh.lastPrune
+	due := since.IsZero() || since.UTC().Format(exchangeHistorySegmentLayout) != now.UTC().Format(exchangeHistorySegmentLayout)
+	eh.mu.Unlock()
+
+	if due {
+		eh.Prune(now)
+	}
+}
+
+func (eh *exchangeHistoryType) Range(ck string, from, to time.Time) []exchangeHistoryPoint {
+	eh.io.Lock()
+	defer eh.io.Unlock()
+
+	points := []exchangeHistoryPoint{}
+	fromDay := from.UTC().Format(exchangeHistorySegmentLayout)
+	toDay := to.UTC().Format(exchangeHistorySegmentLayout)
+
+	for _, segment := range eh.segments(ck) {
+		if segment < fromDay || segment > toDay {
+			continue
+		}
+
+		for _, point := range eh.readSegment(ck, segment) {
+			if point.Timestamp.Before(from) || point.Timestamp.After(to) {
+				continue
+			}
+			points = append(points, point)
+		}
+	}
+
+	return points
+}
+
+// Returns the oldest point in memory recorded at or after from
+func (eh *exchangeHistoryType) First(ck string, from time.Time) (exchangeHistoryPoint, bool) {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	points := eh.recent[ck]
+	i := sort.Search(len(points), func(i int) bool {
+		return !points[i].Timestamp.Before(from)
+	})
+
+	if i == len(points) {
+		return exchangeHistoryPoint{}, false
+	}
+
+	return points[i], true
+}
+
+// Returns up to n of the most recent points from memory, oldest first
+func (eh *exchangeHistoryType) Last(ck string, n int) []exchangeHistoryPoint {
+	if n <= 0 {
+		return []exchangeHistoryPoint{}
+	}
+
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	points := eh.recent[ck]
+	if len(points) > n {
+		points = points[len(points)-n:]
+	}
+
+	return slices.Clone(points)
+}
+
+// Keeps the watcher window and never less than the recent points
+func (eh *exchangeHistoryType) remember(ck string, point exchangeHistoryPoint) {
+	points := append(eh.recent[ck], point)
+
+	cutoff := point.Timestamp.Add(-ExchangeHistoryWindow)
+	stale := sort.Search(len(points), func(i int) bool {
+		return !points[i].Timestamp.Before(cutoff)
+	})
+	stale = min(stale, max(len(points)-ExchangeHistoryRecentPoints, 0))
+
+	eh.recent[ck] = points[stale:]
+}
+
+// Seeding runs once on the worker, points recorded meanwhile are kept after the stored ones
+func (eh *exchangeHistoryType) seed() {
+	eh.io.Lock()
+	stored := make(map[string][]exchangeHistoryPoint)
+	cutoff := time.Now().Add(-ExchangeHistoryWindow).UTC().Format(exchangeHistorySegmentLayout)
+
+	for _, ck := range JC.ListFilesFromStorage(eh.directory) {
+		points := []exchangeHistoryPoint{}
+		segments := eh.segments(ck)
+
+		for i := len(segments) - 1; i >= 0 && (len(points) < ExchangeHistoryRecentPoints || segments[i] >= cutoff); i-- {
+			points = append(eh.readSegment(ck, segments[i]), points...)
+		}
+
+		if len(points) != 0 {
+			stored[ck] = points
+		}
+	}
+	eh.io.Unlock()
+
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	for ck, points := range stored {
+		current := eh.recent[ck]
+		merged := []exchangeHistoryPoint{}
+
+		for _, point := range points {
+			if len(current) != 0 && !point.Timestamp.Before(current[0].Timestamp) {
+				break
+			}
+			merged = append(merged, point)
+		}
+
+		eh.recent[ck] = nil
+		for _, point := range append(merged, current...) {
+			eh.remember(ck, point)
+		}
+	}
+}
+
+func (eh *exchangeHistoryType) OHLC(ck string, from, to time.Time, bucket time.Duration) []exchangeHistoryCandle {
+	candles := []exchangeHistoryCandle{}
+	if bucket <= 0 {
+		return candles
+	}
+
+	for _, point := range eh.Range(ck, from, to) {
+		start := point.Timestamp.Truncate(bucket)
+		last := len(candles) - 1
+
+		if last < 0 || !candles[last].Timestamp.Equal(start) {
+			candles = append(candles, exchangeHistoryCandle{
+				Open:      point.Rate,
+				High:      point.Rate,
+				Low:       point.Rate,
+				Close:     point.Rate,
+				Count:     1,
+				Timestamp: start,
+			})
+			continue
+		}
+
+		candle := &candles[last]
+		if point.Rate.Cmp(candle.High) > 0 {
+			candle.High = point.Rate
+		}
+		if point.Rate.Cmp(candle.Low) < 0 {
+			candle.Low = point.Rate
+		}
+		candle.Close = point.Rate
+		candle.Count++
+	}
+
+	return candles
+}
+
+func (eh *exchangeHistoryType) Prune(now time.Time) {
+	eh.mu.Lock()
+	since := eh.lastPrune
+	eh.lastPrune = now
+	eh.mu.Unlock()
+
+	eh.io.Lock()
+	defer eh.io.Unlock()
+
+	eh.prune(since, now)
+	eh.saveLastPrune(now)
+}
+
+// Segments are only compacted once, when their age crossed the downsample threshold since the previous prune
+func (eh *exchangeHistoryType) prune(since time.Time, now time.Time) {
+	retention := UseConfig().GetHistoryRetention()
+	downsampleAfter := UseConfig().GetHistoryDownsampleAfter()
+	interval := UseConfig().GetHistoryDownsampleInterval()
+
+	today := now.UTC().Format(exchangeHistorySegmentLayout)
+
+	for _, ck := range JC.ListFilesFromStorage(eh.directory) {
+		for _, segment := range eh.segments(ck) {
+			if segment == today {
+				continue
+			}
+
+			day, err := time.Parse(exchangeHistorySegmentLayout, segment)
+			if err != nil {
+				continue
+			}
+
+			end := day.Add(24 * time.Hour)
+			age := now.Sub(end)
+
+			if retention > 0 && age > retention {
+				JC.EraseFileFromStorage(eh.segmentFile(ck, segment))
+				continue
+			}
+
+			if downsampleAfter <= 0 || interval <= 0 || age <= downsampleAfter {
+				continue
+			}
+
+			if !since.IsZero() && since.Sub(end) > downsampleAfter {
+				continue
+			}
+
+			eh.compactSegment(ck, segment, interval)
+		}
+	}
+}
+
+func (eh *exchangeHistoryType) loadLastPrune() time.Time {
+	content, ok := JC.LoadFileFromStorage(eh.directory + ".json")
+	if !ok {
+		return time.Time{}
+	}
+
+	ts, err := jsonparser.GetInt([]byte(content), "pruned")
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(ts)
+}
+
+func (eh *exchangeHistoryType) saveLastPrune(now time.Time) {
+	JC.SaveFileToStorage(eh.directory+".json", []byte(fmt.Sprintf("{\"pruned\":%d}", now.UnixMilli())))
+}
+
+func (eh *exchangeHistoryType) compactSegment(ck string, segment string, interval time.Duration) {
+	points := eh.readSegment(ck, segment)
+	kept := []exchangeHistoryPoint{}
+
+	for _, point := range points {
+		last := len(kept) - 1
+		if last >= 0 && point.Timestamp.Truncate(interval).Equal(kept[last].Timestamp.Truncate(interval)) {
+			kept[last] = point
+			continue
+		}
+		kept = append(kept, point)
+	}
+
+	if len(kept) == len(points) {
+		return
+	}
+
+	var sb strings.Builder
+	for _, point := range kept {
+		sb.WriteString(fmt.Sprintf("{\"timestamp\":%d,\"rate\":\"%s\"}\n", point.Timestamp.UnixMilli(), point.Rate.Text('g', -1)))
+	}
+
+	JC.SaveFileToStorage(eh.segmentFile(ck, segment), []byte(sb.String()))
+}
+
+func (eh *exchangeHistoryType) segments(ck string) []string {
+	segments := []string{}
+
+	for _, file := range JC.ListFilesFromStorage(path.Join(eh.directory, ck)) {
+		if !strings.HasSuffix(file, exchangeHistorySegmentExtension) {
+			continue
+		}
+		segments = append(segments, strings.TrimSuffix(file, exchangeHistorySegmentExtension))
+	}
+
+	sort.Strings(segments)
+
+	return segments
+}
+
+func (eh *exchangeHistoryType) readSegment(ck string, segment string) []exchangeHistoryPoint {
+	points := []exchangeHistoryPoint{}
+
+	content, ok := JC.LoadFileFromStorage(eh.segmentFile(ck, segment))
+	if !ok {
+		return points
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		ts, err := jsonparser.GetInt(line, "timestamp")
+		if err != nil {
+			JC.Logln("Skipping invalid history entry in", ck, segment, err)
+			continue
+		}
+
+		raw, err := jsonparser.GetString(line, "rate")
+		if err != nil {
+			JC.Logln("Skipping invalid history entry in", ck, segment, err)
+			continue
+		}
+
+		rate, ok := JC.ToBigString(raw)
+		if !ok {
+			continue
+		}
+
+		points = append(points, exchangeHistoryPoint{
+			Rate:      rate,
+			Timestamp: time.UnixMilli(ts),
+		})
+	}
+
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Timestamp.Before(points[j].Timestamp)
+	})
+
+	return points
+}
+
+func (eh *exchangeHistoryType) segmentPath(ck string, ts time.Time) string {
+	return eh.segmentFile(ck, ts.UTC().Format(exchangeHistorySegmentLayout))
+}
+
+func (eh *exchangeHistoryType) segmentFile(ck string, segment string) string {
+	return path.Join(eh.directory, ck, segment+exchangeHistorySegmentExtension)
+}
+
+func RegisterExchangeHistory() *exchangeHistoryType {
+	if exchangeHistoryStorage == nil {
+		exchangeHistoryStorage = &exchangeHistoryType{}
+		exchangeHistoryStorage.Init()
+	}
+	return exchangeHistoryStorage
+}
+
+func UseExchangeHistory() *exchangeHistoryType {
+	return exchangeHistoryStorage
+}
@@ -0,0 +1,356 @@
+package types
+
+import (
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	JC "jxwatcher/core"
+)
+
+type exchangeHistoryNullWriter struct{}
+
+func (exchangeHistoryNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func exchangeHistoryTurnOffLogs() {
+	log.SetOutput(exchangeHistoryNullWriter{})
+}
+
+func exchangeHistoryTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func newTestExchangeHistory(t *testing.T, cfg *configType) *exchangeHistoryType {
+	exchangeHistoryTurnOffLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	configMu.Lock()
+	previous := configStorage
+	configStorage = cfg
+	configMu.Unlock()
+
+	directory := "history_test_" + strings.ReplaceAll(t.Name(), "/", "_")
+	eh := &exchangeHistoryType{directory: directory}
+	eh.Init()
+	eh.lastPrune = time.Now()
+
+	t.Cleanup(func() {
+		eh.Flush()
+		os.RemoveAll(strings.TrimPrefix(JC.BuildPathRelatedToUserDirectory([]string{directory}), "file://"))
+		os.Remove(strings.TrimPrefix(JC.BuildPathRelatedToUserDirectory([]string{directory + ".json"}), "file://"))
+
+		configMu.Lock()
+		configStorage = previous
+		configMu.Unlock()
+
+		exchangeHistoryTurnOnLogs()
+	})
+
+	return eh
+}
+
+func TestExchangeHistoryRecordAndLast(t *testing.T) {
+	eh := newTestExchangeHistory(t, &configType{})
+	now := time.Now().Truncate(time.Second)
+
+	for i := range 5 {
+		if !eh.Record("1-2", JC.ToBigFloat(float64(100+i)), now.Add(time.Duration(i)*time.Minute)) {
+			t.Fatalf("Expected record %d to be stored", i)
+		}
+	}
+
+	eh.Flush()
+
+	points := eh.Last("1-2", 3)
+	if len(points) != 3 {
+		t.Fatalf("Expected 3 points, got %d", len(points))
+	}
+
+	if f, _ := points[0].Rate.Float64(); f != 102 {
+		t.Errorf("Expected first of last 3 to be 102, got %v", f)
+	}
+	if f, _ := points[2].Rate.Float64(); f != 104 {
+		t.Errorf("Expected last point to be 104, got %v", f)
+	}
+	if !points[2].Timestamp.Equal(now.Add(4 * time.Minute)) {
+		t.Errorf("Unexpected timestamp %v", points[2].Timestamp)
+	}
+
+	if len(eh.Last("9-9", 3)) != 0 {
+		t.Error("Expected no points for unknown pair")
+	}
+}
+
+func TestExchangeHistoryRecentRing(t *testing.T) {
+	eh := newTestExchangeHistory(t, &configType{})
+	now := time.Now().Truncate(time.Second)
+
+	for i := range ExchangeHistoryRecentPoints + 10 {
+		eh.Record("1-2", JC.ToBigFloat(float64(i+1)), now.Add(time.Duration(i)*time.Hour))
+	}
+
+	points := eh.Last("1-2", ExchangeHistoryRecentPoints*2)
+	if len(points) != ExchangeHistoryRecentPoints {
+		t.Fatalf("Expected ring capped at %d points, got %d", ExchangeHistoryRecentPoints, len(points))
+	}
+	if f, _ := points[0].Rate.Float64(); f != 11 {
+		t.Errorf("Expected oldest kept point to be 11, got %v", f)
+	}
+
+	eh.Flush()
+
+	reloaded := &exchangeHistoryType{directory: eh.directory}
+	reloaded.Init()
+	reloaded.lastPrune = time.Now()
+
+	reloaded.Record("1-2", JC.ToBigFloat(999), now.Add(time.Duration(ExchangeHistoryRecentPoints+10)*time.Hour))
+	reloaded.Flush()
+
+	points = reloaded.Last("1-2", 3)
+	if len(points) != 3 {
+		t.Fatalf("Expected ring seeded from disk, got %d points", len(points))
+	}
+	if f, _ := points[0].Rate.Float64(); f != 57 {
+		t.Errorf("Expected seeded points before the new record, got %v", f)
+	}
+	if f, _ := points[2].Rate.Float64(); f != 999 {
+		t.Errorf("Expected new record last, got %v", f)
+	}
+}
+
+func TestExchangeHistoryKeepsWatcherWindow(t *testing.T) {
+	eh := newTestExchangeHistory(t, &configType{})
+	now := time.Now().Truncate(time.Minute)
+	start := now.Add(-30 * time.Hour)
+
+	for i := 0; i <= 180; i++ {
+		eh.Record("1-2", JC.ToBigFloat(float64(i+1)), start.Add(time.Duration(i)*10*time.Minute))
+	}
+
+	points := eh.Last("1-2", 1000)
+	if len(points) != 145 {
+		t.Fatalf("Expected the last 24 hours kept in memory, got %d points", len(points))
+	}
+
+	point, ok := eh.First("1-2", now.Add(-15*time.Minute))
+	if f, _ := point.Rate.Float64(); !ok || f != 180 {
+		t.Errorf("Expected first point after the window start, got %v", f)
+	}
+
+	if _, ok := eh.First("1-2", now.Add(time.Minute)); ok {
+		t.Error("Expected no point after the newest one")
+	}
+}
+
+func TestExchangeHistoryRejectsInvalid(t *testing.T) {
+	eh := newTestExchangeHistory(t, &configType{})
+	now := time.Now()
+
+	if eh.Record(JC.STRING_EMPTY, JC.ToBigFloat(1), now) {
+		t.Error("Expected empty key to be rejected")
+	}
+	if eh.Record("1-2", nil, now) {
+		t.Error("Expected nil rate to be rejected")
+	}
+	if eh.Record("1-2", JC.ToBigFloat(0), now) {
+		t.Error("Expected zero rate to be rejected")
+	}
+	if eh.Record("1-2", JC.ToBigFloat(1), time.Time{}) {
+		t.Error("Expected zero timestamp to be rejected")
+	}
+}
+
+func TestExchangeHistoryResolution(t *testing.T) {
+	eh := newTestExchangeHistory(t, &configType{HistoryResolution: 60})
+	now := time.Now().Truncate(time.Second)
+
+	eh.Record("1-2", JC.ToBigFloat(1), now)
+	if eh.Record("1-2", JC.ToBigFloat(2), now.Add(30*time.Second)) {
+		t.Error("Expected point within resolution to be skipped")
+	}
+	if eh.Record("1-2", JC.ToBigFloat(3), now) {
+		t.Error("Expected duplicate timestamp to be skipped")
+	}
+	if !eh.Record("1-2", JC.ToBigFloat(4), now.Add(90*time.Second)) {
+		t.Error("Expected point after resolution to be stored")
+	}
+
+	eh.Flush()
+
+	if n := len(eh.Last("1-2", 10)); n != 2 {
+		t.Errorf("Expected 2 stored points, got %d", n)
+	}
+}
+
+func TestExchangeHistoryRangeAcrossSegments(t *testing.T) {
+	eh := newTestExchangeHistory(t, &configType{})
+	base := time.Date(2025, 1, 1, 22, 0, 0, 0, time.UTC)
+
+	for i := range 6 {
+		eh.Record("1-2", JC.ToBigFloat(float64(i+1)), base.Add(time.Duration(i)*time.Hour))
+	}
+
+	eh.Flush()
+
+	if segments := eh.segments("1-2"); len(segments) != 2 {
+		t.Fatalf("Expected 2 day segments, got %v", segments)
+	}
+
+	points := eh.Range("1-2", base.Add(time.Hour), base.Add(4*time.Hour))
+	if len(points) != 4 {
+		t.Fatalf("Expected 4 points in range, got %d", len(points))
+	}
+	if f, _ := points[0].Rate.Float64(); f != 2 {
+		t.Errorf("Expected range to start at 2, got %v", f)
+	}
+	if f, _ := points[3].Rate.Float64(); f != 5 {
+		t.Errorf("Expected range to end at 5, got %v", f)
+	}
+}
+
+func TestExchangeHistoryOHLC(t *testing.T) {
+	eh := newTestExchangeHistory(t, &configType{})
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	rates := []float64{5, 7, 3, 6, 10, 8}
+
+	for i, r := range rates {
+		eh.Record("1-2", JC.ToBigFloat(r), base.Add(time.Duration(i)*20*time.Minute))
+	}
+
+	eh.Flush()
+
+	candles := eh.OHLC("1-2", base, base.Add(3*time.Hour), time.Hour)
+	if len(candles) != 2 {
+		t.Fatalf("Expected 2 candles, got %d", len(candles))
+	}
+
+	check := func(c exchangeHistoryCandle, open, high, low, close float64, count int) {
+		o, _ := c.Open.Float64()
+		h, _ := c.High.Float64()
+		l, _ := c.Low.Float64()
+		cl, _ := c.Close.Float64()
+		if o != open || h != high || l != low || cl != close || c.Count != count {
+			t.Errorf("Unexpected candle %v/%v/%v/%v (%d), want %v/%v/%v/%v (%d)", o, h, l, cl, c.Count, open, high, low, close, count)
+		}
+	}
+
+	check(candles[0], 5, 7, 3, 3, 3)
+	check(candles[1], 6, 10, 6, 8, 3)
+
+	if !candles[1].Timestamp.Equal(base.Add(time.Hour)) {
+		t.Errorf("Expected second candle to start at %v, got %v", base.Add(time.Hour), candles[1].Timestamp)
+	}
+
+	if len(eh.OHLC("1-2", base, base.Add(time.Hour), 0)) != 0 {
+		t.Error("Expected no candles for zero bucket")
+	}
+}
+
+func TestExchangeHistoryPruneRetentionAndDownsample(t *testing.T) {
+	eh := newTestExchangeHistory(t, &configType{
+		HistoryRetention:          10,
+		HistoryDownsampleAfter:    2,
+		HistoryDownsampleInterval: 3600,
+	})
+
+	now := time.Now().UTC()
+	expired := now.Add(-20 * 24 * time.Hour).Truncate(24 * time.Hour)
+	old := now.Add(-5 * 24 * time.Hour).Truncate(24 * time.Hour)
+
+	eh.Record("1-2", JC.ToBigFloat(1), expired)
+	for i := range 4 {
+		eh.Record("1-2", JC.ToBigFloat(float64(10+i)), old.Add(time.Duration(i)*15*time.Minute))
+	}
+
+	eh.Flush()
+	eh.lastPrune = time.Time{}
+	eh.Prune(now)
+
+	segments := eh.segments("1-2")
+	if len(segments) != 1 || segments[0] != old.Format(exchangeHistorySegmentLayout) {
+		t.Fatalf("Expected only the recent segment to survive, got %v", segments)
+	}
+
+	points := eh.Range("1-2", old, old.Add(24*time.Hour))
+	if len(points) != 1 {
+		t.Fatalf("Expected downsampled segment to hold 1 point, got %d", len(points))
+	}
+	if f, _ := points[0].Rate.Float64(); f != 13 {
+		t.Errorf("Expected downsampled point to keep the latest rate 13, got %v", f)
+	}
+}
+
+func TestExchangeHistoryCompactsOnlyCrossedSegments(t *testing.T) {
+	eh := newTestExchangeHistory(t, &configType{
+		HistoryDownsampleAfter:    2,
+		HistoryDownsampleInterval: 3600,
+	})
+
+	now := time.Now().UTC()
+	crossed := now.Add(-3 * 24 * time.Hour).Truncate(24 * time.Hour)
+	compacted := now.Add(-5 * 24 * time.Hour).Truncate(24 * time.Hour)
+
+	for i := range 4 {
+		eh.Record("1-2", JC.ToBigFloat(float64(10+i)), compacted.Add(time.Duration(i)*15*time.Minute))
+	}
+	for i := range 4 {
+		eh.Record("1-2", JC.ToBigFloat(float64(20+i)), crossed.Add(time.Duration(i)*15*time.Minute))
+	}
+
+	eh.Flush()
+	eh.lastPrune = now.Add(-24 * time.Hour)
+	eh.Prune(now)
+
+	if n := len(eh.Range("1-2", crossed, crossed.Add(24*time.Hour))); n != 1 {
+		t.Errorf("Expected segment that just crossed the threshold to be compacted, got %d points", n)
+	}
+	if n := len(eh.Range("1-2", compacted, compacted.Add(24*time.Hour))); n != 4 {
+		t.Errorf("Expected segment crossed before the previous prune to be left alone, got %d points", n)
+	}
+
+	reloaded := &exchangeHistoryType{directory: eh.directory}
+	reloaded.Init()
+	defer reloaded.Flush()
+
+	if !reloaded.lastPrune.Equal(time.UnixMilli(now.UnixMilli())) {
+		t.Errorf("Expected prune time restored from disk, got %v", reloaded.lastPrune)
+	}
+}
+
+func TestExchangeCacheInsertRecordsHistory(t *testing.T) {
+	eh := newTestExchangeHistory(t, &configType{})
+
+	previous := exchangeHistoryStorage
+	exchangeHistoryStorage = eh
+	defer func() { exchangeHistoryStorage = previous }()
+
+	cache := &exchangeDataCacheType{}
+	cache.Init()
+
+	cache.Insert(&exchangeDataType{
+		SourceSymbol: "BTC",
+		SourceId:     1,
+		SourceAmount: 1,
+		TargetSymbol: "ETH",
+		TargetId:     2,
+		TargetAmount: JC.ToBigFloat(15.5),
+		Timestamp:    time.Now(),
+	})
+
+	eh.Flush()
+
+	points := eh.Last("1-2", 1)
+	if len(points) != 1 {
+		t.Fatalf("Expected inserted rate to be recorded, got %d points", len(points))
+	}
+	if f, _ := points[0].Rate.Float64(); f != 15.5 {
+		t.Errorf("Expected recorded rate 15.5, got %v", f)
+	}
+}
@@ -49,16 +49,14 @@ func (er *altSeasonFetcher) GetRate(ctx context.Context, payload any) int64 {
 	}
 
 	return JC.GetRequest(
-		ctx,
+		JC.WithLowPriority(ctx),
 		UseConfig().AltSeasonEndpoint,
 		func(url url.Values, req *http.Request) {
 			startUnix, endUnix := JC.GetMonthBounds(time.Now())
 			url.Add("start", strconv.FormatInt(startUnix, 10))
 			url.Add("end", strconv.FormatInt(endUnix, 10))
 
-			if UseConfig().AuthKey != JC.STRING_EMPTY {
-				req.Header.Set("Authorization", UseConfig().AuthKey)
-			}
+			UseAuthKey().SetHeader(req)
 		},
 		func(cctx context.Context, resp *http.Response) int64 {
 
@@ -78,6 +76,8 @@ func (er *altSeasonFetcher) GetRate(ctx context.Context, payload any) int64 {
 
 			tickerCacheStorage.Insert(TickerTypeAltcoinIndex, er.Index, er.LastUpdate)
 
+			scheduleTicker(TickerTypeAltcoinIndex, time.Time{})
+
 			return JC.NETWORKING_SUCCESS
 		})
 }
@@ -58,16 +58,14 @@ func (er *cmc100Fetcher) GetRate(ctx context.Context, payload any) int64 {
 	}
 
 	return JC.GetRequest(
-		ctx,
+		JC.WithLowPriority(ctx),
 		UseConfig().CMC100Endpoint,
 		func(url url.Values, req *http.Request) {
 			startUnix, endUnix := JC.GetMonthBounds(time.Now())
 			url.Add("start", strconv.FormatInt(startUnix, 10))
 			url.Add("end", strconv.FormatInt(endUnix, 10))
 
-			if UseConfig().AuthKey != JC.STRING_EMPTY {
-				req.Header.Set("Authorization", UseConfig().AuthKey)
-			}
+			UseAuthKey().SetHeader(req)
 		},
 		func(cctx context.Context, resp *http.Response) int64 {
 
@@ -85,8 +83,12 @@ func (er *cmc100Fetcher) GetRate(ctx context.Context, payload any) int64 {
 				return JC.NETWORKING_BAD_DATA_RECEIVED
 			}
 
-			tickerCacheStorage.Insert(TickerTypeCMC100, er.Value, er.NextUpdate)
-			tickerCacheStorage.Insert(TickerTypeCMC10024hChange, er.PercentChange, er.NextUpdate)
+			// The next update time only drives the schedule, the values themselves are current
+			now := time.Now()
+			tickerCacheStorage.Insert(TickerTypeCMC100, er.Value, now)
+			tickerCacheStorage.Insert(TickerTypeCMC10024hChange, er.PercentChange, now)
+
+			scheduleTicker(TickerTypeCMC100, er.NextUpdate)
 
 			return JC.NETWORKING_SUCCESS
 		})
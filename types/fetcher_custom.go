@@ -0,0 +1,130 @@
+package types
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/buger/jsonparser"
+
+	JC "jxwatcher/core"
+)
+
+type customTickerFetcher struct {
+	Config    customTickerConfigType
+	Value     string
+	Timestamp time.Time
+}
+
+func (er *customTickerFetcher) parseJSON(data []byte) error {
+
+	value, dataType, _, err := jsonparser.Get(data, er.Config.ValuePath...)
+	if err != nil {
+		JC.Logln("ParseJSON error: missing custom ticker value:", err)
+		return err
+	}
+
+	// Numbers are kept as is, numeric strings are accepted for apis quoting their numbers
+	raw := string(value)
+	if dataType == jsonparser.String {
+		raw, _ = jsonparser.ParseString(value)
+	}
+
+	val, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		JC.Logln("ParseJSON error: custom ticker value is not a number:", raw)
+		return err
+	}
+
+	er.Value = strconv.FormatFloat(val, 'f', -1, 64)
+	er.Timestamp = time.Now()
+
+	if len(er.Config.TimestampPath) == 0 {
+		return nil
+	}
+
+	tsValue, tsType, _, err := jsonparser.Get(data, er.Config.TimestampPath...)
+	if err != nil {
+		JC.Logln("ParseJSON error: missing custom ticker timestamp:", err)
+		return nil
+	}
+
+	tsRaw := string(tsValue)
+	if tsType == jsonparser.String {
+		tsRaw, _ = jsonparser.ParseString(tsValue)
+	}
+
+	if ts, err := strconv.ParseInt(tsRaw, 10, 64); err == nil {
+		// Millisecond timestamps are too large to be seconds for the next few thousand years
+		if ts > 1e11 {
+			er.Timestamp = time.UnixMilli(ts)
+		} else {
+			er.Timestamp = time.Unix(ts, 0)
+		}
+	} else if ts, err := time.Parse(time.RFC3339, tsRaw); err == nil {
+		er.Timestamp = ts
+	} else {
+		JC.Logln("ParseJSON error: invalid custom ticker timestamp:", tsRaw)
+	}
+
+	return nil
+}
+
+func (er *customTickerFetcher) GetRate(ctx context.Context, payload any) int64 {
+
+	if ctx != nil && ctx.Err() != nil {
+		return JC.NETWORKING_ERROR_CONNECTION
+	}
+
+	if !er.Config.IsValid() {
+		return JC.NETWORKING_BAD_CONFIG
+	}
+
+	return JC.GetRequest(
+		JC.WithLowPriority(ctx),
+		er.Config.Endpoint,
+		func(url url.Values, req *http.Request) {
+			// Keep any query written into the endpoint itself
+			for key, vals := range req.URL.Query() {
+				for _, val := range vals {
+					url.Add(key, val)
+				}
+			}
+
+			for key, val := range er.Config.Params {
+				url.Set(key, val)
+			}
+
+			for key, val := range er.Config.Headers {
+				req.Header.Set(key, val)
+			}
+		},
+		func(cctx context.Context, resp *http.Response) int64 {
+
+			if cctx != nil && cctx.Err() != nil {
+				return JC.NETWORKING_ERROR_CONNECTION
+			}
+
+			body, close, err := JC.ReadResponse(JC.ACT_TICKER_GET_CUSTOM, resp, 2)
+			defer close()
+			if err != nil {
+				return JC.NETWORKING_BAD_DATA_RECEIVED
+			}
+
+			if err := er.parseJSON(body); err != nil {
+				return JC.NETWORKING_BAD_DATA_RECEIVED
+			}
+
+			tickerCacheStorage.Insert(er.Config.GetType(), er.Value, er.Timestamp)
+
+			scheduleTicker(er.Config.GetType(), time.Time{})
+
+			return JC.NETWORKING_SUCCESS
+		})
+}
+
+func NewCustomTickerFetcher(config customTickerConfigType) *customTickerFetcher {
+	return &customTickerFetcher{Config: config}
+}
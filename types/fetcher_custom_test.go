@@ -0,0 +1,166 @@
+package types
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	JC "jxwatcher/core"
+)
+
+type customFetcherNullWriter struct{}
+
+func (customFetcherNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func customFetcherTurnOffLogs() {
+	log.SetOutput(customFetcherNullWriter{})
+}
+
+func customFetcherTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func TestCustomFetcherParseJSON(t *testing.T) {
+	customFetcherTurnOffLogs()
+	defer customFetcherTurnOnLogs()
+
+	raw := []byte(`{
+		"result": {
+			"fast": 12.50,
+			"quoted": "3.25",
+			"list": [{"v": 7}, {"v": 8}],
+			"text": "n/a",
+			"seconds": 1759785908,
+			"millis": 1759785908123,
+			"rfc": "2025-10-06T21:25:08Z"
+		}
+	}`)
+
+	values := []struct {
+		path     []string
+		expected string
+	}{
+		{[]string{"result", "fast"}, "12.5"},
+		{[]string{"result", "quoted"}, "3.25"},
+		{[]string{"result", "list", "[1]", "v"}, "8"},
+	}
+
+	for _, tt := range values {
+		fetcher := NewCustomTickerFetcher(customTickerConfigType{ValuePath: tt.path})
+		if err := fetcher.parseJSON(raw); err != nil {
+			t.Errorf("Unexpected error for %v: %v", tt.path, err)
+			continue
+		}
+		if fetcher.Value != tt.expected {
+			t.Errorf("Expected %s for %v, got %s", tt.expected, tt.path, fetcher.Value)
+		}
+	}
+
+	for _, path := range [][]string{{"result", "missing"}, {"result", "text"}} {
+		fetcher := NewCustomTickerFetcher(customTickerConfigType{ValuePath: path})
+		if err := fetcher.parseJSON(raw); err == nil {
+			t.Errorf("Expected error for %v", path)
+		}
+	}
+
+	timestamps := []struct {
+		path     []string
+		expected time.Time
+	}{
+		{[]string{"result", "seconds"}, time.Unix(1759785908, 0)},
+		{[]string{"result", "millis"}, time.UnixMilli(1759785908123)},
+		{[]string{"result", "rfc"}, time.Date(2025, 10, 6, 21, 25, 8, 0, time.UTC)},
+	}
+
+	for _, tt := range timestamps {
+		fetcher := NewCustomTickerFetcher(customTickerConfigType{
+			ValuePath:     []string{"result", "fast"},
+			TimestampPath: tt.path,
+		})
+		if err := fetcher.parseJSON(raw); err != nil {
+			t.Errorf("Unexpected error for %v: %v", tt.path, err)
+			continue
+		}
+		if !fetcher.Timestamp.Equal(tt.expected) {
+			t.Errorf("Expected timestamp %v for %v, got %v", tt.expected, tt.path, fetcher.Timestamp)
+		}
+	}
+
+	before := time.Now()
+	fetcher := NewCustomTickerFetcher(customTickerConfigType{
+		ValuePath:     []string{"result", "fast"},
+		TimestampPath: []string{"result", "text"},
+	})
+	if err := fetcher.parseJSON(raw); err != nil {
+		t.Errorf("Expected invalid timestamp not to fail parsing, got %v", err)
+	}
+	if fetcher.Timestamp.Before(before) {
+		t.Error("Expected invalid timestamp to fall back to now")
+	}
+}
+
+func TestCustomFetcherGetRate(t *testing.T) {
+	customFetcherTurnOffLogs()
+	defer customFetcherTurnOnLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	RegisterTickerCache().Init()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("chain") != "1" || r.URL.Query().Get("unit") != "gwei" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"result": {"fast": 21.5}}`))
+	}))
+	defer server.Close()
+
+	config := customTickerConfigType{
+		ID:        "fetch_test",
+		Endpoint:  server.URL + "/gas?chain=1",
+		Params:    map[string]string{"unit": "gwei"},
+		Headers:   map[string]string{"X-Api-Key": "secret"},
+		ValuePath: []string{"result", "fast"},
+		Interval:  60,
+	}
+
+	previous := tickerScheduleStorage
+	tickerScheduleStorage = &tickerScheduleType{}
+	tickerScheduleStorage.Init()
+	defer func() { tickerScheduleStorage = previous }()
+
+	if code := NewCustomTickerFetcher(config).GetRate(context.Background(), config.GetType()); code != JC.NETWORKING_SUCCESS {
+		t.Fatalf("Expected success, got %d", code)
+	}
+
+	if got := UseTickerCache().Get(config.GetType()); got != "21.5" {
+		t.Errorf("Expected cached value 21.5, got %s", got)
+	}
+
+	if UseTickerSchedule().IsDue(config.GetType()) {
+		t.Error("Expected ticker not to be due right after fetching")
+	}
+
+	config.Headers = nil
+	if code := NewCustomTickerFetcher(config).GetRate(context.Background(), config.GetType()); code == JC.NETWORKING_SUCCESS {
+		t.Error("Expected missing header to fail")
+	}
+
+	config.Endpoint = "not a url"
+	if code := NewCustomTickerFetcher(config).GetRate(context.Background(), config.GetType()); code != JC.NETWORKING_BAD_CONFIG {
+		t.Errorf("Expected bad config, got %d", code)
+	}
+}
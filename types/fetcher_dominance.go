@@ -67,12 +67,10 @@ func (df *dominanceFetcher) GetRate(ctx context.Context, payload any) int64 {
 	}
 
 	return JC.GetRequest(
-		ctx,
+		JC.WithLowPriority(ctx),
 		UseConfig().DominanceEndpoint,
 		func(url url.Values, req *http.Request) {
-			if UseConfig().AuthKey != JC.STRING_EMPTY {
-				req.Header.Set("Authorization", UseConfig().AuthKey)
-			}
+			UseAuthKey().SetHeader(req)
 		},
 		func(cctx context.Context, resp *http.Response) int64 {
 
@@ -94,6 +92,8 @@ func (df *dominanceFetcher) GetRate(ctx context.Context, payload any) int64 {
 			tickerCacheStorage.Insert(TickerTypeETCDominance, df.DominanceETC, df.LastUpdate)
 			tickerCacheStorage.Insert(TickerTypeOtherDominance, df.DominanceOther, df.LastUpdate)
 
+			scheduleTicker(TickerTypeDominance, time.Time{})
+
 			return JC.NETWORKING_SUCCESS
 		})
 }
@@ -69,15 +69,13 @@ func (ef *etfFetcher) GetRate(ctx context.Context, payload any) int64 {
 	}
 
 	return JC.GetRequest(
-		ctx,
+		JC.WithLowPriority(ctx),
 		UseConfig().ETFEndpoint,
 		func(url url.Values, req *http.Request) {
 			url.Add("category", "all")
 			url.Add("range", "30d")
 
-			if UseConfig().AuthKey != JC.STRING_EMPTY {
-				req.Header.Set("Authorization", UseConfig().AuthKey)
-			}
+			UseAuthKey().SetHeader(req)
 		},
 		func(cctx context.Context, resp *http.Response) int64 {
 
@@ -97,7 +95,9 @@ func (ef *etfFetcher) GetRate(ctx context.Context, payload any) int64 {
 
 			tickerCacheStorage.Insert(TickerTypeETF, ef.Total, ef.LastUpdate)
 			tickerCacheStorage.Insert(TickerTypeETFBTC, ef.TotalBtcValue, ef.LastUpdate)
-			tickerCacheStorage.Insert(TickerTypeETF, ef.TotalEthValue, ef.LastUpdate)
+			tickerCacheStorage.Insert(TickerTypeETFETH, ef.TotalEthValue, ef.LastUpdate)
+
+			scheduleTicker(TickerTypeETF, time.Time{})
 
 			return JC.NETWORKING_SUCCESS
 		})
@@ -2,7 +2,7 @@ package types
 
 import (
 	"context"
-	"math/big"
+	"math"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -82,9 +82,10 @@ func (er *exchangeResults) parseJSON(data []byte) error {
 
 func (er *exchangeResults) GetRate(ctx context.Context, rk string) int64 {
 
+	// Format: "sourceId|targetId,targetId[|provider]"
 	rko := strings.Split(rk, JC.STRING_PIPE)
 
-	if len(rko) != 2 {
+	if len(rko) != 2 && len(rko) != 3 {
 		return JC.NETWORKING_BAD_PAYLOAD
 	}
 
@@ -113,61 +114,216 @@ func (er *exchangeResults) GetRate(ctx context.Context, rk string) int64 {
 		return JC.NETWORKING_ERROR_CONNECTION
 	}
 
-	return JC.GetRequest(
-		ctx,
-		UseConfig().ExchangeEndpoint,
-		func(url url.Values, req *http.Request) {
-			url.Add("amount", "1")
-			url.Add("id", sid)
-			url.Add("convert_id", tid)
+	providers := RegisterRateProviders()
 
-			if UseConfig().AuthKey != JC.STRING_EMPTY {
-				req.Header.Set("Authorization", UseConfig().AuthKey)
-			}
-		},
-		func(cctx context.Context, resp *http.Response) int64 {
+	name := JC.STRING_EMPTY
+	if len(rko) == 3 {
+		name = strings.TrimSpace(rko[2])
+	}
 
-			if cctx != nil && cctx.Err() != nil {
-				return JC.NETWORKING_ERROR_CONNECTION
-			}
+	provider := providers.Get(name)
 
-			body, close, err := JC.ReadResponse(JC.ACT_EXCHANGE_GET_RATES, resp, 2)
-			defer close()
-			if err != nil {
-				return JC.NETWORKING_BAD_DATA_RECEIVED
-			}
+	source, targets, code := er.newAssets(providers, provider, sid, rkt)
+	if code != JC.NETWORKING_SUCCESS {
+		return code
+	}
 
-			if err := er.parseJSON(body); err != nil {
-				return JC.NETWORKING_BAD_DATA_RECEIVED
-			}
+	code = er.fetchRates(ctx, provider, source, targets)
+	if code != JC.NETWORKING_SUCCESS {
+		return code
+	}
+
+	if UseConfig().CanDoRateConsensus() {
+		er.checkConsensus(ctx, providers, provider, sid, rkt)
+	}
+
+	for _, ex := range er.Rates {
+
+		// Debug to force display refresh!
+		// factor := new(big.Float).SetFloat64(rand.Float64() * 5)
+		// ex.TargetAmount = new(big.Float).Mul(ex.TargetAmount, factor)
+
+		// JC.Logf("Rates received: 1 %s (ID %d) = %s %s (ID %d)", ex.SourceSymbol, ex.SourceId, ex.TargetAmount.Text('f', -1), ex.TargetSymbol, ex.TargetId)
+
+		UseExchangeCache().Insert(&ex)
+
+		rex := exchangeDataType{
+			SourceSymbol: ex.TargetSymbol,
+			SourceId:     ex.TargetId,
+			SourceAmount: 1,
+			TargetSymbol: ex.SourceSymbol,
+			TargetId:     ex.SourceId,
+			TargetAmount: invertRate(ex.TargetAmount),
+			Timestamp:    ex.Timestamp,
+			Provider:     ex.Provider,
+			Consensus:    ex.Consensus,
+			Deviation:    ex.Deviation,
+		}
+
+		UseExchangeCache().Insert(&rex)
+	}
+
+	return JC.NETWORKING_SUCCESS
+}
+
+func (er *exchangeResults) newAssets(providers *rateProvidersType, provider RateProvider, sid string, tids []string) (rateAssetType, []rateAssetType, int64) {
+	source, err := providers.NewAsset(provider, sid)
+	if err != nil {
+		return source, nil, JC.NETWORKING_BAD_PAYLOAD
+	}
+
+	targets := make([]rateAssetType, 0, len(tids))
+	for _, id := range tids {
+		target, err := providers.NewAsset(provider, id)
+		if err != nil {
+			return source, nil, JC.NETWORKING_BAD_PAYLOAD
+		}
+		targets = append(targets, target)
+	}
+
+	return source, targets, JC.NETWORKING_SUCCESS
+}
+
+// Tries every endpoint of the provider in priority order, moving on when one is unreachable, throttled or returns garbage
+func (er *exchangeResults) fetchRates(ctx context.Context, provider RateProvider, source rateAssetType, targets []rateAssetType) int64 {
+
+	er.Rates = nil
+
+	endpoints := provider.GetEndpoints()
+	if len(endpoints) == 0 {
+		return JC.NETWORKING_BAD_CONFIG
+	}
+
+	if resolver, ok := provider.(RatePairResolver); ok {
+		if code := resolver.ResolvePairs(ctx); code != JC.NETWORKING_SUCCESS {
+			return code
+		}
+	}
+
+	code := int64(JC.NETWORKING_BAD_CONFIG)
 
-			for _, ex := range er.Rates {
+	for i, endpoint := range endpoints {
+		label := rateSourceLabel(provider, endpoint, i)
 
-				// Debug to force display refresh!
-				// factor := new(big.Float).SetFloat64(rand.Float64() * 5)
-				// ex.TargetAmount = new(big.Float).Mul(ex.TargetAmount, factor)
+		code = JC.GetRequest(
+			ctx,
+			endpoint,
+			func(url url.Values, req *http.Request) {
+				provider.Prepare(url, req, source, targets)
+			},
+			func(cctx context.Context, resp *http.Response) int64 {
 
-				// JC.Logf("Rates received: 1 %s (ID %d) = %s %s (ID %d)", ex.SourceSymbol, ex.SourceId, ex.TargetAmount.Text('f', -1), ex.TargetSymbol, ex.TargetId)
+				if cctx != nil && cctx.Err() != nil {
+					return JC.NETWORKING_ERROR_CONNECTION
+				}
+
+				body, close, err := JC.ReadResponse(JC.ACT_EXCHANGE_GET_RATES, resp, 2)
+				defer close()
+				if err != nil {
+					return JC.NETWORKING_BAD_DATA_RECEIVED
+				}
 
-				UseExchangeCache().Insert(&ex)
+				rates, err := provider.Parse(body, source, targets)
+				if err != nil {
+					JC.Logln("Failed to parse rates from", label, err)
+					return JC.NETWORKING_BAD_DATA_RECEIVED
+				}
 
-				one := new(big.Float).SetPrec(256).SetFloat64(1)
-				revRate := new(big.Float).SetPrec(256).Quo(one, ex.TargetAmount)
-				rex := exchangeDataType{
-					SourceSymbol: ex.TargetSymbol,
-					SourceId:     ex.TargetId,
-					SourceAmount: 1,
-					TargetSymbol: ex.SourceSymbol,
-					TargetId:     ex.TargetId,
-					TargetAmount: revRate,
-					Timestamp:    ex.Timestamp,
+				for i := range rates {
+					rates[i].Provider = label
 				}
 
-				UseExchangeCache().Insert(&rex)
+				er.Rates = rates
+
+				return JC.NETWORKING_SUCCESS
+			})
+
+		if !isRateFailoverCode(code) {
+			break
+		}
+
+		if ctx != nil && ctx.Err() != nil {
+			break
+		}
+
+		if i+1 < len(endpoints) {
+			JC.Logln("Rate endpoint failed, switching to next endpoint:", label, code)
+		}
+	}
+
+	return code
+}
+
+// Fetches the same pairs from the consensus provider and records how far apart both answers are
+func (er *exchangeResults) checkConsensus(ctx context.Context, providers *rateProvidersType, provider RateProvider, sid string, tids []string) {
+
+	name := UseConfig().GetRateConsensus()
+	if !providers.Has(name) || name == provider.GetName() {
+		return
+	}
+
+	consensus := providers.Get(name)
+
+	source, targets, code := er.newAssets(providers, consensus, sid, tids)
+	if code != JC.NETWORKING_SUCCESS {
+		return
+	}
+
+	cr := NewExchangeResults()
+	if cr.fetchRates(ctx, consensus, source, targets) != JC.NETWORKING_SUCCESS {
+		JC.Logln("Unable to check rates consensus with", name)
+		return
+	}
+
+	er.applyConsensus(cr.Rates, name)
+}
+
+func (er *exchangeResults) applyConsensus(rates []exchangeDataType, name string) {
+	for i := range er.Rates {
+		ex := &er.Rates[i]
+
+		for _, cex := range rates {
+			if cex.SourceId != ex.SourceId || cex.TargetId != ex.TargetId {
+				continue
+			}
+
+			a, _ := ex.TargetAmount.Float64()
+			b, _ := cex.TargetAmount.Float64()
+			if b == 0 {
+				break
 			}
 
-			return JC.NETWORKING_SUCCESS
-		})
+			ex.Consensus = name
+			ex.Deviation = math.Abs(a-b) / b * 100
+
+			if ex.Deviation > UseConfig().GetRateConsensusTolerance() {
+				JC.Logf("Rates disagree for %s/%s: %s %v, %s %v (%.2f%%)", ex.SourceSymbol, ex.TargetSymbol, ex.Provider, a, name, b, ex.Deviation)
+			}
+
+			break
+		}
+	}
+}
+
+func isRateFailoverCode(code int64) bool {
+	switch code {
+	case JC.NETWORKING_RATE_LIMIT, JC.NETWORKING_ERROR_CONNECTION, JC.NETWORKING_BAD_DATA_RECEIVED:
+		return true
+	}
+
+	return false
+}
+
+func rateSourceLabel(provider RateProvider, endpoint string, index int) string {
+	if index == 0 {
+		return provider.GetName()
+	}
+
+	if parsed, err := url.Parse(endpoint); err == nil && parsed.Host != JC.STRING_EMPTY {
+		return provider.GetName() + "@" + parsed.Host
+	}
+
+	return provider.GetName()
 }
 
 func NewExchangeResults() *exchangeResults {
@@ -1,11 +1,19 @@
 package types
 
 import (
+	"context"
 	"log"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"fyne.io/fyne/v2/test"
+
+	JC "jxwatcher/core"
 )
 
 type exchangeResultsNullWriter struct{}
@@ -86,3 +94,123 @@ func TestExchangeResultsParseJSONInvalid(t *testing.T) {
 	}
 	exchangeResultsTurnOnLogs()
 }
+
+func TestExchangeResultsFetchRatesFailover(t *testing.T) {
+	exchangeResultsTurnOffLogs()
+	defer exchangeResultsTurnOnLogs()
+
+	fixture, err := os.ReadFile(filepath.Join("testdata", "rates_coinmarketcap.json"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	primaryHits := 0
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryHits++
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/garbage":
+			w.Write([]byte(`{"status":{"error_code":"500"}}`))
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer primary.Close()
+
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") != "1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write(fixture)
+	}))
+	defer mirror.Close()
+
+	cfg := UseConfig()
+	oldPrimary, oldSecondary := cfg.ExchangeEndpoint, cfg.ExchangeEndpointSecondary
+	defer func() {
+		cfg.ExchangeEndpoint, cfg.ExchangeEndpointSecondary = oldPrimary, oldSecondary
+	}()
+
+	cfg.ExchangeEndpointSecondary = mirror.URL
+
+	provider := NewCMCRateProvider()
+	source := rateAssetType{Id: 1, Symbol: "BTC", Code: "BTC"}
+	targets := []rateAssetType{{Id: 825, Symbol: "USDT", Code: "USDT"}, {Id: 1027, Symbol: "ETH", Code: "ETH"}}
+
+	for _, path := range []string{"/limited", "/garbage"} {
+		cfg.ExchangeEndpoint = primary.URL + path
+
+		er := NewExchangeResults()
+		if code := er.fetchRates(context.Background(), provider, source, targets); code != JC.NETWORKING_SUCCESS {
+			t.Fatalf("Expected failover to succeed for %s, got %d", path, code)
+		}
+
+		if len(er.Rates) != 2 {
+			t.Fatalf("Expected 2 rates from the mirror, got %d", len(er.Rates))
+		}
+
+		host := strings.TrimPrefix(mirror.URL, "http://")
+		if er.Rates[0].Provider != JC.RATE_PROVIDER_CMC+"@"+host {
+			t.Errorf("Expected rates to be labelled with the mirror, got %q", er.Rates[0].Provider)
+		}
+	}
+
+	cfg.ExchangeEndpoint = primary.URL + "/missing"
+	er := NewExchangeResults()
+	if code := er.fetchRates(context.Background(), provider, source, targets); code != JC.NETWORKING_BAD_CONFIG {
+		t.Errorf("Expected config errors not to fail over, got %d", code)
+	}
+	if len(er.Rates) != 0 {
+		t.Error("Expected no rates when the primary endpoint is misconfigured")
+	}
+
+	cfg.ExchangeEndpoint = mirror.URL
+	cfg.ExchangeEndpointSecondary = JC.STRING_EMPTY
+	hits := primaryHits
+	er = NewExchangeResults()
+	if code := er.fetchRates(context.Background(), provider, source, targets); code != JC.NETWORKING_SUCCESS {
+		t.Fatalf("Expected primary endpoint to succeed, got %d", code)
+	}
+	if er.Rates[0].Provider != JC.RATE_PROVIDER_CMC {
+		t.Errorf("Expected primary rates to be labelled with the provider, got %q", er.Rates[0].Provider)
+	}
+	if primaryHits != hits {
+		t.Error("Expected no request to other endpoints after a success")
+	}
+}
+
+func TestExchangeResultsApplyConsensus(t *testing.T) {
+	exchangeResultsTurnOffLogs()
+	defer exchangeResultsTurnOnLogs()
+
+	cfg := UseConfig()
+	oldTolerance := cfg.RateConsensusTolerance
+	defer func() {
+		cfg.RateConsensusTolerance = oldTolerance
+	}()
+	cfg.RateConsensusTolerance = 1
+
+	er := NewExchangeResults()
+	er.Rates = []exchangeDataType{
+		{SourceId: 1, TargetId: 825, TargetAmount: JC.ToBigFloat(101), Provider: JC.RATE_PROVIDER_CMC},
+		{SourceId: 1, TargetId: 1027, TargetAmount: JC.ToBigFloat(30), Provider: JC.RATE_PROVIDER_CMC},
+		{SourceId: 1, TargetId: 5604, TargetAmount: JC.ToBigFloat(5), Provider: JC.RATE_PROVIDER_CMC},
+	}
+
+	er.applyConsensus([]exchangeDataType{
+		{SourceId: 1, TargetId: 825, TargetAmount: JC.ToBigFloat(100)},
+		{SourceId: 1, TargetId: 1027, TargetAmount: JC.ToBigFloat(25)},
+	}, JC.RATE_PROVIDER_BINANCE)
+
+	if er.Rates[0].Consensus != JC.RATE_PROVIDER_BINANCE || math.Abs(er.Rates[0].Deviation-1) > 1e-9 {
+		t.Errorf("Unexpected consensus result %+v", er.Rates[0])
+	}
+	if math.Abs(er.Rates[1].Deviation-20) > 1e-9 {
+		t.Errorf("Expected 20%% deviation, got %v", er.Rates[1].Deviation)
+	}
+	if er.Rates[2].Consensus != JC.STRING_EMPTY {
+		t.Error("Expected pairs missing from the consensus provider to stay unchecked")
+	}
+}
@@ -50,16 +50,14 @@ func (fg *fearGreedFetcher) GetRate(ctx context.Context, payload any) int64 {
 	}
 
 	return JC.GetRequest(
-		ctx,
+		JC.WithLowPriority(ctx),
 		UseConfig().FearGreedEndpoint,
 		func(url url.Values, req *http.Request) {
 			startUnix, endUnix := JC.GetMonthBounds(time.Now())
 			url.Add("start", strconv.FormatInt(startUnix, 10))
 			url.Add("end", strconv.FormatInt(endUnix, 10))
 
-			if UseConfig().AuthKey != JC.STRING_EMPTY {
-				req.Header.Set("Authorization", UseConfig().AuthKey)
-			}
+			UseAuthKey().SetHeader(req)
 		},
 		func(cctx context.Context, resp *http.Response) int64 {
 
@@ -79,6 +77,8 @@ func (fg *fearGreedFetcher) GetRate(ctx context.Context, payload any) int64 {
 
 			tickerCacheStorage.Insert(TickerTypeFearGreed, fg.Score, fg.LastUpdate)
 
+			scheduleTicker(TickerTypeFearGreed, time.Time{})
+
 			return JC.NETWORKING_SUCCESS
 		})
 }
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	JC "jxwatcher/core"
+)
+
+type fiatRateFetcher struct{}
+
+// Fetches the USD to display fiat rate, fiat ids are only known to the coinmarketcap exchange endpoint
+func (ff *fiatRateFetcher) GetRate(ctx context.Context, payload any) int64 {
+	if ctx != nil && ctx.Err() != nil {
+		return JC.NETWORKING_ERROR_CONNECTION
+	}
+
+	fiat := UseConfig().GetDisplayFiat()
+	if fiat.IsUSD() {
+		scheduleTicker(TickerTypeFiatRate, time.Time{})
+		return JC.NETWORKING_SUCCESS
+	}
+
+	rk := fmt.Sprintf("%d|%d|%s", JC.FIAT_USD_ID, fiat.Id, JC.RATE_PROVIDER_CMC)
+
+	code := NewExchangeResults().GetRate(ctx, rk)
+	if code == JC.NETWORKING_SUCCESS {
+		scheduleTicker(TickerTypeFiatRate, time.Time{})
+	}
+
+	return code
+}
+
+// Rate to multiply USD values with, false when the display fiat is USD or its rate is not fetched yet
+func GetFiatRate(fiat JC.FiatType) (float64, bool) {
+	if fiat.IsUSD() || UseExchangeCache() == nil {
+		return 1, false
+	}
+
+	ex := UseExchangeCache().Get(UseExchangeCache().CreateKeyFromInt(JC.FIAT_USD_ID, fiat.Id))
+	if ex == nil || ex.TargetAmount == nil {
+		return 1, false
+	}
+
+	rate, _ := ex.TargetAmount.Float64()
+	if rate <= 0 {
+		return 1, false
+	}
+
+	return rate, true
+}
+
+func NewFiatRateFetcher() *fiatRateFetcher {
+	return &fiatRateFetcher{}
+}
@@ -0,0 +1,114 @@
+package types
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+
+	JC "jxwatcher/core"
+)
+
+type fiatFetcherNullWriter struct{}
+
+func (fiatFetcherNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func fiatFetcherTurnOffLogs() {
+	log.SetOutput(fiatFetcherNullWriter{})
+}
+
+func fiatFetcherTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func TestFiatRateFetcherGetRate(t *testing.T) {
+	fiatFetcherTurnOffLogs()
+	defer fiatFetcherTurnOnLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") != "2781" || r.URL.Query().Get("convert_id") != "2790" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{
+			"data": {
+				"id": "2781", "symbol": "USD", "amount": 1, "last_updated": "2025-09-29T03:00:00.000Z",
+				"quote": [{"cryptoId": 2790, "symbol": "EUR", "price": 0.9, "lastUpdated": "2025-09-29T03:00:00.000Z"}]
+			},
+			"status": {"error_code": "0"}
+		}`))
+	}))
+	defer server.Close()
+
+	useCustomTickerConfig(t, &configType{Delay: 60, ExchangeEndpoint: server.URL, DisplayFiat: "eur"})
+
+	previousCache := exchangeCacheStorage
+	exchangeCacheStorage = &exchangeDataCacheType{}
+	exchangeCacheStorage.Init()
+
+	previousSchedule := tickerScheduleStorage
+	tickerScheduleStorage = &tickerScheduleType{}
+	tickerScheduleStorage.Init()
+
+	defer func() {
+		exchangeCacheStorage = previousCache
+		tickerScheduleStorage = previousSchedule
+	}()
+
+	td := NewTickerData()
+	td.Init()
+	td.SetType(TickerTypeMarketCap)
+	td.SetFormat(TickerFormatShortCurrency)
+	td.Set("2000000000000")
+
+	if got := td.FormatContent(); got != "$2.00T" {
+		t.Errorf("Expected USD until the fiat rate is fetched, got %s", got)
+	}
+
+	if code := NewFiatRateFetcher().GetRate(context.Background(), TickerTypeFiatRate); code != JC.NETWORKING_SUCCESS {
+		t.Fatalf("Expected success, got %d", code)
+	}
+
+	if UseTickerSchedule().IsDue(TickerTypeFiatRate) {
+		t.Error("Expected fiat rate not to be due right after fetching")
+	}
+
+	rate, ok := GetFiatRate(UseConfig().GetDisplayFiat())
+	if !ok || rate != 0.9 {
+		t.Fatalf("Expected EUR rate 0.9, got %v %v", rate, ok)
+	}
+
+	if got := td.FormatContent(); got != "€1.80T" {
+		t.Errorf("Expected market cap in EUR, got %s", got)
+	}
+
+	td.SetFormat(TickerFormatShortCurrencyWithSign)
+	td.Set("-1000000")
+	if got := td.FormatContent(); got != "-€900.00K" {
+		t.Errorf("Expected signed flow in EUR, got %s", got)
+	}
+
+	td.SetFormat(TickerFormatCurrency)
+	td.Set("1000")
+	if got := td.FormatContent(); got != "€900" {
+		t.Errorf("Expected index value in EUR, got %s", got)
+	}
+
+	UseConfig().DisplayFiat = "JPY"
+	if got := td.FormatContent(); got != "$1,000" {
+		t.Errorf("Expected USD when the fiat rate is unknown, got %s", got)
+	}
+
+	UseConfig().DisplayFiat = JC.FIAT_USD
+	if code := NewFiatRateFetcher().GetRate(context.Background(), TickerTypeFiatRate); code != JC.NETWORKING_SUCCESS {
+		t.Errorf("Expected USD not to need a fetch, got %d", code)
+	}
+}
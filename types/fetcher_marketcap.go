@@ -69,15 +69,13 @@ func (mc *marketCapFetcher) GetRate(ctx context.Context, payload any) int64 {
 	}
 
 	return JC.GetRequest(
-		ctx,
+		JC.WithLowPriority(ctx),
 		UseConfig().MarketCapEndpoint,
 		func(url url.Values, req *http.Request) {
 			url.Add("convertId", "2781")
 			url.Add("range", "30d")
 
-			if UseConfig().AuthKey != JC.STRING_EMPTY {
-				req.Header.Set("Authorization", UseConfig().AuthKey)
-			}
+			UseAuthKey().SetHeader(req)
 		},
 		func(cctx context.Context, resp *http.Response) int64 {
 
@@ -105,6 +103,8 @@ func (mc *marketCapFetcher) GetRate(ctx context.Context, payload any) int64 {
 			tickerCacheStorage.Insert(TickerTypeCMC10030dChange, mc.ThirtyDaysChangePct, mc.LastUpdate)
 			tickerCacheStorage.Insert(TickerTypeMarketCap24hChange, dif, mc.LastUpdate)
 
+			scheduleTicker(TickerTypeMarketCap, time.Time{})
+
 			return JC.NETWORKING_SUCCESS
 		})
 }
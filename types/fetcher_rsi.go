@@ -78,7 +78,7 @@ func (rf *rsiFetcher) GetRate(ctx context.Context, payload any) int64 {
 	}
 
 	return JC.GetRequest(
-		ctx,
+		JC.WithLowPriority(ctx),
 		UseConfig().RSIEndpoint,
 		func(url url.Values, req *http.Request) {
 			url.Add("timeframe", "4h")
@@ -86,9 +86,7 @@ func (rf *rsiFetcher) GetRate(ctx context.Context, payload any) int64 {
 			url.Add("volume24Range.min", "1000000")
 			url.Add("marketCapRange.min", "50000000")
 
-			if UseConfig().AuthKey != JC.STRING_EMPTY {
-				req.Header.Set("Authorization", UseConfig().AuthKey)
-			}
+			UseAuthKey().SetHeader(req)
 		},
 		func(cctx context.Context, resp *http.Response) int64 {
 
@@ -116,6 +114,8 @@ func (rf *rsiFetcher) GetRate(ctx context.Context, payload any) int64 {
 			tickerCacheStorage.Insert(TickerTypeRSIOverbought, rf.OverboughtPercentage, rf.LastUpdate)
 			tickerCacheStorage.Insert(TickerTypeRSINeutral, rf.NeutralPercentage, rf.LastUpdate)
 
+			scheduleTicker(TickerTypeRSI, time.Time{})
+
 			return JC.NETWORKING_SUCCESS
 		})
 }
@@ -0,0 +1,211 @@
+package types
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/buger/jsonparser"
+
+	JC "jxwatcher/core"
+)
+
+const ledgerDateLayout = "2006-01-02"
+
+var ledgerStorage *ledgerType = nil
+
+type ledgerEntryType struct {
+	Date        string  `json:"date"`
+	Type        string  `json:"type"`
+	Coin        int64   `json:"coin"`
+	CoinSymbol  string  `json:"coin_symbol"`
+	Quantity    float64 `json:"quantity"`
+	Price       float64 `json:"price"`
+	Quote       int64   `json:"quote"`
+	QuoteSymbol string  `json:"quote_symbol"`
+	Fee         float64 `json:"fee"`
+	Note        string  `json:"note,omitempty"`
+}
+
+type ledgerType struct {
+	mu       sync.RWMutex
+	filename string
+	entries  []ledgerEntryType
+}
+
+func (e *ledgerEntryType) IsValid() bool {
+	switch e.Type {
+	case JC.LEDGER_BUY, JC.LEDGER_SELL, JC.LEDGER_TRANSFER_IN, JC.LEDGER_TRANSFER_OUT:
+	default:
+		return false
+	}
+
+	if _, err := time.ParseInLocation(ledgerDateLayout, e.Date, time.Local); err != nil {
+		return false
+	}
+
+	return e.Coin > 0 && e.Quote > 0 && e.Coin != e.Quote && e.Quantity > 0 && e.Price >= 0 && e.Fee >= 0
+}
+
+func (e *ledgerEntryType) GetTime() time.Time {
+	ts, _ := time.ParseInLocation(ledgerDateLayout, e.Date, time.Local)
+	return ts
+}
+
+func (e *ledgerEntryType) Format() string {
+	text := e.Date + fmtSpace + strings.ReplaceAll(e.Type, "_", fmtSpace) + fmtSpace +
+		JC.FormatNumberWithCommas(e.Quantity, 8) + fmtSpace + e.CoinSymbol
+
+	if e.Price > 0 {
+		text += " @ " + JC.FormatNumberWithCommas(e.Price, 8) + fmtSpace + e.QuoteSymbol
+	}
+
+	return text
+}
+
+func (l *ledgerType) Init() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.filename == JC.STRING_EMPTY {
+		l.filename = "ledger.json"
+	}
+
+	l.entries = []ledgerEntryType{}
+
+	exists, _ := JC.FileExists(JC.BuildPathRelatedToUserDirectory([]string{l.filename}))
+	if !exists {
+		return
+	}
+
+	data, ok := JC.LoadFileFromStorage(l.filename)
+	if !ok {
+		return
+	}
+
+	l.entries = l.parseJSON([]byte(data))
+
+	JC.Logln("Ledger Loaded")
+}
+
+func (l *ledgerType) parseJSON(data []byte) []ledgerEntryType {
+	entries := []ledgerEntryType{}
+
+	jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		entry := ledgerEntryType{}
+
+		if val, e := jsonparser.GetString(value, "date"); e == nil {
+			entry.Date = val
+		}
+		if val, e := jsonparser.GetString(value, "type"); e == nil {
+			entry.Type = val
+		}
+		if val, e := jsonparser.GetInt(value, "coin"); e == nil {
+			entry.Coin = val
+		}
+		if val, e := jsonparser.GetString(value, "coin_symbol"); e == nil {
+			entry.CoinSymbol = val
+		}
+		if val, e := jsonparser.GetFloat(value, "quantity"); e == nil {
+			entry.Quantity = val
+		}
+		if val, e := jsonparser.GetFloat(value, "price"); e == nil {
+			entry.Price = val
+		}
+		if val, e := jsonparser.GetInt(value, "quote"); e == nil {
+			entry.Quote = val
+		}
+		if val, e := jsonparser.GetString(value, "quote_symbol"); e == nil {
+			entry.QuoteSymbol = val
+		}
+		if val, e := jsonparser.GetFloat(value, "fee"); e == nil {
+			entry.Fee = val
+		}
+		if val, e := jsonparser.GetString(value, "note"); e == nil {
+			entry.Note = val
+		}
+
+		if !entry.IsValid() {
+			JC.Logln("Ignoring invalid ledger entry:", string(value))
+			return
+		}
+
+		entries = append(entries, entry)
+	})
+
+	return entries
+}
+
+func (l *ledgerType) Save() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return JC.SaveFileToStorage(l.filename, l.entries)
+}
+
+func (l *ledgerType) GetEntries() []ledgerEntryType {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := make([]ledgerEntryType, len(l.entries))
+	copy(entries, l.entries)
+	return entries
+}
+
+func (l *ledgerType) SetEntries(entries []ledgerEntryType) {
+	valid := make([]ledgerEntryType, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsValid() {
+			valid = append(valid, entry)
+		}
+	}
+
+	sort.SliceStable(valid, func(i, j int) bool {
+		return valid[i].Date < valid[j].Date
+	})
+
+	l.mu.Lock()
+	l.entries = valid
+	l.mu.Unlock()
+}
+
+func (l *ledgerType) IsEmpty() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.entries) == 0
+}
+
+func (l *ledgerType) Compute() []ledgerPositionType {
+	return ComputeLedger(l.GetEntries(), UseConfig().GetLedgerMethod(), l.GetRate)
+}
+
+func (l *ledgerType) GetRate(coin, quote int64) (float64, bool) {
+	if UseExchangeCache() == nil {
+		return 0, false
+	}
+
+	return UseExchangeCache().GetRate(coin, quote)
+}
+
+func NewLedgerEntry() *ledgerEntryType {
+	return &ledgerEntryType{
+		Date: time.Now().Format(ledgerDateLayout),
+		Type: JC.LEDGER_BUY,
+	}
+}
+
+func NewLedgerEntries() []ledgerEntryType {
+	return []ledgerEntryType{}
+}
+
+func RegisterLedger() *ledgerType {
+	if ledgerStorage == nil {
+		ledgerStorage = &ledgerType{}
+	}
+	return ledgerStorage
+}
+
+func UseLedger() *ledgerType {
+	return ledgerStorage
+}
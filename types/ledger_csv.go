@@ -0,0 +1,131 @@
+package types
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	JC "jxwatcher/core"
+)
+
+var ledgerCSVHeader = []string{"date", "type", "coin", "coin_symbol", "quantity", "price", "quote", "quote_symbol", "fee", "note"}
+
+func (l *ledgerType) ExportCSV(w io.Writer) error {
+	return WriteLedgerCSV(w, l.GetEntries())
+}
+
+func WriteLedgerCSV(w io.Writer, entries []ledgerEntryType) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(ledgerCSVHeader); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		record := []string{
+			entry.Date,
+			entry.Type,
+			strconv.FormatInt(entry.Coin, 10),
+			entry.CoinSymbol,
+			strconv.FormatFloat(entry.Quantity, 'f', -1, 64),
+			strconv.FormatFloat(entry.Price, 'f', -1, 64),
+			strconv.FormatInt(entry.Quote, 10),
+			entry.QuoteSymbol,
+			strconv.FormatFloat(entry.Fee, 'f', -1, 64),
+			entry.Note,
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// Appends the entries from a csv with a ledgerCSVHeader style header row, columns may come in any order
+func (l *ledgerType) ImportCSV(r io.Reader) (int, error) {
+	entries, err := ParseLedgerCSV(r)
+	if err != nil {
+		return 0, err
+	}
+
+	l.SetEntries(append(l.GetEntries(), entries...))
+
+	return len(entries), nil
+}
+
+func ParseLedgerCSV(r io.Reader) ([]ledgerEntryType, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("empty ledger csv")
+		}
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range []string{"date", "type", "coin", "quantity", "price", "quote"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("ledger csv is missing the %s column", name)
+		}
+	}
+
+	entries := []ledgerEntryType{}
+	line := 1
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, err
+		}
+
+		get := func(name string) string {
+			if idx, ok := columns[name]; ok && idx < len(record) {
+				return strings.TrimSpace(record[idx])
+			}
+			return JC.STRING_EMPTY
+		}
+
+		entry := ledgerEntryType{
+			Date:        get("date"),
+			Type:        strings.ToLower(get("type")),
+			CoinSymbol:  get("coin_symbol"),
+			QuoteSymbol: get("quote_symbol"),
+			Note:        get("note"),
+		}
+
+		entry.Coin, _ = strconv.ParseInt(get("coin"), 10, 64)
+		entry.Quote, _ = strconv.ParseInt(get("quote"), 10, 64)
+		entry.Quantity, _ = strconv.ParseFloat(get("quantity"), 64)
+		entry.Price, _ = strconv.ParseFloat(get("price"), 64)
+
+		if fee := get("fee"); fee != JC.STRING_EMPTY {
+			entry.Fee, _ = strconv.ParseFloat(fee, 64)
+		}
+
+		if !entry.IsValid() {
+			return nil, fmt.Errorf("invalid ledger entry on line %d", line)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
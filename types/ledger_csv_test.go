@@ -0,0 +1,92 @@
+package types
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+
+	JC "jxwatcher/core"
+)
+
+type ledgerCSVNullWriter struct{}
+
+func (ledgerCSVNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func ledgerCSVTurnOffLogs() {
+	log.SetOutput(ledgerCSVNullWriter{})
+}
+
+func ledgerCSVTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func TestLedgerCSVRoundTrip(t *testing.T) {
+	ledgerCSVTurnOffLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	l := &ledgerType{}
+	l.SetEntries([]ledgerEntryType{
+		{Date: "2024-02-01", Type: JC.LEDGER_SELL, Coin: 1, CoinSymbol: "BTC", Quote: 825, QuoteSymbol: "USDT", Quantity: 0.25, Price: 52000.5, Fee: 1.5, Note: "partial, exit"},
+		{Date: "2024-01-01", Type: JC.LEDGER_BUY, Coin: 1, CoinSymbol: "BTC", Quote: 825, QuoteSymbol: "USDT", Quantity: 1, Price: 42000},
+	})
+
+	buf := bytes.NewBuffer(nil)
+	if err := l.ExportCSV(buf); err != nil {
+		t.Fatalf("Unexpected export error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[0] != strings.Join(ledgerCSVHeader, ",") {
+		t.Fatalf("Unexpected csv output %q", buf.String())
+	}
+	if lines[1] != "2024-01-01,buy,1,BTC,1,42000,825,USDT,0," {
+		t.Errorf("Expected entries sorted by date, got %q", lines[1])
+	}
+
+	nl := &ledgerType{}
+	count, err := nl.ImportCSV(buf)
+	if err != nil || count != 2 {
+		t.Fatalf("Expected 2 imported entries, got %d (%v)", count, err)
+	}
+
+	entries := nl.GetEntries()
+	if entries[1].Note != "partial, exit" || entries[1].Fee != 1.5 || entries[1].Price != 52000.5 {
+		t.Errorf("Unexpected imported entry %+v", entries[1])
+	}
+
+	ledgerCSVTurnOnLogs()
+}
+
+func TestLedgerCSVParse(t *testing.T) {
+	ledgerCSVTurnOffLogs()
+
+	raw := "Type,Date,Coin,Quote,Quantity,Price\nBUY,2024-01-01,1,825,2,100\n"
+	entries, err := ParseLedgerCSV(strings.NewReader(raw))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d (%v)", len(entries), err)
+	}
+	if entries[0].Type != JC.LEDGER_BUY || entries[0].Quantity != 2 || entries[0].Fee != 0 {
+		t.Errorf("Unexpected entry %+v", entries[0])
+	}
+
+	if _, err := ParseLedgerCSV(strings.NewReader("date,type,coin\n")); err == nil {
+		t.Error("Expected error for missing columns")
+	}
+	if _, err := ParseLedgerCSV(strings.NewReader(JC.STRING_EMPTY)); err == nil {
+		t.Error("Expected error for empty csv")
+	}
+
+	_, err = ParseLedgerCSV(strings.NewReader("date,type,coin,quote,quantity,price\n2024-01-01,swap,1,825,1,1\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected invalid entry error with line number, got %v", err)
+	}
+
+	ledgerCSVTurnOnLogs()
+}
@@ -0,0 +1,211 @@
+package types
+
+import (
+	"sort"
+
+	JC "jxwatcher/core"
+)
+
+const ledgerEpsilon = 1e-12
+
+type ledgerLotType struct {
+	Quantity float64
+	Cost     float64
+}
+
+type ledgerPositionType struct {
+	Coin        int64
+	CoinSymbol  string
+	Quote       int64
+	QuoteSymbol string
+	Quantity    float64
+	Cost        float64
+	Realized    float64
+	Value       float64
+	Priced      bool
+	Incomplete  bool
+	lots        []ledgerLotType
+}
+
+func (lp *ledgerPositionType) GetUnrealized() float64 {
+	if !lp.Priced {
+		return 0
+	}
+	return lp.Value - lp.Cost
+}
+
+func (lp *ledgerPositionType) GetAverageCost() float64 {
+	if lp.Quantity <= ledgerEpsilon {
+		return 0
+	}
+	return lp.Cost / lp.Quantity
+}
+
+func (lp *ledgerPositionType) Format() string {
+	text := lp.CoinSymbol + "/" + lp.QuoteSymbol + ": " +
+		JC.FormatNumberWithCommas(lp.Quantity, 8) + " held, realized " +
+		ledgerSigned(lp.Realized)
+
+	if lp.Priced {
+		text += ", unrealized " + ledgerSigned(lp.GetUnrealized())
+	}
+
+	text += fmtSpace + lp.QuoteSymbol
+
+	if lp.Incomplete {
+		text += ", missing conversion rates"
+	}
+
+	return text
+}
+
+func (lp *ledgerPositionType) add(quantity, cost float64, method string) {
+	lp.lots = append(lp.lots, ledgerLotType{Quantity: quantity, Cost: cost})
+
+	// Average cost keeps one pooled lot, so removing from it always takes the mean price
+	if method == JC.LEDGER_METHOD_AVERAGE && len(lp.lots) > 1 {
+		pooled := ledgerLotType{}
+		for _, lot := range lp.lots {
+			pooled.Quantity += lot.Quantity
+			pooled.Cost += lot.Cost
+		}
+		lp.lots = []ledgerLotType{pooled}
+	}
+
+	lp.Quantity += quantity
+	lp.Cost += cost
+}
+
+// Removes quantity from the open lots and returns the cost basis that went with it
+func (lp *ledgerPositionType) remove(quantity float64, method string) float64 {
+	removed := 0.0
+	remaining := quantity
+
+	for remaining > ledgerEpsilon && len(lp.lots) > 0 {
+		idx := 0
+		if method == JC.LEDGER_METHOD_LIFO {
+			idx = len(lp.lots) - 1
+		}
+
+		lot := &lp.lots[idx]
+
+		if lot.Quantity <= remaining+ledgerEpsilon {
+			removed += lot.Cost
+			remaining -= lot.Quantity
+			lp.lots = append(lp.lots[:idx], lp.lots[idx+1:]...)
+			continue
+		}
+
+		cost := lot.Cost * remaining / lot.Quantity
+		removed += cost
+		lot.Cost -= cost
+		lot.Quantity -= remaining
+		remaining = 0
+	}
+
+	if remaining > ledgerEpsilon {
+		JC.Logf("Ledger removes %v %s more than held, treating it as zero cost", remaining, lp.CoinSymbol)
+	}
+
+	lp.Quantity = 0
+	lp.Cost = 0
+	for _, lot := range lp.lots {
+		lp.Quantity += lot.Quantity
+		lp.Cost += lot.Cost
+	}
+
+	return removed
+}
+
+// Replays the entries in date order per coin, valued in the quote of the first entry of that coin.
+// Rate returns the current price of one coin in the quote and converts entries made in other quotes.
+func ComputeLedger(entries []ledgerEntryType, method string, rate func(coin, quote int64) (float64, bool)) []ledgerPositionType {
+
+	sorted := make([]ledgerEntryType, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsValid() {
+			sorted = append(sorted, entry)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date < sorted[j].Date
+	})
+
+	positions := map[int64]*ledgerPositionType{}
+	order := []int64{}
+
+	for _, entry := range sorted {
+		lp, ok := positions[entry.Coin]
+		if !ok {
+			lp = &ledgerPositionType{
+				Coin:        entry.Coin,
+				CoinSymbol:  entry.CoinSymbol,
+				Quote:       entry.Quote,
+				QuoteSymbol: entry.QuoteSymbol,
+			}
+			positions[entry.Coin] = lp
+			order = append(order, entry.Coin)
+		}
+
+		conversion := 1.0
+		if entry.Quote != lp.Quote {
+			conversion = 0
+			if rate != nil {
+				if price, ok := rate(entry.Quote, lp.Quote); ok {
+					conversion = price
+				}
+			}
+
+			// The quantity must still move, otherwise later sells would eat into the wrong lots
+			if conversion == 0 {
+				JC.Logf("Ledger has no rate from %s to %s, counting %s %s entry of %s without value", entry.QuoteSymbol, lp.QuoteSymbol, entry.CoinSymbol, entry.Type, entry.Date)
+				lp.Incomplete = true
+			}
+		}
+
+		amount := entry.Quantity * entry.Price * conversion
+		fee := entry.Fee * conversion
+
+		switch entry.Type {
+		case JC.LEDGER_BUY, JC.LEDGER_TRANSFER_IN:
+			lp.add(entry.Quantity, amount+fee, method)
+
+		case JC.LEDGER_SELL:
+			cost := lp.remove(entry.Quantity, method)
+			lp.Realized += amount - fee - cost
+
+		case JC.LEDGER_TRANSFER_OUT:
+			lp.remove(entry.Quantity, method)
+			lp.Realized -= fee
+		}
+	}
+
+	result := make([]ledgerPositionType, 0, len(order))
+	for _, key := range order {
+		lp := positions[key]
+
+		if rate != nil {
+			if price, ok := rate(lp.Coin, lp.Quote); ok {
+				lp.Value = lp.Quantity * price
+				lp.Priced = true
+			}
+		}
+
+		lp.lots = nil
+		result = append(result, *lp)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CoinSymbol < result[j].CoinSymbol
+	})
+
+	return result
+}
+
+func ledgerSigned(val float64) string {
+	if val < 0 {
+		return JC.STRING_MINUS + JC.FormatNumberWithCommas(-val, 2)
+	}
+	return JC.STRING_PLUS + JC.FormatNumberWithCommas(val, 2)
+}
@@ -0,0 +1,194 @@
+package types
+
+import (
+	"log"
+	"math"
+	"os"
+	"testing"
+
+	JC "jxwatcher/core"
+)
+
+type ledgerPnLNullWriter struct{}
+
+func (ledgerPnLNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func ledgerPnLTurnOffLogs() {
+	log.SetOutput(ledgerPnLNullWriter{})
+}
+
+func ledgerPnLTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func ledgerPnLEntries() []ledgerEntryType {
+	return []ledgerEntryType{
+		{Date: "2024-01-01", Type: JC.LEDGER_BUY, Coin: 1, CoinSymbol: "BTC", Quote: 825, QuoteSymbol: "USDT", Quantity: 1, Price: 100},
+		{Date: "2024-02-01", Type: JC.LEDGER_BUY, Coin: 1, CoinSymbol: "BTC", Quote: 825, QuoteSymbol: "USDT", Quantity: 1, Price: 200},
+		{Date: "2024-03-01", Type: JC.LEDGER_SELL, Coin: 1, CoinSymbol: "BTC", Quote: 825, QuoteSymbol: "USDT", Quantity: 1.5, Price: 300, Fee: 10},
+	}
+}
+
+func ledgerPnLRate(coin, quote int64) (float64, bool) {
+	if coin == 1 && quote == 825 {
+		return 400, true
+	}
+	return 0, false
+}
+
+func ledgerPnLNear(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestLedgerComputeMethods(t *testing.T) {
+	ledgerPnLTurnOffLogs()
+
+	tests := []struct {
+		method     string
+		realized   float64
+		cost       float64
+		unrealized float64
+	}{
+		// Sells 1 @100 and 0.5 @200, proceeds 450 - 10 fee
+		{JC.LEDGER_METHOD_FIFO, 440 - 200, 100, 100},
+		// Sells 1 @200 and 0.5 @100
+		{JC.LEDGER_METHOD_LIFO, 440 - 250, 50, 150},
+		// Sells 1.5 @150 average
+		{JC.LEDGER_METHOD_AVERAGE, 440 - 225, 75, 125},
+	}
+
+	for _, tt := range tests {
+		positions := ComputeLedger(ledgerPnLEntries(), tt.method, ledgerPnLRate)
+		if len(positions) != 1 {
+			t.Fatalf("%s: expected 1 position, got %d", tt.method, len(positions))
+		}
+
+		lp := positions[0]
+		if !ledgerPnLNear(lp.Quantity, 0.5) {
+			t.Errorf("%s: expected 0.5 held, got %v", tt.method, lp.Quantity)
+		}
+		if !ledgerPnLNear(lp.Realized, tt.realized) {
+			t.Errorf("%s: expected realized %v, got %v", tt.method, tt.realized, lp.Realized)
+		}
+		if !ledgerPnLNear(lp.Cost, tt.cost) {
+			t.Errorf("%s: expected cost %v, got %v", tt.method, tt.cost, lp.Cost)
+		}
+		if !lp.Priced || !ledgerPnLNear(lp.GetUnrealized(), tt.unrealized) {
+			t.Errorf("%s: expected unrealized %v, got %v", tt.method, tt.unrealized, lp.GetUnrealized())
+		}
+	}
+
+	ledgerPnLTurnOnLogs()
+}
+
+func TestLedgerComputeTransfersAndOrdering(t *testing.T) {
+	ledgerPnLTurnOffLogs()
+
+	entries := []ledgerEntryType{
+		{Date: "2024-03-01", Type: JC.LEDGER_TRANSFER_OUT, Coin: 1027, CoinSymbol: "ETH", Quote: 825, QuoteSymbol: "USDT", Quantity: 1, Fee: 2},
+		{Date: "2024-01-01", Type: JC.LEDGER_BUY, Coin: 1027, CoinSymbol: "ETH", Quote: 825, QuoteSymbol: "USDT", Quantity: 2, Price: 1000, Fee: 4},
+		{Date: "2024-02-01", Type: JC.LEDGER_TRANSFER_IN, Coin: 1027, CoinSymbol: "ETH", Quote: 825, QuoteSymbol: "USDT", Quantity: 1, Price: 1500},
+		{Date: "2024-02-01", Type: JC.LEDGER_BUY, Coin: 1, CoinSymbol: "BTC", Quote: 825, QuoteSymbol: "USDT", Quantity: 1, Price: 100},
+		{Date: "bad", Type: JC.LEDGER_BUY, Coin: 1, CoinSymbol: "BTC", Quote: 825, QuoteSymbol: "USDT", Quantity: 1, Price: 100},
+	}
+
+	positions := ComputeLedger(entries, JC.LEDGER_METHOD_FIFO, nil)
+	if len(positions) != 2 {
+		t.Fatalf("Expected 2 positions, got %d", len(positions))
+	}
+	if positions[0].CoinSymbol != "BTC" || positions[1].CoinSymbol != "ETH" {
+		t.Errorf("Expected positions sorted by symbol, got %s and %s", positions[0].CoinSymbol, positions[1].CoinSymbol)
+	}
+	if positions[0].Quantity != 1 {
+		t.Errorf("Expected invalid entry to be skipped, got %v BTC", positions[0].Quantity)
+	}
+
+	eth := positions[1]
+	if !ledgerPnLNear(eth.Quantity, 2) || !ledgerPnLNear(eth.Cost, 1002+1500) {
+		t.Errorf("Expected transfer out to take the oldest lot, got %v for %v", eth.Quantity, eth.Cost)
+	}
+	if !ledgerPnLNear(eth.Realized, -2) {
+		t.Errorf("Expected only the transfer fee to be realized, got %v", eth.Realized)
+	}
+	if eth.Priced || eth.GetUnrealized() != 0 {
+		t.Error("Expected position without rate to stay unpriced")
+	}
+	if !ledgerPnLNear(eth.GetAverageCost(), 1251) {
+		t.Errorf("Expected average cost 1251, got %v", eth.GetAverageCost())
+	}
+
+	ledgerPnLTurnOnLogs()
+}
+
+func TestLedgerComputeOversell(t *testing.T) {
+	ledgerPnLTurnOffLogs()
+
+	entries := []ledgerEntryType{
+		{Date: "2024-01-01", Type: JC.LEDGER_BUY, Coin: 1, CoinSymbol: "BTC", Quote: 825, QuoteSymbol: "USDT", Quantity: 1, Price: 100},
+		{Date: "2024-01-02", Type: JC.LEDGER_SELL, Coin: 1, CoinSymbol: "BTC", Quote: 825, QuoteSymbol: "USDT", Quantity: 2, Price: 150},
+	}
+
+	positions := ComputeLedger(entries, JC.LEDGER_METHOD_LIFO, ledgerPnLRate)
+	lp := positions[0]
+
+	if lp.Quantity != 0 || lp.Cost != 0 {
+		t.Errorf("Expected position to be closed, got %v for %v", lp.Quantity, lp.Cost)
+	}
+	if !ledgerPnLNear(lp.Realized, 200) {
+		t.Errorf("Expected excess to be sold at zero cost, got %v", lp.Realized)
+	}
+	if lp.Format() != "BTC/USDT: 0 held, realized +200, unrealized +0 USDT" {
+		t.Errorf("Unexpected format %q", lp.Format())
+	}
+
+	ledgerPnLTurnOnLogs()
+}
+
+func TestLedgerComputeCrossQuote(t *testing.T) {
+	ledgerPnLTurnOffLogs()
+	defer ledgerPnLTurnOnLogs()
+
+	entries := []ledgerEntryType{
+		{Date: "2024-01-01", Type: JC.LEDGER_BUY, Coin: 1, CoinSymbol: "BTC", Quote: 825, QuoteSymbol: "USDT", Quantity: 1, Price: 100},
+		{Date: "2024-02-01", Type: JC.LEDGER_BUY, Coin: 1, CoinSymbol: "BTC", Quote: 1027, QuoteSymbol: "ETH", Quantity: 1, Price: 0.05},
+		{Date: "2024-03-01", Type: JC.LEDGER_SELL, Coin: 1, CoinSymbol: "BTC", Quote: 1027, QuoteSymbol: "ETH", Quantity: 1.5, Price: 0.1, Fee: 0.001},
+	}
+
+	rate := func(coin, quote int64) (float64, bool) {
+		if coin == 1027 && quote == 825 {
+			return 3000, true
+		}
+		return ledgerPnLRate(coin, quote)
+	}
+
+	positions := ComputeLedger(entries, JC.LEDGER_METHOD_FIFO, rate)
+	if len(positions) != 1 {
+		t.Fatalf("Expected one position per coin across quotes, got %d", len(positions))
+	}
+
+	// The ETH buy costs 150 USDT, the sell brings 450 minus a 3 USDT fee against 100 + 75 of cost
+	lp := positions[0]
+	if lp.QuoteSymbol != "USDT" || lp.Incomplete {
+		t.Errorf("Expected position valued in USDT, got %s (incomplete %v)", lp.QuoteSymbol, lp.Incomplete)
+	}
+	if !ledgerPnLNear(lp.Quantity, 0.5) || !ledgerPnLNear(lp.Cost, 75) {
+		t.Errorf("Expected 0.5 held for 75, got %v for %v", lp.Quantity, lp.Cost)
+	}
+	if !ledgerPnLNear(lp.Realized, 272) {
+		t.Errorf("Expected realized 272, got %v", lp.Realized)
+	}
+	if !lp.Priced || !ledgerPnLNear(lp.GetUnrealized(), 125) {
+		t.Errorf("Expected unrealized 125, got %v", lp.GetUnrealized())
+	}
+
+	positions = ComputeLedger(entries, JC.LEDGER_METHOD_FIFO, ledgerPnLRate)
+	lp = positions[0]
+	if !lp.Incomplete || !ledgerPnLNear(lp.Quantity, 0.5) {
+		t.Errorf("Expected quantity kept and position marked incomplete without a conversion rate, got %v (%v)", lp.Quantity, lp.Incomplete)
+	}
+	if lp.Format() != "BTC/USDT: 0.5 held, realized -100, unrealized +200 USDT, missing conversion rates" {
+		t.Errorf("Unexpected format %q", lp.Format())
+	}
+}
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+
+	JC "jxwatcher/core"
+)
+
+type ledgerNullWriter struct{}
+
+func (ledgerNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func ledgerTurnOffLogs() {
+	log.SetOutput(ledgerNullWriter{})
+}
+
+func ledgerTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func TestLedgerSaveAndLoad(t *testing.T) {
+	ledgerTurnOffLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	JC.EraseFileFromStorage("ledger_test.json")
+	defer JC.EraseFileFromStorage("ledger_test.json")
+
+	l := &ledgerType{filename: "ledger_test.json"}
+	l.Init()
+
+	if !l.IsEmpty() {
+		t.Fatal("Expected empty ledger without file")
+	}
+
+	entry := NewLedgerEntry()
+	entry.Coin = 1
+	entry.CoinSymbol = "BTC"
+	entry.Quote = 825
+	entry.QuoteSymbol = "USDT"
+	entry.Quantity = 0.5
+	entry.Price = 60000
+
+	invalid := NewLedgerEntry()
+	invalid.Coin = 1
+	invalid.Quote = 1
+	invalid.Quantity = 1
+
+	l.SetEntries(append(NewLedgerEntries(), *entry, *invalid))
+	if len(l.GetEntries()) != 1 {
+		t.Fatalf("Expected invalid entry to be dropped, got %d entries", len(l.GetEntries()))
+	}
+
+	if !l.Save() {
+		t.Fatal("Expected ledger to be saved")
+	}
+
+	nl := &ledgerType{filename: "ledger_test.json"}
+	nl.Init()
+
+	entries := nl.GetEntries()
+	if len(entries) != 1 || entries[0] != *entry {
+		t.Errorf("Expected saved entry to be loaded back, got %+v", entries)
+	}
+
+	ledgerTurnOnLogs()
+}
+
+func TestLedgerEntryValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry ledgerEntryType
+		valid bool
+	}{
+		{"buy", ledgerEntryType{Date: "2024-01-01", Type: JC.LEDGER_BUY, Coin: 1, Quote: 2, Quantity: 1}, true},
+		{"unknown type", ledgerEntryType{Date: "2024-01-01", Type: "swap", Coin: 1, Quote: 2, Quantity: 1}, false},
+		{"bad date", ledgerEntryType{Date: "01/01/2024", Type: JC.LEDGER_SELL, Coin: 1, Quote: 2, Quantity: 1}, false},
+		{"zero quantity", ledgerEntryType{Date: "2024-01-01", Type: JC.LEDGER_SELL, Coin: 1, Quote: 2}, false},
+		{"negative fee", ledgerEntryType{Date: "2024-01-01", Type: JC.LEDGER_SELL, Coin: 1, Quote: 2, Quantity: 1, Fee: -1}, false},
+	}
+
+	for _, tt := range tests {
+		if tt.entry.IsValid() != tt.valid {
+			t.Errorf("%s: expected valid=%v", tt.name, tt.valid)
+		}
+	}
+}
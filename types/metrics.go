@@ -0,0 +1,140 @@
+package types
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	JC "jxwatcher/core"
+)
+
+const metricsPrefix = "jxwatcher_"
+
+type metricLabel struct {
+	name  string
+	value string
+}
+
+func WriteMetrics(w io.Writer) error {
+	var b strings.Builder
+
+	writeMetricHeader(&b, "panel_rate", "Exchange rate shown by each panel", "gauge")
+	for _, pot := range UsePanelMaps().GetData() {
+		pdt := UsePanelMaps().GetDataByID(pot.GetID())
+		if pdt == nil || !pdt.IsStatus(JC.STATE_LOADED) {
+			continue
+		}
+
+		pkt := pdt.UsePanelKey()
+		rate, _ := pkt.GetValueFloat().Float64()
+		if rate < 0 {
+			continue
+		}
+
+		writeMetric(&b, "panel_rate", rate,
+			metricLabel{"id", pdt.GetID()},
+			metricLabel{"source", pkt.GetSourceCoinString()},
+			metricLabel{"target", pkt.GetTargetCoinString()},
+			metricLabel{"source_symbol", pkt.GetSourceSymbolString()},
+			metricLabel{"target_symbol", pkt.GetTargetSymbolString()},
+		)
+	}
+
+	writeMetricHeader(&b, "ticker_value", "Latest value of each cached ticker", "gauge")
+	if UseTickerCache() != nil {
+		for _, entry := range UseTickerCache().Serialize().Data {
+			value, err := strconv.ParseFloat(entry.Value, 64)
+			if err != nil {
+				continue
+			}
+
+			writeMetric(&b, "ticker_value", value, metricLabel{"key", entry.Key})
+		}
+	}
+
+	writeMetricHeader(&b, "fetch_requests_total", "Outcomes of network fetches by networking code", "counter")
+	outcomes := JC.GetNetworkingOutcomes()
+	codes := make([]int64, 0, len(outcomes))
+	for code := range outcomes {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] > codes[j] })
+
+	for _, code := range codes {
+		writeMetric(&b, "fetch_requests_total", float64(outcomes[code]),
+			metricLabel{"code", strconv.FormatInt(code, 10)},
+			metricLabel{"outcome", JC.GetNetworkingCodeName(code)},
+		)
+	}
+
+	if JC.UseQuota() != nil {
+		usage := JC.UseQuota().GetUsage()
+
+		writeMetricHeader(&b, "quota_requests", "Requests counted against the quota in the current period", "gauge")
+		writeMetric(&b, "quota_requests", float64(usage.Today), metricLabel{"period", "day"})
+		writeMetric(&b, "quota_requests", float64(usage.Month), metricLabel{"period", "month"})
+
+		if usage.IsLimited() {
+			writeMetricHeader(&b, "quota_remaining", "Requests left in the tightest quota", "gauge")
+			writeMetric(&b, "quota_remaining", float64(usage.Remaining))
+		}
+	}
+
+	workers := map[string]time.Time{}
+	if JC.UseWorker() != nil {
+		for _, key := range JC.UseWorker().GetKeys() {
+			if last := JC.UseWorker().GetLastUpdate(key); !last.IsZero() {
+				workers[key] = last
+			}
+		}
+	}
+
+	workerKeys := make([]string, 0, len(workers))
+	for key := range workers {
+		workerKeys = append(workerKeys, key)
+	}
+	sort.Strings(workerKeys)
+
+	writeMetricHeader(&b, "worker_last_run_timestamp_seconds", "Unix time of the last run of each worker", "gauge")
+	for _, key := range workerKeys {
+		writeMetric(&b, "worker_last_run_timestamp_seconds", float64(workers[key].UnixMilli())/1000, metricLabel{"worker", key})
+	}
+
+	now := time.Now()
+
+	writeMetricHeader(&b, "worker_seconds_since_last_run", "Seconds elapsed since the last run of each worker", "gauge")
+	for _, key := range workerKeys {
+		writeMetric(&b, "worker_seconds_since_last_run", now.Sub(workers[key]).Seconds(), metricLabel{"worker", key})
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMetricHeader(b *strings.Builder, name string, help string, kind string) {
+	b.WriteString("# HELP " + metricsPrefix + name + " " + help + "\n")
+	b.WriteString("# TYPE " + metricsPrefix + name + " " + kind + "\n")
+}
+
+func writeMetric(b *strings.Builder, name string, value float64, labels ...metricLabel) {
+	b.WriteString(metricsPrefix + name)
+
+	if len(labels) != 0 {
+		b.WriteString("{")
+		for i, label := range labels {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString(label.name + "=\"" + escapeMetricLabel(label.value) + "\"")
+		}
+		b.WriteString("}")
+	}
+
+	b.WriteString(" " + strconv.FormatFloat(value, 'g', -1, 64) + "\n")
+}
+
+func escapeMetricLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	JC "jxwatcher/core"
+)
+
+type metricsNullWriter struct{}
+
+func (metricsNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func metricsTurnOffLogs() {
+	log.SetOutput(metricsNullWriter{})
+}
+
+func metricsTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func TestWriteMetrics(t *testing.T) {
+	metricsTurnOffLogs()
+	defer metricsTurnOnLogs()
+
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	RegisterExchangeCache().Init()
+	UseExchangeCache().Insert(&exchangeDataType{
+		SourceId:     1,
+		TargetId:     2,
+		SourceSymbol: "BTC",
+		TargetSymbol: "ETH",
+		SourceAmount: 1,
+		TargetAmount: JC.ToBigFloat(42.5),
+		Timestamp:    time.Now(),
+	})
+
+	RegisterTickerCache().Init()
+	UseTickerCache().Insert(TickerTypeFearGreed, "55", time.Now())
+	UseTickerCache().Insert(TickerTypePulse, "not a number", time.Now())
+
+	UsePanelMaps().Init()
+	defer UsePanelMaps().Destroy()
+
+	loaded := UsePanelMaps().Append("1-2-1-BTC-ETH-4|-1")
+	loaded.UpdateRate()
+	loaded.SetStatus(JC.STATE_LOADED)
+	UsePanelMaps().Append("1-3-1-BTC-XRP-4|-1")
+
+	var b strings.Builder
+	if err := WriteMetrics(&b); err != nil {
+		t.Fatalf("Unexpected error writing metrics: %v", err)
+	}
+	out := b.String()
+
+	expected := []string{
+		"# TYPE jxwatcher_panel_rate gauge\n",
+		`jxwatcher_panel_rate{id="` + loaded.GetID() + `",source="1",target="2",source_symbol="BTC",target_symbol="ETH"} 42.5` + "\n",
+		`jxwatcher_ticker_value{key="feargreed"} 55` + "\n",
+		"# TYPE jxwatcher_fetch_requests_total counter\n",
+		"# TYPE jxwatcher_worker_seconds_since_last_run gauge\n",
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(out, line) {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, out)
+		}
+	}
+
+	if strings.Count(out, "jxwatcher_panel_rate{") != 1 {
+		t.Error("Expected only loaded panels to be exported")
+	}
+	if strings.Contains(out, `key="pulse"`) {
+		t.Error("Expected non numeric tickers to be skipped")
+	}
+}
+
+func TestEscapeMetricLabel(t *testing.T) {
+	if got := escapeMetricLabel("a\"b\\c\nd"); got != `a\"b\\c\nd` {
+		t.Errorf("Unexpected escaped label %q", got)
+	}
+}
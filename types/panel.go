@@ -1,5 +1,12 @@
 package types
 
+import (
+	"strings"
+	"time"
+)
+
+const panelAcquiredLayout = "2006-01-02"
+
 type panelType struct {
 	Source       int64   `json:"source"`
 	Target       int64   `json:"target"`
@@ -7,6 +14,14 @@ type panelType struct {
 	Decimals     int64   `json:"decimals"`
 	SourceSymbol string  `json:"source_symbol"`
 	TargetSymbol string  `json:"target_symbol"`
+	Provider     string  `json:"provider,omitempty"`
+
+	// Holding
+	CostBasis float64 `json:"cost_basis,omitempty"`
+	Acquired  string  `json:"acquired,omitempty"`
+
+	// Background colors by rate
+	Colors *colorRulesType `json:"colors,omitempty"`
 
 	// // Watcher
 	Rate      float64 `json:"target_rate"`
@@ -15,4 +30,16 @@ type panelType struct {
 	Limit     int     `json:"limit"`
 	Duration  int     `json:"duration"`
 	Timestamp int     `json:"timestamp"`
+	AlertRate float64 `json:"alert_rate,omitempty"`
+
+	Conditions *watcherConditionsType `json:"conditions,omitempty"`
+}
+
+func ParseAcquiredDate(val string) (int64, bool) {
+	ts, err := time.ParseInLocation(panelAcquiredLayout, strings.TrimSpace(val), time.Local)
+	if err != nil {
+		return 0, false
+	}
+
+	return ts.Unix(), true
 }
@@ -13,7 +13,6 @@ import (
 )
 
 const fmtSpace = " "
-const fmtTo = " to "
 const fmtVal = "1 "
 const fmtEqual = " = "
 
@@ -24,15 +23,22 @@ type PanelData interface {
 	SetID(val string)
 	SetOldKey(val string)
 	SetWatcherKey(val string)
+	SetProvider(val string)
+	SetColorRules(val colorRulesType)
 	SetParent(val *panelsMapType)
 	SetRate(val *big.Float) bool
 	Get() string
 	GetStatus() int
 	GetID() string
 	GetOldKey() string
+	GetProvider() string
+	GetColorRules() colorRulesType
+	GetColor() (fyne.ThemeColorName, bool)
+	GetRateSource() string
 	GetParent() *panelsMapType
 	GetValueString() string
 	GetOldValueString() string
+	GetRecentRates(n int) []float64
 	UseData() JC.DataBinding
 	UsePanelKey() *panelKeyType
 	UseWatcherKey() *watcherKeyType
@@ -43,11 +49,13 @@ type PanelData interface {
 	IsEqualContentString(pk string) bool
 	IsOnInitialValue() bool
 	IsValueIncrease() int
+	IsRateDisputed() bool
 	HasParent() bool
 	RefreshData()
 	RefreshKey(key string) string
 	Insert(panel panelType, rate float64)
 	Update(pk string) bool
+	Reconfigure(pk string)
 	UpdateRate() bool
 	UpdateStatus() bool
 	Destroy()
@@ -55,6 +63,8 @@ type PanelData interface {
 	FormatSubtitle() string
 	FormatBottomText() string
 	FormatContent() string
+	FormatRateSource() string
+	FormatPnL() string
 	DidChange() bool
 	Serialize() panelDataCache
 	ProcessWatcher()
@@ -71,6 +81,9 @@ type panelDataType struct {
 	data       JC.DataBinding
 	oldKey     string
 	watcherKey string
+	transition string
+	provider   string
+	colors     colorRulesType
 	id         string
 	parent     *panelsMapType
 }
@@ -80,6 +93,9 @@ func (p *panelDataType) Init() {
 	p.id = JC.STRING_EMPTY
 	p.oldKey = JC.STRING_EMPTY
 	p.watcherKey = JC.STRING_EMPTY
+	p.transition = JC.STRING_EMPTY
+	p.provider = JC.STRING_EMPTY
+	p.colors = nil
 }
 
 func (p *panelDataType) Set(val string) {
@@ -113,6 +129,14 @@ func (p *panelDataType) SetWatcherKey(val string) {
 	}
 }
 
+func (p *panelDataType) SetProvider(val string) {
+	p.provider = val
+}
+
+func (p *panelDataType) SetColorRules(val colorRulesType) {
+	p.colors = val
+}
+
 func (p *panelDataType) SetParent(val *panelsMapType) {
 	p.parent = val
 }
@@ -166,6 +190,35 @@ func (p *panelDataType) GetWatcherKey() string {
 	return p.watcherKey
 }
 
+func (p *panelDataType) GetProvider() string {
+	return p.provider
+}
+
+func (p *panelDataType) GetColorRules() colorRulesType {
+	return p.colors
+}
+
+// Evaluates the panel color rules against the current rate of one source coin
+func (p *panelDataType) GetColor() (fyne.ThemeColorName, bool) {
+	if len(p.colors) == 0 {
+		return JC.STRING_EMPTY, false
+	}
+
+	rate, _ := p.UsePanelKey().GetValueFloat().Float64()
+	if rate < 0 {
+		return JC.STRING_EMPTY, false
+	}
+
+	return p.colors.Evaluate(rate)
+}
+
+func (p *panelDataType) GetRateSource() string {
+	if dt := p.getCachedRate(); dt != nil {
+		return dt.Provider
+	}
+	return JC.STRING_EMPTY
+}
+
 func (p *panelDataType) GetParent() *panelsMapType {
 	return p.parent
 }
@@ -244,6 +297,15 @@ func (p *panelDataType) IsValueIncrease() int {
 	return JC.VALUE_NO_CHANGE
 }
 
+func (p *panelDataType) IsRateDisputed() bool {
+	dt := p.getCachedRate()
+	if dt == nil || dt.Consensus == JC.STRING_EMPTY {
+		return false
+	}
+
+	return dt.Deviation > UseConfig().GetRateConsensusTolerance()
+}
+
 func (p *panelDataType) HasParent() bool {
 	return p.parent != nil
 }
@@ -269,6 +331,8 @@ func (p *panelDataType) RefreshKey(key string) string {
 	targetSymbol := pkt.GetTargetSymbolString()
 	decimals := pkt.GetDecimalsString()
 	rate := pkt.GetValueFloat()
+	costBasis := pkt.GetCostBasisFloat()
+	acquired := pkt.GetAcquiredInt()
 
 	if sourceSymbol == JC.STRING_EMPTY {
 		sourceSymbol = p.parent.GetSymbolById(source)
@@ -278,12 +342,19 @@ func (p *panelDataType) RefreshKey(key string) string {
 		targetSymbol = p.parent.GetSymbolById(target)
 	}
 
-	return pkt.GenerateKey(source, target, value, sourceSymbol, targetSymbol, decimals, rate)
+	pkt.GenerateKey(source, target, value, sourceSymbol, targetSymbol, decimals, rate)
+
+	return pkt.SetHolding(costBasis, acquired)
 }
 
 func (p *panelDataType) ProcessWatcher() {
 	wx := p.UseWatcherKey()
 
+	// Crossing rules only fire once for each oldKey to key transition
+	transition := p.oldKey + JC.STRING_PIPE + p.Get()
+	crossed := p.transition != transition && p.DidChange()
+	p.transition = transition
+
 	if !wx.CanSend() {
 		return
 	}
@@ -292,42 +363,154 @@ func (p *panelDataType) ProcessWatcher() {
 	sent := wx.GetSent()
 	now := time.Now().UTC().UnixMicro()
 
-	var op string
-	var to string
-	switch wx.GetOperator() {
-	case 0:
-		op = JC.STRING_EQUAL
-		to = "equal"
-	case 1:
-		op = JC.STRING_LESS
-		to = "less than"
-	case 2:
-		op = JC.STRING_GREATER
-		to = "greater than"
-	default:
-		return
+	rules := wx.GetRules()
+	matched := []string{}
+
+	for _, rule := range rules {
+		if p.matchWatcherRule(rule, crossed) {
+			matched = append(matched, rule.FormatDescription())
+		}
 	}
 
-	if !px.IsValueMatching(JC.ToBigFloat(wx.GetRate()), op) {
-		return
+	switch wx.GetLogic() {
+	case JC.WATCHER_LOGIC_OR:
+		if len(matched) == 0 {
+			return
+		}
+	default:
+		if len(rules) == 0 || len(matched) != len(rules) {
+			return
+		}
 	}
 
-	JC.App.SendNotification(fyne.NewNotification(
-		"Rate Alert",
-		fmt.Sprintf("%s to %s rates is %s %s",
+	alert := &alertType{
+		Title: "Rate Alert",
+		Message: fmt.Sprintf("%s to %s %s",
 			px.GetSourceSymbolString(),
 			px.GetTargetSymbolString(),
-			to,
-			wx.GetFormattedRateString()),
-	))
+			strings.Join(matched, " and ")),
+		SourceId:     px.GetSourceCoinInt(),
+		SourceSymbol: px.GetSourceSymbolString(),
+		TargetId:     px.GetTargetCoinInt(),
+		TargetSymbol: px.GetTargetSymbolString(),
+		Rate:         px.GetValueString(),
+		Rules:        matched,
+		Timestamp:    time.Now(),
+	}
+
+	if UseAlerts() != nil {
+		if !UseAlerts().Dispatch(alert) {
+			return
+		}
+	} else if !JC.IsHeadless {
+		JC.App.SendNotification(fyne.NewNotification(alert.Title, alert.Message))
+	}
 
 	wx.UpdateTimestamp(now)
 	wx.UpdateSent(sent + 1)
+	if rate, _ := px.GetValueFloat().Float64(); rate > 0 {
+		wx.UpdateAlertRate(rate)
+	}
 	p.SetWatcherKey(wx.GetRawValue())
 
 	JC.Logln("Sending notification: ", wx.GetRawValue())
 }
 
+func (p *panelDataType) matchWatcherRule(rule watcherRuleType, crossed bool) bool {
+	if !rule.IsValid() {
+		return false
+	}
+
+	switch rule.Metric {
+	case JC.WATCHER_METRIC_RATE:
+		if rule.IsCrossing() {
+			if !crossed {
+				return false
+			}
+
+			opk := panelKeyType{value: p.oldKey}
+			previous, _ := opk.GetValueFloat().Float64()
+			current, _ := p.UsePanelKey().GetValueFloat().Float64()
+			if previous <= 0 || current <= 0 {
+				return false
+			}
+
+			return rule.MatchCrossing(previous, current)
+		}
+
+		return p.UsePanelKey().IsValueMatching(JC.ToBigFloat(rule.Value), rule.GetOperatorSymbol())
+
+	case JC.WATCHER_METRIC_CHANGE_24H:
+		change, ok := p.getRateChange(ExchangeHistoryWindow)
+		if !ok {
+			return false
+		}
+		return rule.Match(change)
+
+	case JC.WATCHER_METRIC_CHANGE_WINDOW:
+		change, ok := p.getRateChange(time.Duration(rule.Window) * time.Minute)
+		if !ok {
+			return false
+		}
+		return rule.Match(change)
+
+	case JC.WATCHER_METRIC_CHANGE_SINCE_ALERT:
+		change, ok := p.getRateChangeSinceAlert()
+		if !ok {
+			return false
+		}
+		return rule.Match(change)
+	}
+
+	return false
+}
+
+func (p *panelDataType) getRateChange(window time.Duration) (float64, bool) {
+	return p.getRateChangeFrom(time.Now().Add(-window))
+}
+
+// Compares against the rate stored when the last alert fired, a watcher without one stores the current rate as its start
+func (p *panelDataType) getRateChangeSinceAlert() (float64, bool) {
+	current, _ := p.UsePanelKey().GetValueFloat().Float64()
+	if current <= 0 {
+		return 0, false
+	}
+
+	wx := p.UseWatcherKey()
+	past := wx.GetAlertRate()
+	if past <= 0 {
+		p.SetWatcherKey(wx.UpdateAlertRate(current))
+		return 0, false
+	}
+
+	return (current - past) / past * 100, true
+}
+
+func (p *panelDataType) getRateChangeFrom(from time.Time) (float64, bool) {
+	if UseExchangeHistory() == nil {
+		return 0, false
+	}
+
+	pk := p.UsePanelKey()
+	current, _ := pk.GetValueFloat().Float64()
+	if current <= 0 {
+		return 0, false
+	}
+
+	ck := UseExchangeCache().CreateKeyFromInt(pk.GetSourceCoinInt(), pk.GetTargetCoinInt())
+	point, ok := UseExchangeHistory().First(ck, from)
+	if !ok {
+		return 0, false
+	}
+
+	past, _ := point.Rate.Float64()
+	if past <= 0 {
+		return 0, false
+	}
+
+	return (current - past) / past * 100, true
+}
+
 func (p *panelDataType) Update(pk string) bool {
 	if JC.IsShuttingDown() {
 		return false
@@ -370,6 +553,43 @@ func (p *panelDataType) Update(pk string) bool {
 	return false
 }
 
+// Reconfigure applies an edited panel key, keeping the current rate when the pair is unchanged
+func (p *panelDataType) Reconfigure(pk string) {
+	pkt := p.UsePanelKey()
+	npk := panelKeyType{value: pk}
+
+	if pkt.GetSourceCoinInt() != npk.GetSourceCoinInt() || pkt.GetTargetCoinInt() != npk.GetTargetCoinInt() {
+		p.SetStatus(JC.STATE_LOADING)
+		p.Set(npk.GetRawValue())
+		p.Update(npk.GetRawValue())
+		return
+	}
+
+	opk := p.GetOldKey()
+	npk.UpdateValue(pkt.GetValueFloat())
+	p.Set(npk.GetRawValue())
+	p.SetOldKey(opk)
+}
+
+func (p *panelDataType) GetRecentRates(n int) []float64 {
+	rates := []float64{}
+
+	if UseExchangeHistory() == nil {
+		return rates
+	}
+
+	pk := p.UsePanelKey()
+	ck := UseExchangeCache().CreateKeyFromInt(pk.GetSourceCoinInt(), pk.GetTargetCoinInt())
+
+	for _, point := range UseExchangeHistory().Last(ck, n) {
+		if f, _ := point.Rate.Float64(); f > 0 {
+			rates = append(rates, f)
+		}
+	}
+
+	return rates
+}
+
 func (p *panelDataType) UpdateRate() bool {
 	if JC.IsShuttingDown() {
 		return false
@@ -388,6 +608,17 @@ func (p *panelDataType) UpdateRate() bool {
 	return false
 }
 
+func (p *panelDataType) getCachedRate() *exchangeDataType {
+	if UseExchangeCache() == nil {
+		return nil
+	}
+
+	pk := p.UsePanelKey()
+	ck := UseExchangeCache().CreateKeyFromInt(pk.GetSourceCoinInt(), pk.GetTargetCoinInt())
+
+	return UseExchangeCache().Get(ck)
+}
+
 func (p *panelDataType) UpdateStatus() bool {
 	if JC.IsShuttingDown() {
 		return false
@@ -410,6 +641,7 @@ func (p *panelDataType) Destroy() {
 	p.data = nil
 	p.parent = nil
 	p.oldKey = JC.STRING_EMPTY
+	p.transition = JC.STRING_EMPTY
 	p.id = JC.STRING_EMPTY
 }
 
@@ -420,7 +652,9 @@ func (p *panelDataType) FormatTitle() string {
 	b.WriteString(pk.GetSourceValueFormattedString())
 	b.WriteString(fmtSpace)
 	b.WriteString(pk.GetSourceSymbolString())
-	b.WriteString(fmtTo)
+	b.WriteString(fmtSpace)
+	b.WriteString(JC.Translate("to"))
+	b.WriteString(fmtSpace)
 	b.WriteString(pk.GetTargetSymbolString())
 
 	return b.String()
@@ -465,6 +699,36 @@ func (p *panelDataType) FormatContent() string {
 	return b.String()
 }
 
+func (p *panelDataType) FormatPnL() string {
+	pk := p.UsePanelKey()
+	if !pk.IsHolding() {
+		return JC.STRING_EMPTY
+	}
+
+	var b strings.Builder
+	b.WriteString(pk.GetPnLFormattedString())
+	b.WriteString(fmtSpace)
+	b.WriteString(pk.GetTargetSymbolString())
+	b.WriteString(" (")
+	b.WriteString(pk.GetPnLPercentFormattedString())
+	b.WriteString(")")
+
+	return b.String()
+}
+
+func (p *panelDataType) FormatRateSource() string {
+	dt := p.getCachedRate()
+	if dt == nil || dt.Provider == JC.STRING_EMPTY {
+		return JC.STRING_EMPTY
+	}
+
+	if !p.IsRateDisputed() {
+		return dt.Provider
+	}
+
+	return JC.Translatef("%s, %s%% off %s", dt.Provider, JC.FormatDecimal(dt.Deviation, 2), dt.Consensus)
+}
+
 func (p *panelDataType) DidChange() bool {
 	opt := &panelKeyType{value: p.oldKey}
 	return p.oldKey != p.Get() &&
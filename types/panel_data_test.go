@@ -361,6 +361,156 @@ func TestPanelDataUpdateAndUpdateRate(t *testing.T) {
 	panelDataTurnOnLogs()
 }
 
+func TestPanelDataReconfigure(t *testing.T) {
+	panelDataTurnOffLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	RegisterExchangeCache().Init()
+
+	p := NewPanelData()
+	p.Init()
+	p.SetStatus(JC.STATE_LOADED)
+	p.Set("1-2-1-BTC-ETH-4|0.5")
+	p.Set("1-2-1-BTC-ETH-4|0.6")
+
+	p.Reconfigure("1-2-3-BTC-ETH-2|-1")
+
+	if p.Get() != "1-2-3-BTC-ETH-2|0.6" {
+		t.Errorf("Expected rate to be kept for the same pair, got %s", p.Get())
+	}
+	if p.GetOldKey() != "1-2-1-BTC-ETH-4|0.5" {
+		t.Errorf("Expected old key to be kept for the same pair, got %s", p.GetOldKey())
+	}
+	if !p.IsStatus(JC.STATE_LOADED) {
+		t.Error("Expected status to stay loaded for the same pair")
+	}
+
+	p.Reconfigure("3-2-1-XRP-ETH-4|-1")
+
+	if p.Get() != "3-2-1-XRP-ETH-4|-1" {
+		t.Errorf("Expected new pair key, got %s", p.Get())
+	}
+	if !p.IsStatus(JC.STATE_LOADING) {
+		t.Error("Expected status to be loading after changing the pair")
+	}
+
+	panelDataTurnOnLogs()
+}
+
+func TestPanelDataRateSource(t *testing.T) {
+	panelDataTurnOffLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	cfg := UseConfig()
+	oldTolerance := cfg.RateConsensusTolerance
+	defer func() {
+		cfg.RateConsensusTolerance = oldTolerance
+	}()
+	cfg.RateConsensusTolerance = 1
+
+	RegisterExchangeCache().Init()
+
+	p := NewPanelData()
+	p.Init()
+	p.Set("2-1-1-ETH-BTC-4|-1")
+
+	if p.FormatRateSource() != JC.STRING_EMPTY || p.IsRateDisputed() {
+		t.Error("Expected no rate source without cached rate")
+	}
+
+	UseExchangeCache().Insert(&exchangeDataType{
+		SourceId:     1,
+		TargetId:     2,
+		SourceAmount: 1,
+		TargetAmount: JC.ToBigFloat(25),
+		Timestamp:    time.Now(),
+		Provider:     JC.RATE_PROVIDER_CMC,
+		Consensus:    JC.RATE_PROVIDER_KRAKEN,
+		Deviation:    0.5,
+	})
+
+	if p.GetRateSource() != JC.RATE_PROVIDER_CMC {
+		t.Errorf("Expected rate source from the reversed pair, got %q", p.GetRateSource())
+	}
+	if p.IsRateDisputed() || p.FormatRateSource() != JC.RATE_PROVIDER_CMC {
+		t.Errorf("Expected rate within tolerance not to be disputed, got %q", p.FormatRateSource())
+	}
+
+	cfg.RateConsensusTolerance = 0.25
+
+	if !p.IsRateDisputed() {
+		t.Error("Expected rate beyond tolerance to be disputed")
+	}
+	if p.FormatRateSource() != "coinmarketcap, 0.50% off kraken" {
+		t.Errorf("Unexpected rate source %q", p.FormatRateSource())
+	}
+
+	panelDataTurnOnLogs()
+}
+
+func TestPanelDataFormatPnL(t *testing.T) {
+	panelDataTurnOffLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	p := NewPanelData()
+	p.Init()
+	p.Set("1-2-0.5-BTC-ETH-4|0.6")
+
+	if p.FormatPnL() != JC.STRING_EMPTY {
+		t.Errorf("Expected no P&L without cost basis, got %q", p.FormatPnL())
+	}
+
+	p.Set("1-2-0.5-BTC-ETH-4-0.5-1700000000|0.6")
+	if p.FormatPnL() != "+0.05 ETH (+20.00%)" {
+		t.Errorf("Unexpected P&L %q", p.FormatPnL())
+	}
+
+	p.Set("1-2-0.5-BTC-ETH-4-0.8-1700000000|0.6")
+	if p.FormatPnL() != "-0.1 ETH (-25.00%)" {
+		t.Errorf("Unexpected P&L %q", p.FormatPnL())
+	}
+
+	panelDataTurnOnLogs()
+}
+
+func TestPanelDataColor(t *testing.T) {
+	panelDataTurnOffLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	p := NewPanelData()
+	p.Init()
+	p.Set("1-2-0.5-BTC-ETH-4|0.6")
+
+	if _, ok := p.GetColor(); ok {
+		t.Error("Expected no color without rules")
+	}
+
+	p.SetColorRules(colorRulesType{
+		colorBand(1, JC.ColorNameGreen),
+		colorBand(0.5, JC.ColorNameYellow),
+	})
+
+	if color, ok := p.GetColor(); !ok || color != JC.ColorNameYellow {
+		t.Errorf("Expected yellow for rate 0.6, got %q", color)
+	}
+
+	p.Set("1-2-0.5-BTC-ETH-4|1.2")
+	if color, _ := p.GetColor(); color != JC.ColorNameGreen {
+		t.Errorf("Expected green for rate 1.2, got %q", color)
+	}
+
+	p.Set("1-2-0.5-BTC-ETH-4|0.1")
+	if _, ok := p.GetColor(); ok {
+		t.Error("Expected no color when no rule matches")
+	}
+
+	panelDataTurnOnLogs()
+}
+
 func TestPanelDataWatcherIntegration(t *testing.T) {
 	panelDataTurnOffLogs()
 	t.Setenv("FYNE_STORAGE", t.TempDir())
@@ -420,3 +570,203 @@ func TestPanelDataWatcherIntegration(t *testing.T) {
 
 	panelDataTurnOnLogs()
 }
+
+func TestPanelDataGetRecentRates(t *testing.T) {
+	eh := newTestExchangeHistory(t, &configType{})
+
+	previous := exchangeHistoryStorage
+	exchangeHistoryStorage = nil
+	defer func() { exchangeHistoryStorage = previous }()
+
+	RegisterExchangeCache()
+
+	p := NewPanelData()
+	p.Init()
+	p.Set("1-2-1-BTC-ETH-4|0.5")
+
+	if len(p.GetRecentRates(5)) != 0 {
+		t.Error("Expected no rates without history store")
+	}
+
+	exchangeHistoryStorage = eh
+	now := time.Now()
+	for i := range 4 {
+		eh.Record("1-2", JC.ToBigFloat(float64(i+1)), now.Add(time.Duration(i)*time.Minute))
+	}
+	eh.Record("2-1", JC.ToBigFloat(9), now)
+	eh.Flush()
+
+	rates := p.GetRecentRates(3)
+	if len(rates) != 3 || rates[0] != 2 || rates[2] != 4 {
+		t.Errorf("Expected [2 3 4], got %v", rates)
+	}
+}
+
+func TestPanelDataMatchWatcherRule(t *testing.T) {
+	eh := newTestExchangeHistory(t, &configType{})
+
+	previous := exchangeHistoryStorage
+	exchangeHistoryStorage = nil
+	defer func() { exchangeHistoryStorage = previous }()
+
+	RegisterExchangeCache()
+
+	p := &panelDataType{}
+	p.Init()
+	p.Set("1-2-1-BTC-ETH-4|110")
+
+	rate := NewWatcherRule(JC.WATCHER_METRIC_RATE, JC.WATCHER_OPERATOR_GREATER, 100)
+	change := NewWatcherRule(JC.WATCHER_METRIC_CHANGE_24H, JC.WATCHER_OPERATOR_GREATER, 5)
+
+	if !p.matchWatcherRule(rate, false) {
+		t.Error("Expected rate rule to match")
+	}
+	if p.matchWatcherRule(change, false) {
+		t.Error("Expected change rule to fail without history")
+	}
+
+	exchangeHistoryStorage = eh
+	eh.Record("1-2", JC.ToBigFloat(100), time.Now().Add(-12*time.Hour))
+	eh.Flush()
+
+	if got, ok := p.getRateChange(24 * time.Hour); !ok || got < 9.99 || got > 10.01 {
+		t.Errorf("Expected 10%% change, got %v (%v)", got, ok)
+	}
+	if !p.matchWatcherRule(change, false) {
+		t.Error("Expected change rule to match with history")
+	}
+
+	if p.matchWatcherRule(NewWatcherRule(JC.WATCHER_METRIC_RATE, 99, 100), false) {
+		t.Error("Expected invalid rule not to match")
+	}
+}
+
+func TestPanelDataMatchWatcherCrossing(t *testing.T) {
+	RegisterExchangeCache()
+
+	p := &panelDataType{}
+	p.Init()
+	p.Set("1-2-1-BTC-ETH-4|90")
+	p.Set("1-2-1-BTC-ETH-4|110")
+	p.SetStatus(JC.STATE_LOADED)
+
+	above := NewWatcherRule(JC.WATCHER_METRIC_RATE, JC.WATCHER_OPERATOR_CROSS_ABOVE, 100)
+	below := NewWatcherRule(JC.WATCHER_METRIC_RATE, JC.WATCHER_OPERATOR_CROSS_BELOW, 100)
+
+	if !p.matchWatcherRule(above, true) {
+		t.Error("Expected crossing above to match")
+	}
+	if p.matchWatcherRule(below, true) {
+		t.Error("Expected crossing below not to match")
+	}
+	if p.matchWatcherRule(above, false) {
+		t.Error("Expected consumed transition not to match")
+	}
+
+	p.Set("1-2-1-BTC-ETH-4|120")
+	if p.matchWatcherRule(above, true) {
+		t.Error("Expected no crossing while staying above")
+	}
+
+	p.Set("1-2-1-BTC-ETH-4|95")
+	if !p.matchWatcherRule(below, true) {
+		t.Error("Expected crossing below to match")
+	}
+}
+
+func TestPanelDataMatchWatcherMoves(t *testing.T) {
+	eh := newTestExchangeHistory(t, &configType{})
+
+	previous := exchangeHistoryStorage
+	exchangeHistoryStorage = eh
+	defer func() { exchangeHistoryStorage = previous }()
+
+	RegisterExchangeCache()
+
+	p := &panelDataType{}
+	p.Init()
+	p.Set("1-2-1-BTC-ETH-4|90")
+
+	now := time.Now()
+	eh.Record("1-2", JC.ToBigFloat(120), now.Add(-3*time.Hour))
+	eh.Record("1-2", JC.ToBigFloat(100), now.Add(-10*time.Minute))
+	eh.Flush()
+
+	window := NewWatcherRule(JC.WATCHER_METRIC_CHANGE_WINDOW, JC.WATCHER_OPERATOR_MOVES_BY, 5)
+	window.Window = 15
+
+	if !p.matchWatcherRule(window, false) {
+		t.Error("Expected -10% move within 15 minutes to match")
+	}
+
+	window.Value = 15
+	if p.matchWatcherRule(window, false) {
+		t.Error("Expected -10% move not to match a 15% threshold")
+	}
+
+	day := NewWatcherRule(JC.WATCHER_METRIC_CHANGE_24H, JC.WATCHER_OPERATOR_MOVES_BY, 20)
+	if !p.matchWatcherRule(day, false) {
+		t.Error("Expected -25% move over the last 24 hours to match")
+	}
+
+	since := NewWatcherRule(JC.WATCHER_METRIC_CHANGE_SINCE_ALERT, JC.WATCHER_OPERATOR_MOVES_BY, 20)
+	p.SetWatcherKey(NewWatcherKey().GenerateKeyFromRules(0, JC.WATCHER_LOGIC_AND, []watcherRuleType{since}, 5, 1, 0))
+
+	if p.matchWatcherRule(since, false) {
+		t.Error("Expected no match before a starting rate is stored")
+	}
+	if rate := p.UseWatcherKey().GetAlertRate(); rate != 90 {
+		t.Errorf("Expected current rate stored as the start, got %v", rate)
+	}
+
+	p.Set("1-2-1-BTC-ETH-4|60")
+	if !p.matchWatcherRule(since, false) {
+		t.Error("Expected -33% move since the stored rate to match")
+	}
+
+	wk := NewWatcherKey()
+	wk.GenerateKeyFromRules(1, JC.WATCHER_LOGIC_AND, []watcherRuleType{since}, 5, 1, int(now.Add(-time.Hour).UnixMicro()))
+	p.SetWatcherKey(wk.UpdateAlertRate(66))
+	if p.matchWatcherRule(since, false) {
+		t.Error("Expected -9% move since the last alert not to match a 20% threshold")
+	}
+}
+
+func TestPanelDataFormatLocale(t *testing.T) {
+	panelDataTurnOffLogs()
+	defer panelDataTurnOnLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	JC.RegisterLocaleManager().Init()
+	defer JC.UseLocale().SetLocale(JC.LOCALE_ENGLISH)
+
+	p := NewPanelData()
+	p.Init()
+	p.Set("1-2-1000.5-BTC-ETH-4|1500.25")
+
+	tests := []struct {
+		code     string
+		title    string
+		subtitle string
+		content  string
+	}{
+		{JC.LOCALE_ENGLISH, "1,000.5 BTC to ETH", "1 BTC = 1,500.25 ETH", "1,501,000.12 ETH"},
+		{JC.LOCALE_GERMAN, "1.000,5 BTC zu ETH", "1 BTC = 1.500,25 ETH", "1.501.000,12 ETH"},
+		{JC.LOCALE_INDONESIAN, "1.000,5 BTC ke ETH", "1 BTC = 1.500,25 ETH", "1.501.000,12 ETH"},
+	}
+
+	for _, tt := range tests {
+		JC.UseLocale().SetLocale(tt.code)
+
+		if got := p.FormatTitle(); got != tt.title {
+			t.Errorf("[%s] FormatTitle mismatch, got %q want %q", tt.code, got, tt.title)
+		}
+		if got := p.FormatSubtitle(); got != tt.subtitle {
+			t.Errorf("[%s] FormatSubtitle mismatch, got %q want %q", tt.code, got, tt.subtitle)
+		}
+		if got := p.FormatContent(); got != tt.content {
+			t.Errorf("[%s] FormatContent mismatch, got %q want %q", tt.code, got, tt.content)
+		}
+	}
+}
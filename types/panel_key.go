@@ -1,9 +1,11 @@
 package types
 
 import (
+	"math"
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
 
 	JC "jxwatcher/core"
 )
@@ -93,6 +95,35 @@ func (p *panelKeyType) GenerateKeyFromPanel(panel panelType, rate *big.Float) st
 	b.WriteString(JC.STRING_PIPE)
 	b.WriteString(rate.Text('g', -1))
 	p.value = b.String()
+
+	if panel.CostBasis > 0 {
+		acquired, _ := ParseAcquiredDate(panel.Acquired)
+		p.SetHolding(panel.CostBasis, acquired)
+	}
+
+	return p.value
+}
+
+// Holdings carry their cost basis and acquisition unix time as two extra segments before the rate
+func (p *panelKeyType) SetHolding(costBasis float64, acquired int64) string {
+	pkm := strings.SplitN(p.value, JC.STRING_PIPE, 2)
+	pkv := strings.Split(pkm[0], JC.STRING_MINUS)
+	if len(pkv) > 6 {
+		pkv = pkv[:6]
+	}
+
+	if costBasis > 0 {
+		pkv = append(pkv,
+			strconv.FormatFloat(costBasis, 'f', -1, 64),
+			strconv.FormatInt(max(acquired, 0), 10),
+		)
+	}
+
+	p.value = strings.Join(pkv, JC.STRING_MINUS)
+	if len(pkm) > 1 {
+		p.value += JC.STRING_PIPE + pkm[1]
+	}
+
 	return p.value
 }
 
@@ -103,7 +134,7 @@ func (p *panelKeyType) Validate() bool {
 	}
 
 	pkt := strings.Split(pkv[0], JC.STRING_MINUS)
-	if len(pkt) != 6 {
+	if len(pkt) != 6 && len(pkt) != 8 {
 		return false
 	}
 
@@ -122,6 +153,8 @@ func (p *panelKeyType) GetPanel() panelType {
 		Value:        p.GetSourceValueFloat(),
 		SourceSymbol: p.GetSourceSymbolString(),
 		TargetSymbol: p.GetTargetSymbolString(),
+		CostBasis:    p.GetCostBasisFloat(),
+		Acquired:     p.GetAcquiredString(),
 	}
 }
 
@@ -339,6 +372,104 @@ func (p *panelKeyType) GetDecimalsString() string {
 	return JC.STRING_EMPTY
 }
 
+func (p *panelKeyType) IsHolding() bool {
+	return p.GetCostBasisFloat() > 0
+}
+
+func (p *panelKeyType) GetCostBasisFloat() float64 {
+	value, err := strconv.ParseFloat(p.GetCostBasisString(), 64)
+	if err == nil && value > 0 {
+		return value
+	}
+
+	return 0
+}
+
+func (p *panelKeyType) GetCostBasisString() string {
+
+	pkm := strings.Split(p.value, JC.STRING_PIPE)
+	pkv := strings.Split(pkm[0], JC.STRING_MINUS)
+
+	if len(pkv) > 7 {
+		return pkv[6]
+	}
+
+	return JC.STRING_EMPTY
+}
+
+func (p *panelKeyType) GetAcquiredInt() int64 {
+
+	pkm := strings.Split(p.value, JC.STRING_PIPE)
+	pkv := strings.Split(pkm[0], JC.STRING_MINUS)
+
+	if len(pkv) > 7 {
+		acquired, err := strconv.ParseInt(pkv[7], 10, 64)
+		if err == nil && acquired > 0 {
+			return acquired
+		}
+	}
+
+	return 0
+}
+
+func (p *panelKeyType) GetAcquiredString() string {
+	acquired := p.GetAcquiredInt()
+	if acquired == 0 {
+		return JC.STRING_EMPTY
+	}
+
+	return time.Unix(acquired, 0).Format(panelAcquiredLayout)
+}
+
+func (p *panelKeyType) GetCostValueFloat() float64 {
+	return p.GetCostBasisFloat() * p.GetSourceValueFloat()
+}
+
+func (p *panelKeyType) GetHoldingValueFloat() float64 {
+	nv := new(big.Float).SetPrec(256).Mul(p.GetValueFloat(), JC.ToBigFloat(p.GetSourceValueFloat()))
+	f64, _ := nv.Float64()
+	return f64
+}
+
+func (p *panelKeyType) GetPnLFloat() float64 {
+	return p.GetHoldingValueFloat() - p.GetCostValueFloat()
+}
+
+func (p *panelKeyType) GetPnLPercentFloat() float64 {
+	cost := p.GetCostValueFloat()
+	if cost == 0 {
+		return 0
+	}
+
+	return p.GetPnLFloat() / cost * 100
+}
+
+func (p *panelKeyType) GetPnLFormattedString() string {
+	pnl := p.GetPnLFloat()
+	frac := max(int(p.GetDecimalsInt()), 2)
+	if math.Abs(pnl) >= 1 {
+		frac = 2
+	}
+
+	sign := JC.STRING_PLUS
+	if pnl < 0 {
+		sign = JC.STRING_MINUS
+	}
+
+	return sign + JC.FormatNumberWithCommas(math.Abs(pnl), frac)
+}
+
+func (p *panelKeyType) GetPnLPercentFormattedString() string {
+	pct := p.GetPnLPercentFloat()
+
+	sign := JC.STRING_PLUS
+	if pct < 0 {
+		sign = JC.STRING_MINUS
+	}
+
+	return sign + JC.FormatDecimal(math.Abs(pct), 2) + JC.STRING_PERCENTAGE
+}
+
 func NewPanelKey() *panelKeyType {
 	return &panelKeyType{}
 }
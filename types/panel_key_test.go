@@ -2,6 +2,7 @@ package types
 
 import (
 	"log"
+	"math"
 	"math/big"
 	"os"
 	"testing"
@@ -79,3 +80,83 @@ func TestPanelKeyComparison(t *testing.T) {
 	}
 	panelKeyTurnOnLogs()
 }
+
+func TestPanelKeyHolding(t *testing.T) {
+	panelKeyTurnOffLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	pk := &panelKeyType{value: "1-2-0.5-BTC-ETH-4|15.5"}
+	if pk.IsHolding() {
+		t.Error("Expected plain panel key not to be a holding")
+	}
+
+	raw := pk.SetHolding(10, 1700000000)
+	if raw != "1-2-0.5-BTC-ETH-4-10-1700000000|15.5" {
+		t.Fatalf("Unexpected holding key: %s", raw)
+	}
+	if !pk.Validate() {
+		t.Error("Expected holding key to be valid")
+	}
+	if !pk.IsHolding() || pk.GetCostBasisFloat() != 10 || pk.GetAcquiredInt() != 1700000000 {
+		t.Error("Holding parsing failed")
+	}
+	if pk.GetDecimalsInt() != 4 || pk.GetValueString() != "15.5" {
+		t.Error("Expected holding segments not to disturb decimals and value")
+	}
+
+	if pk.GetCostValueFloat() != 5 || pk.GetHoldingValueFloat() != 7.75 {
+		t.Errorf("Unexpected cost %v or value %v", pk.GetCostValueFloat(), pk.GetHoldingValueFloat())
+	}
+	if pk.GetPnLFloat() != 2.75 || math.Abs(pk.GetPnLPercentFloat()-55) > 1e-9 {
+		t.Errorf("Unexpected P&L %v (%v%%)", pk.GetPnLFloat(), pk.GetPnLPercentFloat())
+	}
+	if pk.GetPnLFormattedString() != "+2.75" || pk.GetPnLPercentFormattedString() != "+55.00%" {
+		t.Errorf("Unexpected P&L format %s %s", pk.GetPnLFormattedString(), pk.GetPnLPercentFormattedString())
+	}
+
+	pk.UpdateValue(big.NewFloat(8))
+	if !pk.IsHolding() || pk.GetPnLPercentFormattedString() != "-20.00%" {
+		t.Errorf("Expected loss after update, got %s", pk.GetPnLPercentFormattedString())
+	}
+
+	if pk.SetHolding(0, 0) != "1-2-0.5-BTC-ETH-4|8" || pk.IsHolding() {
+		t.Errorf("Expected holding to be cleared, got %s", pk.GetRawValue())
+	}
+
+	bad := &panelKeyType{value: "1-2-0.5-BTC-ETH-4-10|15.5"}
+	if bad.Validate() {
+		t.Error("Expected key with 7 segments to be invalid")
+	}
+	panelKeyTurnOnLogs()
+}
+
+func TestPanelKeyHoldingFromPanel(t *testing.T) {
+	panelKeyTurnOffLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	panel := panelType{
+		Source:       1,
+		Target:       2,
+		Value:        0.5,
+		Decimals:     4,
+		SourceSymbol: "BTC",
+		TargetSymbol: "ETH",
+		CostBasis:    12.5,
+		Acquired:     "2024-03-15",
+	}
+
+	pk := &panelKeyType{}
+	pk.GenerateKeyFromPanel(panel, JC.ToBigFloat(-1))
+
+	if !pk.Validate() || !pk.IsHolding() {
+		t.Fatalf("Expected valid holding key, got %s", pk.GetRawValue())
+	}
+
+	np := pk.GetPanel()
+	if np.CostBasis != 12.5 || np.Acquired != "2024-03-15" {
+		t.Errorf("Expected holding to survive round trip, got %v %q", np.CostBasis, np.Acquired)
+	}
+	panelKeyTurnOnLogs()
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	JC "jxwatcher/core"
+)
+
+type panelSummaryType struct {
+	ID      string
+	Title   string
+	Content string
+	Fired   bool
+}
+
+// Label for menus, panels without a loaded rate show their title only
+func (ps panelSummaryType) Format() string {
+	if ps.Content == JC.STRING_EMPTY {
+		return ps.Title
+	}
+
+	return ps.Title + ": " + ps.Content
+}
+
+// The first panels in display order, a panel has fired once its watcher sent an alert
+func GetPanelSummaries(limit int) []panelSummaryType {
+	summaries := []panelSummaryType{}
+
+	if UsePanelMaps() == nil {
+		return summaries
+	}
+
+	for _, pot := range UsePanelMaps().GetData() {
+		if limit > 0 && len(summaries) >= limit {
+			break
+		}
+
+		pdt := UsePanelMaps().GetDataByID(pot.GetID())
+		if pdt == nil {
+			continue
+		}
+
+		ps := panelSummaryType{
+			ID:    pdt.GetID(),
+			Title: pdt.FormatTitle(),
+			Fired: isWatcherFired(pdt),
+		}
+
+		if pdt.IsStatus(JC.STATE_LOADED) {
+			ps.Content = pdt.FormatContent()
+		}
+
+		summaries = append(summaries, ps)
+	}
+
+	return summaries
+}
+
+func HasFiredWatchers() bool {
+	if UsePanelMaps() == nil {
+		return false
+	}
+
+	for _, pot := range UsePanelMaps().GetData() {
+		if pdt := UsePanelMaps().GetDataByID(pot.GetID()); pdt != nil && isWatcherFired(pdt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isWatcherFired(pdt PanelData) bool {
+	wkt := pdt.UseWatcherKey()
+	return !wkt.IsDisabled() && wkt.GetSent() > 0
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+
+	JC "jxwatcher/core"
+)
+
+type panelSummaryNullWriter struct{}
+
+func (panelSummaryNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func panelSummaryTurnOffLogs() {
+	log.SetOutput(panelSummaryNullWriter{})
+}
+
+func panelSummaryTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func TestGetPanelSummaries(t *testing.T) {
+	panelSummaryTurnOffLogs()
+	defer panelSummaryTurnOnLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	previousCache := exchangeCacheStorage
+	exchangeCacheStorage = &exchangeDataCacheType{}
+	exchangeCacheStorage.Init()
+	defer func() {
+		exchangeCacheStorage = previousCache
+	}()
+
+	UsePanelMaps().Init()
+	defer UsePanelMaps().Init()
+
+	loaded := UsePanelMaps().Append("1-825-0.5-BTC-USDT-2|60000")
+	loaded.SetStatus(JC.STATE_LOADED)
+	loaded.SetWatcherKey(NewWatcherKey().GenerateKeyFromArgs(0, JC.WATCHER_OPERATOR_GREATER, 70000, 3, 1, 0))
+
+	fetching := UsePanelMaps().Append("1027-825-2-ETH-USDT-2|2000")
+	fetching.SetStatus(JC.STATE_FETCHING_NEW)
+
+	UsePanelMaps().Append("2-825-1-LTC-USDT-2|80")
+
+	summaries := GetPanelSummaries(2)
+	if len(summaries) != 2 {
+		t.Fatalf("Expected 2 summaries, got %d", len(summaries))
+	}
+
+	if got := summaries[0].Format(); got != "0.5 BTC to USDT: 30,000 USDT" {
+		t.Errorf("Expected title and content, got %q", got)
+	}
+	if got := summaries[1].Format(); got != "2 ETH to USDT" {
+		t.Errorf("Expected title only while fetching, got %q", got)
+	}
+	if summaries[0].Fired || HasFiredWatchers() {
+		t.Error("Expected no fired watchers before any alert was sent")
+	}
+
+	if len(GetPanelSummaries(0)) != 3 {
+		t.Error("Expected no limit to list every panel")
+	}
+
+	loaded.SetWatcherKey(NewWatcherKey().GenerateKeyFromArgs(1, JC.WATCHER_OPERATOR_GREATER, 70000, 3, 1, 0))
+	if !GetPanelSummaries(1)[0].Fired || !HasFiredWatchers() {
+		t.Error("Expected a watcher that sent an alert to count as fired")
+	}
+
+	loaded.SetWatcherKey(NewWatcherKey().GenerateKeyFromArgs(JC.WATCHER_DISABLED, JC.WATCHER_OPERATOR_GREATER, 70000, 3, 1, 0))
+	if HasFiredWatchers() {
+		t.Error("Expected disabled watchers not to count as fired")
+	}
+}
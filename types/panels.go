@@ -93,6 +93,22 @@ func (p *panelsType) parseJSON(data []byte) error {
 		if ts, e := jsonparser.GetString(value, "target_symbol"); e == nil {
 			panel.TargetSymbol = ts
 		}
+		if pv, e := jsonparser.GetString(value, "provider"); e == nil {
+			panel.Provider = pv
+		}
+		if cb, e := jsonparser.GetFloat(value, "cost_basis"); e == nil {
+			panel.CostBasis = cb
+		}
+		if aq, e := jsonparser.GetString(value, "acquired"); e == nil {
+			panel.Acquired = aq
+		}
+		if cl, _, _, e := jsonparser.Get(value, "colors"); e == nil {
+			if rules, err := ParseColorRules(cl); err == nil {
+				panel.Colors = &rules
+			} else {
+				JC.Logln("Ignoring invalid panel colors:", string(cl))
+			}
+		}
 
 		if rate, e := jsonparser.GetFloat(value, "target_rate"); e == nil {
 			panel.Rate = rate
@@ -112,6 +128,12 @@ func (p *panelsType) parseJSON(data []byte) error {
 		if ts, e := jsonparser.GetInt(value, "timestamp"); e == nil {
 			panel.Timestamp = int(ts)
 		}
+		if ar, e := jsonparser.GetFloat(value, "alert_rate"); e == nil {
+			panel.AlertRate = ar
+		}
+		if cond, _, _, e := jsonparser.Get(value, "conditions"); e == nil {
+			panel.Conditions = p.parseConditions(cond)
+		}
 
 		// This is probably from old value or invalid json. Give default value
 		if panel.Sent == 0 && panel.Operator == 0 && panel.Rate == 0 && panel.Limit == 0 && panel.Duration == 0 && panel.Timestamp == 0 {
@@ -133,6 +155,40 @@ func (p *panelsType) parseJSON(data []byte) error {
 	return nil
 }
 
+func (p *panelsType) parseConditions(data []byte) *watcherConditionsType {
+	conditions := watcherConditionsType{}
+
+	if logic, e := jsonparser.GetInt(data, "logic"); e == nil {
+		conditions.Logic = int(logic)
+	}
+
+	jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		rule := watcherRuleType{}
+
+		if metric, e := jsonparser.GetInt(value, "metric"); e == nil {
+			rule.Metric = int(metric)
+		}
+		if op, e := jsonparser.GetInt(value, "operator"); e == nil {
+			rule.Operator = int(op)
+		}
+		if val, e := jsonparser.GetFloat(value, "value"); e == nil {
+			rule.Value = val
+		}
+		if window, e := jsonparser.GetInt(value, "window"); e == nil {
+			rule.Window = int(window)
+		}
+
+		conditions.Rules = append(conditions.Rules, rule)
+	}, "rules")
+
+	if !conditions.IsValid() {
+		JC.Logln("Ignoring invalid watcher conditions:", string(data))
+		return nil
+	}
+
+	return &conditions
+}
+
 func (p *panelsType) save(maps *panelsMapType) bool {
 	panelsMu.RLock()
 	defer panelsMu.RUnlock()
@@ -158,6 +214,10 @@ func (p *panelsType) save(maps *panelsMapType) bool {
 			Decimals:     pk.GetDecimalsInt(),
 			SourceSymbol: pk.GetSourceSymbolString(),
 			TargetSymbol: pk.GetTargetSymbolString(),
+			Provider:     pdt.GetProvider(),
+
+			CostBasis: pk.GetCostBasisFloat(),
+			Acquired:  pk.GetAcquiredString(),
 
 			Rate:      pw.GetRate(),
 			Sent:      pw.GetSent(),
@@ -165,6 +225,16 @@ func (p *panelsType) save(maps *panelsMapType) bool {
 			Limit:     pw.GetLimit(),
 			Duration:  pw.GetDuration(),
 			Timestamp: pw.GetTimestamp(),
+			AlertRate: pw.GetAlertRate(),
+
+			Conditions: &watcherConditionsType{
+				Logic: pw.GetLogic(),
+				Rules: pw.GetRules(),
+			},
+		}
+
+		if rules := pdt.GetColorRules(); len(rules) != 0 {
+			panel.Colors = &rules
 		}
 
 		np = append(np, panel)
@@ -220,7 +290,165 @@ func (p *panelsType) convert(maps *panelsMapType) {
 
 		npdt := maps.Append(pko.GenerateKeyFromPanel(*pp, JC.ToBigFloat(-1)))
 		npdt.SetWatcherKey(wko.GetRawValue())
+		npdt.SetProvider(pp.Provider)
+		if pp.Colors != nil {
+			npdt.SetColorRules(*pp.Colors)
+		}
+	}
+}
+
+type panelsDiffType struct {
+	Added   []PanelData
+	Changed []PanelData
+	Removed []string
+	Moved   bool
+}
+
+func (d *panelsDiffType) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0 && !d.Moved
+}
+
+// Panels whose config is unchanged are kept as is, so their rates and widgets survive the reload
+func (p *panelsType) diff(maps *panelsMapType) *panelsDiffType {
+	panelsMu.RLock()
+	defer panelsMu.RUnlock()
+
+	result := &panelsDiffType{}
+
+	current := maps.GetData()
+	used := make([]bool, len(current))
+	order := make([]PanelData, len(*p))
+	keys := make([]string, len(*p))
+
+	for i := range *p {
+		pp := &(*p)[i]
+
+		pko := panelKeyType{}
+		pko.GenerateKeyFromPanel(*pp, JC.ToBigFloat(-1))
+
+		pp.SourceSymbol = maps.GetSymbolById(pko.GetSourceCoinString())
+		pp.TargetSymbol = maps.GetSymbolById(pko.GetTargetCoinString())
+
+		keys[i] = pko.GenerateKeyFromPanel(*pp, JC.ToBigFloat(-1))
+	}
+
+	for i, pk := range keys {
+		for j, pdt := range current {
+			if !used[j] && pdt.UsePanelKey().IsConfigMatching(pk) {
+				used[j] = true
+				order[i] = pdt
+				break
+			}
+		}
+	}
+
+	// Same pair with edited value or decimals, reconfigure in place to keep the current rate
+	reconfigured := make([]bool, len(keys))
+	for i, pk := range keys {
+		if order[i] != nil {
+			continue
+		}
+
+		npk := panelKeyType{value: pk}
+		for j, pdt := range current {
+			pko := pdt.UsePanelKey()
+			if used[j] || pko.GetSourceCoinInt() != npk.GetSourceCoinInt() || pko.GetTargetCoinInt() != npk.GetTargetCoinInt() {
+				continue
+			}
+
+			used[j] = true
+			pdt.Reconfigure(pk)
+			order[i] = pdt
+			reconfigured[i] = true
+			break
+		}
+	}
+
+	added := make([]bool, len(keys))
+	for i, pk := range keys {
+		if order[i] != nil {
+			continue
+		}
+
+		order[i] = maps.Append(pk)
+		added[i] = true
+	}
+
+	for j, pdt := range current {
+		if used[j] {
+			continue
+		}
+
+		if maps.Remove(pdt.GetID()) {
+			result.Removed = append(result.Removed, pdt.GetID())
+		}
+	}
+
+	for i, pdt := range order {
+		changed := p.apply(pdt, (*p)[i])
+
+		switch {
+		case added[i]:
+			result.Added = append(result.Added, pdt)
+		case reconfigured[i] || changed:
+			result.Changed = append(result.Changed, pdt)
+		}
+
+		if maps.Move(pdt.GetID(), i) {
+			result.Moved = true
+		}
+	}
+
+	return result
+}
+
+func (p *panelsType) apply(pdt PanelData, panel panelType) bool {
+	changed := false
+
+	wko := watcherKeyType{}
+	wko.GenerateKeyFromPanel(panel)
+	if pdt.UseWatcherKey().GetRawValue() != wko.GetRawValue() {
+		pdt.SetWatcherKey(wko.GetRawValue())
+		changed = true
+	}
+
+	if pdt.GetProvider() != panel.Provider {
+		pdt.SetProvider(panel.Provider)
+		changed = true
+	}
+
+	colors := colorRulesType{}
+	if panel.Colors != nil {
+		colors = *panel.Colors
+	}
+
+	if !p.isEqualColors(pdt.GetColorRules(), colors) {
+		pdt.SetColorRules(colors)
+		changed = true
+	}
+
+	return changed
+}
+
+func (p *panelsType) isEqualColors(a colorRulesType, b colorRulesType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	isEqual := func(x *float64, y *float64) bool {
+		if x == nil || y == nil {
+			return x == y
+		}
+		return *x == *y
 	}
+
+	for i := range a {
+		if a[i].Color != b[i].Color || a[i].Sign != b[i].Sign || !isEqual(a[i].Min, b[i].Min) || !isEqual(a[i].Max, b[i].Max) {
+			return false
+		}
+	}
+
+	return true
 }
 
 func PanelsInit() {
@@ -243,6 +471,20 @@ func SavePanels() bool {
 	return panels.save(UsePanelMaps())
 }
 
+// ReloadPanels applies panels.json edited outside the app, the current panels are kept when it fails to parse
+func ReloadPanels(data []byte) (*panelsDiffType, error) {
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("panels.json is not valid json")
+	}
+
+	panels := panelsType{}
+	if err := panels.parseJSON(data); err != nil {
+		return nil, err
+	}
+
+	return panels.diff(UsePanelMaps()), nil
+}
+
 func RemovePanel(uuid string) bool {
 	return UsePanelMaps().Remove(uuid)
 }
@@ -31,6 +31,9 @@ func (pc *panelsMapType) SetData(data []PanelData) {
 		if !pk.HasParent() {
 			pk.SetParent(pc)
 		}
+		if pk.GetID() == JC.STRING_EMPTY {
+			pk.SetID(JC.CreateUUID())
+		}
 	}
 	pc.data = data
 }
@@ -117,6 +120,7 @@ func (pc *panelsMapType) Append(pk string) PanelData {
 
 	ref := &panelDataType{}
 	ref.Init()
+	ref.SetID(JC.CreateUUID())
 	ref.Update(pk)
 	ref.SetParent(pc)
 	ref.SetStatus(JC.STATE_FETCHING_NEW)
@@ -246,6 +250,18 @@ func (pc *panelsMapType) IsEmpty() bool {
 	return len(pc.data) == 0
 }
 
+func (pc *panelsMapType) HasHoldings() bool {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+
+	for _, pdt := range pc.data {
+		if pdt.UsePanelKey().IsHolding() {
+			return true
+		}
+	}
+	return false
+}
+
 func (pc *panelsMapType) TotalData() int {
 	pc.mu.RLock()
 	defer pc.mu.RUnlock()
@@ -264,6 +280,19 @@ func (pc *panelsMapType) ChangeStatus(newStatus int, shouldChange func(pdt Panel
 	}
 }
 
+// Panels without a rate yet turn into errors when a fetch failed, a skipped fetch leaves them as they are
+func (pc *panelsMapType) ApplyFetchStatus(status int) bool {
+	switch status {
+	case JC.STATUS_NETWORK_ERROR, JC.STATUS_CONFIG_ERROR:
+		pc.ChangeStatus(JC.STATE_ERROR, func(pdt PanelData) bool {
+			return pdt.UsePanelKey().IsValueMatchingFloat(0, JC.STRING_LESS) || pdt.IsStatus(JC.STATE_LOADING)
+		})
+		return true
+	}
+
+	return false
+}
+
 func (pc *panelsMapType) Hydrate(data []PanelData) {
 
 	dataLen := pc.TotalData()
@@ -50,6 +50,9 @@ func TestPanelsMapInitAndAppend(t *testing.T) {
 	if !ref.HasParent() {
 		t.Error("Expected PanelData to have parent set")
 	}
+	if ref.GetID() == JC.STRING_EMPTY || pm.GetDataByID(ref.GetID()) == nil {
+		t.Error("Expected PanelData to have an ID assigned")
+	}
 	if ref.GetStatus() != JC.STATE_FETCHING_NEW {
 		t.Errorf("Expected status FETCHING_NEW, got %d", ref.GetStatus())
 	}
@@ -164,3 +167,34 @@ func TestPanelsMapHydrateAndSerialize(t *testing.T) {
 	}
 	panelsMapTurnOnLogs()
 }
+
+func TestPanelsMapApplyFetchStatus(t *testing.T) {
+	panelsMapTurnOffLogs()
+	defer panelsMapTurnOnLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	pm := &panelsMapType{}
+	pm.Init()
+	cached := pm.Append("1-2-0.5-BTC-ETH-4|15.5")
+	pending := pm.Append("1-3-0.5-BTC-XRP-4|25.5")
+	cached.SetStatus(JC.STATE_LOADED)
+	pending.SetStatus(JC.STATE_LOADING)
+
+	if pm.ApplyFetchStatus(JC.DetectHTTPResponse(JC.NETWORKING_QUOTA_EXCEEDED)) {
+		t.Error("Expected a throttled fetch to leave the panels alone")
+	}
+	if cached.GetStatus() != JC.STATE_LOADED || pending.GetStatus() != JC.STATE_LOADING {
+		t.Errorf("Expected statuses untouched, got %d and %d", cached.GetStatus(), pending.GetStatus())
+	}
+	if cached.UsePanelKey().GetValueString() != "15.5" {
+		t.Errorf("Expected cached value kept, got %s", cached.UsePanelKey().GetValueString())
+	}
+
+	if !pm.ApplyFetchStatus(JC.DetectHTTPResponse(JC.NETWORKING_ERROR_CONNECTION)) {
+		t.Error("Expected a network error to flag the panels")
+	}
+	if cached.GetStatus() != JC.STATE_LOADED || pending.GetStatus() != JC.STATE_ERROR {
+		t.Errorf("Expected only the pending panel flagged, got %d and %d", cached.GetStatus(), pending.GetStatus())
+	}
+}
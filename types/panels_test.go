@@ -126,7 +126,11 @@ func TestPanelsTypeParseJSON(t *testing.T) {
 			"value": 0.5,
 			"decimals": 4,
 			"source_symbol": "BTC",
-			"target_symbol": "ETH"
+			"target_symbol": "ETH",
+			"colors": [
+				{"min": 1, "color": "green"},
+				{"color": "bogus"}
+			]
 		}
 	]`)
 
@@ -158,6 +162,260 @@ func TestPanelsTypeParseJSON(t *testing.T) {
 	if panel.TargetSymbol != "ETH" {
 		t.Errorf("Expected TargetSymbol=ETH, got %s", panel.TargetSymbol)
 	}
+	if panel.Colors == nil || len(*panel.Colors) != 1 || (*panel.Colors)[0].Color != "green" {
+		t.Errorf("Expected one valid color rule, got %+v", panel.Colors)
+	}
 
 	panelsTurnOnLogs()
 }
+
+func TestPanelsTypeParseJSONHoldings(t *testing.T) {
+	panelsTurnOffLogs()
+	defer panelsTurnOnLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	raw := []byte(`[
+		{
+			"source": 1,
+			"target": 2,
+			"value": 0.5,
+			"decimals": 4,
+			"source_symbol": "BTC",
+			"target_symbol": "ETH",
+			"cost_basis": 12.5,
+			"acquired": "2024-03-15"
+		}
+	]`)
+
+	p := &panelsType{}
+	if err := p.parseJSON(raw); err != nil {
+		t.Fatalf("Unexpected error parsing JSON: %v", err)
+	}
+
+	if len(*p) != 1 {
+		t.Fatalf("Expected 1 panel, got %d", len(*p))
+	}
+	if panel := (*p)[0]; panel.CostBasis != 12.5 || panel.Acquired != "2024-03-15" {
+		t.Errorf("Expected holding 12.5 from 2024-03-15, got %v %q", panel.CostBasis, panel.Acquired)
+	}
+}
+
+func TestPanelsTypeParseJSONConditions(t *testing.T) {
+	panelsTurnOffLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	raw := []byte(`[
+		{
+			"source": 1,
+			"target": 2,
+			"value": 1,
+			"decimals": 4,
+			"source_symbol": "BTC",
+			"target_symbol": "ETH",
+			"conditions": {
+				"logic": 1,
+				"rules": [
+					{"metric": 0, "operator": 1, "value": 90},
+					{"metric": 1, "operator": 2, "value": 5}
+				]
+			}
+		},
+		{
+			"source": 1,
+			"target": 3,
+			"value": 1,
+			"decimals": 4,
+			"source_symbol": "BTC",
+			"target_symbol": "SOL",
+			"conditions": {
+				"logic": 0,
+				"rules": [
+					{"metric": 0, "operator": 9, "value": 90}
+				]
+			}
+		}
+	]`)
+
+	p := &panelsType{}
+	if err := p.parseJSON(raw); err != nil {
+		t.Fatalf("Unexpected error parsing JSON: %v", err)
+	}
+
+	if len(*p) != 2 {
+		t.Fatalf("Expected 2 panels, got %d", len(*p))
+	}
+
+	cond := (*p)[0].Conditions
+	if cond == nil || cond.Logic != 1 || len(cond.Rules) != 2 {
+		t.Fatalf("Expected parsed conditions, got %+v", cond)
+	}
+	if cond.Rules[1].Metric != 1 || cond.Rules[1].Value != 5 {
+		t.Errorf("Unexpected second rule %+v", cond.Rules[1])
+	}
+
+	if (*p)[1].Conditions != nil {
+		t.Error("Expected invalid conditions to be dropped")
+	}
+
+	wk := NewWatcherKey()
+	wk.GenerateKeyFromPanel((*p)[0])
+	if wk.GetLogic() != 1 || len(wk.GetRules()) != 2 {
+		t.Errorf("Expected conditions to be carried into watcher key, got %s", wk.GetRawValue())
+	}
+
+	panelsTurnOnLogs()
+}
+
+func TestPanelsTypeParseJSONAlertRate(t *testing.T) {
+	panelsTurnOffLogs()
+	defer panelsTurnOnLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	raw := []byte(`[
+		{
+			"source": 1,
+			"target": 2,
+			"value": 1,
+			"decimals": 4,
+			"source_symbol": "BTC",
+			"target_symbol": "ETH",
+			"conditions": {
+				"logic": 0,
+				"rules": [
+					{"metric": 0, "operator": 1, "value": 90},
+					{"metric": 1, "operator": 2, "value": 5}
+				]
+			},
+			"alert_rate": 95.5
+		}
+	]`)
+
+	p := &panelsType{}
+	if err := p.parseJSON(raw); err != nil {
+		t.Fatalf("Unexpected error parsing JSON: %v", err)
+	}
+
+	if len(*p) != 1 || (*p)[0].AlertRate != 95.5 {
+		t.Fatalf("Expected alert rate 95.5, got %+v", *p)
+	}
+
+	wk := NewWatcherKey()
+	wk.GenerateKeyFromPanel((*p)[0])
+	if wk.GetAlertRate() != 95.5 {
+		t.Errorf("Expected alert rate to be carried into watcher key, got %s", wk.GetRawValue())
+	}
+}
+
+func TestPanelsTypeDiffKeepsUnchangedPanels(t *testing.T) {
+	panelsTurnOffLogs()
+	defer panelsTurnOnLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	previousCache := exchangeCacheStorage
+	exchangeCacheStorage = &exchangeDataCacheType{}
+	exchangeCacheStorage.Init()
+	defer func() {
+		exchangeCacheStorage = previousCache
+	}()
+
+	cm := &cryptosMapType{}
+	cm.Init()
+	cm.Insert("1", "1|BTC - Bitcoin")
+	cm.Insert("2", "2|ETH - Ethereum")
+	cm.Insert("3", "3|SOL - Solana")
+
+	pm := &panelsMapType{}
+	pm.Init()
+	pm.SetMaps(cm)
+
+	initial := panelsType{}
+	initial.parseJSON([]byte(`[
+		{"source":1,"target":2,"value":0.5,"decimals":4,"source_symbol":"BTC","target_symbol":"ETH"},
+		{"source":2,"target":1,"value":1,"decimals":4,"source_symbol":"ETH","target_symbol":"BTC"},
+		{"source":3,"target":1,"value":1,"decimals":4,"source_symbol":"SOL","target_symbol":"BTC"}
+	]`))
+	initial.convert(pm)
+
+	kept := pm.GetDataByIndex(0)
+	kept.Set("1-2-0.5-BTC-ETH-4|15.5")
+	edited := pm.GetDataByIndex(1)
+	edited.Set("2-1-1-ETH-BTC-4|0.05")
+	removed := pm.GetDataByIndex(2)
+
+	panels := panelsType{}
+	err := panels.parseJSON([]byte(`[
+		{"source":3,"target":2,"value":2,"decimals":4,"source_symbol":"SOL","target_symbol":"ETH"},
+		{"source":2,"target":1,"value":3,"decimals":4,"source_symbol":"ETH","target_symbol":"BTC","provider":"kraken"},
+		{"source":1,"target":2,"value":0.5,"decimals":4,"source_symbol":"BTC","target_symbol":"ETH"}
+	]`))
+	if err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+
+	diff := panels.diff(pm)
+
+	if len(diff.Added) != 1 || diff.Added[0].UsePanelKey().GetSourceCoinInt() != 3 || diff.Added[0].UsePanelKey().GetTargetCoinInt() != 2 {
+		t.Errorf("Expected SOL/ETH panel added, got %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0] != removed.GetID() {
+		t.Errorf("Expected SOL/BTC panel removed, got %v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0] != edited {
+		t.Errorf("Expected ETH/BTC panel changed in place, got %v", diff.Changed)
+	}
+	if !diff.Moved {
+		t.Error("Expected panels to be reordered")
+	}
+
+	if edited.Get() != "2-1-3-ETH-BTC-4|0.05" {
+		t.Errorf("Expected edited value with the current rate kept, got %q", edited.Get())
+	}
+	if edited.GetProvider() != "kraken" {
+		t.Errorf("Expected provider kraken, got %q", edited.GetProvider())
+	}
+
+	if kept.Get() != "1-2-0.5-BTC-ETH-4|15.5" {
+		t.Errorf("Expected unchanged panel to keep its key, got %q", kept.Get())
+	}
+
+	data := pm.GetData()
+	if len(data) != 3 || data[1] != edited || data[2] != kept {
+		t.Error("Expected panels to follow the file order")
+	}
+
+	again := panels.diff(pm)
+	if !again.IsEmpty() {
+		t.Errorf("Expected no changes when applying the same panels, got %+v", again)
+	}
+}
+
+func TestReloadPanelsInvalidKeepsState(t *testing.T) {
+	panelsTurnOffLogs()
+	defer panelsTurnOnLogs()
+
+	UsePanelMaps().Init()
+	defer UsePanelMaps().Init()
+
+	previousCache := exchangeCacheStorage
+	exchangeCacheStorage = &exchangeDataCacheType{}
+	exchangeCacheStorage.Init()
+	defer func() {
+		exchangeCacheStorage = previousCache
+	}()
+
+	UsePanelMaps().Append("1-2-0.5-BTC-ETH-4|15.5")
+
+	for _, data := range []string{`[{"source":1,`, `{"source":1}`} {
+		if _, err := ReloadPanels([]byte(data)); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+
+	if UsePanelMaps().TotalData() != 1 {
+		t.Errorf("Expected current panels to be kept, got %d", UsePanelMaps().TotalData())
+	}
+}
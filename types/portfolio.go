@@ -0,0 +1,136 @@
+package types
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	JC "jxwatcher/core"
+)
+
+type portfolioTotalType struct {
+	Symbol   string
+	TargetId int64
+	Holdings int
+	Value    float64
+	Cost     float64
+}
+
+func (pt *portfolioTotalType) GetPnL() float64 {
+	return pt.Value - pt.Cost
+}
+
+func (pt *portfolioTotalType) GetPnLPercent() float64 {
+	if pt.Cost == 0 {
+		return 0
+	}
+	return pt.GetPnL() / pt.Cost * 100
+}
+
+func (pt *portfolioTotalType) Format() string {
+	frac := 2
+	if pt.Value < 1 {
+		frac = 6
+	}
+
+	return JC.FormatNumberWithCommas(pt.Value, frac) + fmtSpace + pt.Symbol
+}
+
+// Holdings are totalled per target symbol, the one with most holdings comes first
+func ComputePortfolio() []portfolioTotalType {
+	totals := map[string]*portfolioTotalType{}
+
+	for _, pot := range UsePanelMaps().GetData() {
+		pdt := UsePanelMaps().GetDataByID(pot.GetID())
+		if pdt == nil || !pdt.IsStatus(JC.STATE_LOADED) {
+			continue
+		}
+
+		pkt := pdt.UsePanelKey()
+		if !pkt.IsHolding() || pkt.GetValueFloat().Sign() < 0 {
+			continue
+		}
+
+		symbol := pkt.GetTargetSymbolString()
+		if _, ok := totals[symbol]; !ok {
+			totals[symbol] = &portfolioTotalType{Symbol: symbol, TargetId: pkt.GetTargetCoinInt()}
+		}
+
+		totals[symbol].Holdings++
+		totals[symbol].Value += pkt.GetHoldingValueFloat()
+		totals[symbol].Cost += pkt.GetCostValueFloat()
+	}
+
+	result := make([]portfolioTotalType, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, *total)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Holdings != result[j].Holdings {
+			return result[i].Holdings > result[j].Holdings
+		}
+		return result[i].Symbol < result[j].Symbol
+	})
+
+	return result
+}
+
+// Totals are converted into the currency of the first one, groups without a cached rate to it are left out
+func ComputePortfolioPnLPercent(totals []portfolioTotalType) float64 {
+	if len(totals) == 0 || UseExchangeCache() == nil {
+		return 0
+	}
+
+	base := totals[0]
+	value := 0.0
+	cost := 0.0
+
+	for _, total := range totals {
+		rate, ok := UseExchangeCache().GetRate(total.TargetId, base.TargetId)
+		if !ok {
+			JC.Logln("Portfolio has no rate from", total.Symbol, "to", base.Symbol, "leaving it out of the profit and loss")
+			continue
+		}
+
+		value += total.Value * rate
+		cost += total.Cost * rate
+	}
+
+	if cost == 0 {
+		return 0
+	}
+
+	pnl := (value - cost) / cost * 100
+	if math.IsNaN(pnl) || math.IsInf(pnl, 0) {
+		return 0
+	}
+
+	return pnl
+}
+
+func UpdatePortfolio() bool {
+	if UseTickerCache() == nil {
+		return false
+	}
+
+	totals := ComputePortfolio()
+	if len(totals) == 0 {
+		return false
+	}
+
+	parts := make([]string, 0, len(totals))
+	for _, total := range totals {
+		parts = append(parts, total.Format())
+	}
+
+	now := time.Now()
+	pnl := ComputePortfolioPnLPercent(totals)
+
+	UseTickerCache().Insert(TickerTypePortfolioPnL, strconv.FormatFloat(pnl, 'f', 2, 64), now)
+	UseTickerCache().Insert(TickerTypePortfolio, strings.Join(parts, " · "), now)
+
+	return true
+}
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	JC "jxwatcher/core"
+)
+
+type portfolioNullWriter struct{}
+
+func (portfolioNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func portfolioTurnOffLogs() {
+	log.SetOutput(portfolioNullWriter{})
+}
+
+func portfolioTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func portfolioSetup() {
+	UsePanelMaps().Init()
+
+	for _, pk := range []string{
+		"1-825-0.5-BTC-USDT-2-40000-1700000000|60000",
+		"1027-825-2-ETH-USDT-2-2500-1700000000|2000",
+		"1-1027-1-BTC-ETH-4-10-0|25",
+		"2-825-1-LTC-USDT-2|80",
+		"5-825-1-XRP-USDT-2-1-0|-1",
+	} {
+		pdt := UsePanelMaps().Append(pk)
+		if pdt.UsePanelKey().GetValueFloat().Sign() > 0 {
+			pdt.SetStatus(JC.STATE_LOADED)
+		}
+	}
+}
+
+func TestPortfolioCompute(t *testing.T) {
+	portfolioTurnOffLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	portfolioSetup()
+	defer UsePanelMaps().Init()
+
+	if !UsePanelMaps().HasHoldings() {
+		t.Fatal("Expected panels with cost basis to count as holdings")
+	}
+
+	totals := ComputePortfolio()
+	if len(totals) != 2 {
+		t.Fatalf("Expected totals for 2 symbols, got %d", len(totals))
+	}
+
+	usdt := totals[0]
+	if usdt.Symbol != "USDT" || usdt.Holdings != 2 {
+		t.Errorf("Expected USDT with 2 holdings first, got %s with %d", usdt.Symbol, usdt.Holdings)
+	}
+	if usdt.Value != 34000 || usdt.Cost != 25000 || usdt.GetPnL() != 9000 || usdt.GetPnLPercent() != 36 {
+		t.Errorf("Unexpected USDT totals %+v", usdt)
+	}
+	if usdt.Format() != "34,000 USDT" {
+		t.Errorf("Unexpected format %q", usdt.Format())
+	}
+
+	eth := totals[1]
+	if eth.Symbol != "ETH" || eth.Value != 25 || eth.Cost != 10 {
+		t.Errorf("Unexpected ETH totals %+v", eth)
+	}
+
+	portfolioTurnOnLogs()
+}
+
+func TestPortfolioUpdate(t *testing.T) {
+	portfolioTurnOffLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	RegisterTickerCache().Init()
+	RegisterExchangeCache().Init()
+	defer UseExchangeCache().Init()
+
+	UsePanelMaps().Init()
+	if UpdatePortfolio() {
+		t.Error("Expected no update without holdings")
+	}
+
+	portfolioSetup()
+	defer UsePanelMaps().Init()
+
+	if !UpdatePortfolio() {
+		t.Fatal("Expected portfolio to be updated")
+	}
+	if got := UseTickerCache().Get(TickerTypePortfolio); got != "34,000 USDT · 25 ETH" {
+		t.Errorf("Unexpected portfolio ticker %q", got)
+	}
+	if got := UseTickerCache().Get(TickerTypePortfolioPnL); got != "36.00" {
+		t.Errorf("Expected ETH holdings left out without a rate, got P&L %q", got)
+	}
+
+	UseExchangeCache().Insert(&exchangeDataType{
+		SourceSymbol: "ETH",
+		SourceId:     1027,
+		SourceAmount: 1,
+		TargetSymbol: "USDT",
+		TargetId:     825,
+		TargetAmount: JC.ToBigFloat(2000),
+		Timestamp:    time.Now(),
+	})
+
+	if !UpdatePortfolio() {
+		t.Fatal("Expected portfolio to be updated")
+	}
+
+	// ETH holdings of 25 at a cost of 10 are worth 50,000 and cost 20,000 USDT
+	if got := UseTickerCache().Get(TickerTypePortfolioPnL); got != "86.67" {
+		t.Errorf("Expected P&L over every group in USDT, got %q", got)
+	}
+
+	portfolioTurnOnLogs()
+}
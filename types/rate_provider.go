@@ -0,0 +1,186 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	JC "jxwatcher/core"
+)
+
+var rateProvidersStorage *rateProvidersType = nil
+
+type RateProvider interface {
+	GetName() string
+	GetEndpoints() []string
+	MapSymbol(symbol string) string
+	Prepare(q url.Values, req *http.Request, source rateAssetType, targets []rateAssetType)
+	Parse(data []byte, source rateAssetType, targets []rateAssetType) ([]exchangeDataType, error)
+}
+
+// Providers that must look up their markets before a request can be prepared
+type RatePairResolver interface {
+	ResolvePairs(ctx context.Context) int64
+}
+
+type rateAssetType struct {
+	Id     int64
+	Symbol string
+	Code   string
+}
+
+type rateProvidersType struct {
+	mu        sync.RWMutex
+	providers map[string]RateProvider
+	symbols   map[string]map[string]string
+	fallback  string
+}
+
+func (r *rateProvidersType) Init() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.providers = make(map[string]RateProvider)
+	r.symbols = make(map[string]map[string]string)
+	r.fallback = JC.RATE_PROVIDER_CMC
+
+	for _, provider := range []RateProvider{
+		NewCMCRateProvider(),
+		NewCoinGeckoRateProvider(),
+		NewBinanceRateProvider(),
+		NewKrakenRateProvider(),
+	} {
+		r.providers[provider.GetName()] = provider
+	}
+
+	cfg := UseConfig()
+	if cfg == nil {
+		return
+	}
+
+	name := cfg.GetRateProvider()
+	if _, ok := r.providers[name]; ok {
+		r.fallback = name
+	} else {
+		JC.Logln("Unknown rate provider, using", r.fallback, "instead of", name)
+	}
+
+	for provider, symbols := range cfg.GetRateSymbols() {
+		r.symbols[provider] = make(map[string]string, len(symbols))
+		for symbol, code := range symbols {
+			r.symbols[provider][strings.ToUpper(symbol)] = code
+		}
+	}
+}
+
+func (r *rateProvidersType) Add(provider RateProvider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.providers[provider.GetName()] = provider
+}
+
+func (r *rateProvidersType) Has(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.providers[name]
+	return ok
+}
+
+func (r *rateProvidersType) Get(name string) RateProvider {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if provider, ok := r.providers[name]; ok {
+		return provider
+	}
+
+	return r.providers[r.fallback]
+}
+
+func (r *rateProvidersType) GetNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (r *rateProvidersType) Resolve(pdt PanelData) RateProvider {
+	return r.Get(pdt.GetProvider())
+}
+
+func (r *rateProvidersType) NewAsset(provider RateProvider, id string) (rateAssetType, error) {
+	id = strings.TrimSpace(id)
+
+	iid, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return rateAssetType{}, fmt.Errorf("invalid crypto id %q", id)
+	}
+
+	asset := rateAssetType{Id: iid}
+
+	if UsePanelMaps().HasMaps() {
+		asset.Symbol = UsePanelMaps().GetSymbolById(id)
+	}
+
+	asset.Code = r.MapSymbol(provider, asset.Symbol)
+
+	return asset, nil
+}
+
+func (r *rateProvidersType) MapSymbol(provider RateProvider, symbol string) string {
+	if symbol == JC.STRING_EMPTY {
+		return JC.STRING_EMPTY
+	}
+
+	r.mu.RLock()
+	code, ok := r.symbols[provider.GetName()][strings.ToUpper(symbol)]
+	r.mu.RUnlock()
+
+	if ok {
+		return code
+	}
+
+	return provider.MapSymbol(symbol)
+}
+
+func newRate(source rateAssetType, target rateAssetType, price *big.Float) exchangeDataType {
+	return exchangeDataType{
+		SourceSymbol: source.Symbol,
+		SourceId:     source.Id,
+		SourceAmount: 1,
+		TargetSymbol: target.Symbol,
+		TargetId:     target.Id,
+		TargetAmount: price,
+	}
+}
+
+func invertRate(price *big.Float) *big.Float {
+	one := new(big.Float).SetPrec(256).SetFloat64(1)
+	return new(big.Float).SetPrec(256).Quo(one, price)
+}
+
+func RegisterRateProviders() *rateProvidersType {
+	if rateProvidersStorage == nil {
+		rateProvidersStorage = &rateProvidersType{}
+		rateProvidersStorage.Init()
+	}
+	return rateProvidersStorage
+}
+
+func UseRateProviders() *rateProvidersType {
+	return rateProvidersStorage
+}
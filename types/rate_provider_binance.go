@@ -0,0 +1,224 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/buger/jsonparser"
+
+	JC "jxwatcher/core"
+)
+
+// The unfiltered price list doubles as the list of markets
+var binanceSymbolsEndpoint = "https://api.binance.com/api/v3/ticker/price"
+
+const binanceSymbolsRefresh = 24 * time.Hour
+
+type binanceRateProvider struct {
+	mu       sync.RWMutex
+	symbols  map[string]struct{}
+	resolved time.Time
+}
+
+func (p *binanceRateProvider) GetName() string {
+	return JC.RATE_PROVIDER_BINANCE
+}
+
+func (p *binanceRateProvider) GetEndpoints() []string {
+	return []string{"https://api.binance.com/api/v3/ticker/price"}
+}
+
+func (p *binanceRateProvider) MapSymbol(symbol string) string {
+	return strings.ToUpper(symbol)
+}
+
+// ResolvePairs loads the markets once a day, Binance rejects the whole request when a single symbol is unknown
+func (p *binanceRateProvider) ResolvePairs(ctx context.Context) int64 {
+	p.mu.RLock()
+	loaded := p.symbols != nil
+	fresh := loaded && time.Since(p.resolved) < binanceSymbolsRefresh
+	p.mu.RUnlock()
+
+	if fresh {
+		return JC.NETWORKING_SUCCESS
+	}
+
+	code := JC.GetRequest(ctx, binanceSymbolsEndpoint, nil, func(cctx context.Context, resp *http.Response) int64 {
+		if cctx != nil && cctx.Err() != nil {
+			return JC.NETWORKING_ERROR_CONNECTION
+		}
+
+		body, close, err := JC.ReadResponse("binance_symbols", resp, 256)
+		defer close()
+		if err != nil {
+			return JC.NETWORKING_BAD_DATA_RECEIVED
+		}
+
+		symbols, err := p.parseSymbols(body)
+		if err != nil {
+			JC.Logln("Failed to parse binance symbols", err)
+			return JC.NETWORKING_BAD_DATA_RECEIVED
+		}
+
+		p.mu.Lock()
+		p.symbols = symbols
+		p.resolved = time.Now()
+		p.mu.Unlock()
+
+		return JC.NETWORKING_SUCCESS
+	})
+
+	// Markets rarely change, a failed refresh keeps using the previous list
+	if code != JC.NETWORKING_SUCCESS && loaded {
+		JC.Logln("Unable to refresh binance symbols, using cached symbols:", code)
+		return JC.NETWORKING_SUCCESS
+	}
+
+	return code
+}
+
+// Delisted markets stay in the list with a zero price
+func (p *binanceRateProvider) parseSymbols(data []byte) (map[string]struct{}, error) {
+	symbols := make(map[string]struct{})
+
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		symbol, err := jsonparser.GetString(value, "symbol")
+		if err != nil {
+			return
+		}
+
+		raw, err := jsonparser.GetString(value, "price")
+		if err != nil {
+			return
+		}
+
+		if val, ok := JC.ToBigString(raw); ok && val.Sign() > 0 {
+			symbols[symbol] = struct{}{}
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(symbols) == 0 {
+		return nil, fmt.Errorf("no symbols found in binance response")
+	}
+
+	return symbols, nil
+}
+
+// Only the resolved markets are requested, without the market list every ticker is fetched instead
+func (p *binanceRateProvider) Prepare(q url.Values, req *http.Request, source rateAssetType, targets []rateAssetType) {
+	p.mu.RLock()
+	loaded := p.symbols != nil
+	p.mu.RUnlock()
+
+	if !loaded {
+		return
+	}
+
+	symbols := []string{}
+	for _, target := range targets {
+		symbol := p.resolveSymbol(source.Code, target.Code)
+		if symbol == JC.STRING_EMPTY {
+			continue
+		}
+
+		if quoted := strconv.Quote(symbol); !slices.Contains(symbols, quoted) {
+			symbols = append(symbols, quoted)
+		}
+	}
+
+	q.Add("symbols", "["+strings.Join(symbols, ",")+"]")
+}
+
+// Prefers the direct market and falls back to the reversed one, which Parse inverts
+func (p *binanceRateProvider) resolveSymbol(base string, quote string) string {
+	if base == JC.STRING_EMPTY || quote == JC.STRING_EMPTY {
+		return JC.STRING_EMPTY
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if _, ok := p.symbols[base+quote]; ok {
+		return base + quote
+	}
+
+	if _, ok := p.symbols[quote+base]; ok {
+		return quote + base
+	}
+
+	JC.Logln("Binance has no market for", base, quote)
+
+	return JC.STRING_EMPTY
+}
+
+func (p *binanceRateProvider) Parse(data []byte, source rateAssetType, targets []rateAssetType) ([]exchangeDataType, error) {
+	prices := make(map[string]*big.Float)
+
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		symbol, err := jsonparser.GetString(value, "symbol")
+		if err != nil {
+			return
+		}
+
+		raw, err := jsonparser.GetString(value, "price")
+		if err != nil {
+			return
+		}
+
+		if val, ok := JC.ToBigString(raw); ok && val.Sign() > 0 {
+			prices[symbol] = val
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	rates := []exchangeDataType{}
+	now := time.Now()
+
+	for _, target := range targets {
+		if source.Code == JC.STRING_EMPTY || target.Code == JC.STRING_EMPTY {
+			continue
+		}
+
+		var rate *big.Float
+
+		if val, ok := prices[source.Code+target.Code]; ok {
+			rate = val
+		} else if val, ok := prices[target.Code+source.Code]; ok {
+			rate = invertRate(val)
+		}
+
+		if rate == nil {
+			JC.Logln("Binance has no market for", source.Code, target.Code)
+			continue
+		}
+
+		ex := newRate(source, target, rate)
+		ex.Timestamp = now
+		rates = append(rates, ex)
+	}
+
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("no rates found in binance response")
+	}
+
+	return rates, nil
+}
+
+func NewBinanceRateProvider() *binanceRateProvider {
+	return &binanceRateProvider{}
+}
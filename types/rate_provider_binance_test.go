@@ -0,0 +1,93 @@
+package types
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	JC "jxwatcher/core"
+)
+
+func TestBinanceRateProviderParse(t *testing.T) {
+	rateProviderTurnOffLogs()
+	defer rateProviderTurnOnLogs()
+
+	p := NewBinanceRateProvider()
+	data := rateProviderFixture(t, "rates_binance.json")
+
+	btc := rateAssetType{Id: 1, Symbol: "BTC", Code: "BTC"}
+	eth := rateAssetType{Id: 1027, Symbol: "ETH", Code: "ETH"}
+	usdt := rateAssetType{Id: 825, Symbol: "USDT", Code: "USDT"}
+	delisted := rateAssetType{Id: 9999, Symbol: "DELISTED", Code: "DELISTED"}
+
+	rates, err := p.Parse(data, btc, []rateAssetType{usdt, eth, delisted})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(rates) != 2 {
+		t.Fatalf("Expected 2 rates, got %d", len(rates))
+	}
+
+	rateProviderExpect(t, rates, 1, 825, 112287.99)
+	rateProviderExpect(t, rates, 1, 1027, 1/0.03687)
+
+	if _, err := p.Parse(data, btc, []rateAssetType{delisted}); err == nil {
+		t.Error("Expected error when no market is found")
+	}
+
+	if _, err := p.Parse([]byte(`{"code":-1121,"msg":"Invalid symbol."}`), btc, []rateAssetType{eth}); err == nil {
+		t.Error("Expected error for non array response")
+	}
+}
+
+func TestBinanceRateProviderPrepare(t *testing.T) {
+	rateProviderTurnOffLogs()
+	defer rateProviderTurnOnLogs()
+
+	fixture := rateProviderFixture(t, "rates_binance.json")
+
+	var hits atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write(fixture)
+	}))
+	defer server.Close()
+
+	previous := binanceSymbolsEndpoint
+	binanceSymbolsEndpoint = server.URL
+	defer func() { binanceSymbolsEndpoint = previous }()
+
+	p := NewBinanceRateProvider()
+
+	btc := rateAssetType{Id: 1, Symbol: "BTC", Code: "BTC"}
+	eth := rateAssetType{Id: 1027, Symbol: "ETH", Code: "ETH"}
+	usdt := rateAssetType{Id: 825, Symbol: "USDT", Code: "USDT"}
+	delisted := rateAssetType{Id: 9999, Symbol: "DELISTED", Code: "DELISTED"}
+
+	q := url.Values{}
+	req, _ := http.NewRequest("GET", "http://localhost", nil)
+	p.Prepare(q, req, btc, []rateAssetType{usdt, eth})
+
+	if q.Has("symbols") {
+		t.Errorf("Expected every ticker requested without a market list, got %q", q.Get("symbols"))
+	}
+
+	if code := p.ResolvePairs(context.Background()); code != JC.NETWORKING_SUCCESS {
+		t.Fatalf("Expected symbols resolved, got %s", JC.GetNetworkingCodeName(code))
+	}
+	p.ResolvePairs(context.Background())
+	if hits.Load() != 1 {
+		t.Errorf("Expected symbols cached for a day, got %d requests", hits.Load())
+	}
+
+	q = url.Values{}
+	p.Prepare(q, req, btc, []rateAssetType{usdt, eth, delisted})
+
+	if q.Get("symbols") != `["BTCUSDT","ETHBTC"]` {
+		t.Errorf("Expected direct and reversed markets without the delisted one, got %q", q.Get("symbols"))
+	}
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	JC "jxwatcher/core"
+)
+
+type cmcRateProvider struct{}
+
+func (p *cmcRateProvider) GetName() string {
+	return JC.RATE_PROVIDER_CMC
+}
+
+func (p *cmcRateProvider) GetEndpoints() []string {
+	return UseConfig().GetExchangeEndpoints()
+}
+
+func (p *cmcRateProvider) MapSymbol(symbol string) string {
+	return symbol
+}
+
+func (p *cmcRateProvider) Prepare(q url.Values, req *http.Request, source rateAssetType, targets []rateAssetType) {
+	ids := make([]string, 0, len(targets))
+	for _, target := range targets {
+		ids = append(ids, strconv.FormatInt(target.Id, 10))
+	}
+
+	q.Add("amount", "1")
+	q.Add("id", strconv.FormatInt(source.Id, 10))
+	q.Add("convert_id", strings.Join(ids, ","))
+
+	UseAuthKey().SetHeader(req)
+}
+
+func (p *cmcRateProvider) Parse(data []byte, source rateAssetType, targets []rateAssetType) ([]exchangeDataType, error) {
+	er := exchangeResults{}
+	if err := er.parseJSON(data); err != nil {
+		return nil, err
+	}
+
+	rates := make([]exchangeDataType, 0, len(er.Rates))
+	for _, ex := range er.Rates {
+		if ex.TargetAmount != nil && ex.TargetAmount.Sign() > 0 {
+			rates = append(rates, ex)
+		}
+	}
+
+	return rates, nil
+}
+
+func NewCMCRateProvider() *cmcRateProvider {
+	return &cmcRateProvider{}
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestCMCRateProviderPrepare(t *testing.T) {
+	p := NewCMCRateProvider()
+
+	q := url.Values{}
+	req, _ := http.NewRequest("GET", "http://localhost", nil)
+
+	p.Prepare(q, req,
+		rateAssetType{Id: 1, Symbol: "BTC"},
+		[]rateAssetType{{Id: 825, Symbol: "USDT"}, {Id: 1027, Symbol: "ETH"}},
+	)
+
+	if q.Get("id") != "1" || q.Get("convert_id") != "825,1027" || q.Get("amount") != "1" {
+		t.Errorf("Unexpected query %s", q.Encode())
+	}
+}
+
+func TestCMCRateProviderParse(t *testing.T) {
+	rateProviderTurnOffLogs()
+	defer rateProviderTurnOnLogs()
+
+	p := NewCMCRateProvider()
+	source := rateAssetType{Id: 1, Symbol: "BTC", Code: "BTC"}
+	targets := []rateAssetType{{Id: 825, Symbol: "USDT", Code: "USDT"}, {Id: 1027, Symbol: "ETH", Code: "ETH"}}
+
+	rates, err := p.Parse(rateProviderFixture(t, "rates_coinmarketcap.json"), source, targets)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(rates) != 2 {
+		t.Fatalf("Expected 2 rates, got %d", len(rates))
+	}
+
+	rateProviderExpect(t, rates, 1, 825, 112338.41235467812)
+	rateProviderExpect(t, rates, 1, 1027, 27.11850923419127)
+
+	if rates[0].Timestamp.IsZero() {
+		t.Error("Expected timestamp to be parsed")
+	}
+
+	if _, err := p.Parse([]byte(`{"status":{}}`), source, targets); err == nil {
+		t.Error("Expected error for missing data")
+	}
+}
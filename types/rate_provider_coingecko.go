@@ -0,0 +1,165 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/buger/jsonparser"
+
+	JC "jxwatcher/core"
+)
+
+const coingeckoQuote = "usd"
+
+var coingeckoCoinIds = map[string]string{
+	"BTC":   "bitcoin",
+	"ETH":   "ethereum",
+	"USDT":  "tether",
+	"USDC":  "usd-coin",
+	"BNB":   "binancecoin",
+	"SOL":   "solana",
+	"XRP":   "ripple",
+	"ADA":   "cardano",
+	"DOGE":  "dogecoin",
+	"TRX":   "tron",
+	"TON":   "the-open-network",
+	"DOT":   "polkadot",
+	"LTC":   "litecoin",
+	"BCH":   "bitcoin-cash",
+	"LINK":  "chainlink",
+	"AVAX":  "avalanche-2",
+	"SHIB":  "shiba-inu",
+	"XLM":   "stellar",
+	"MATIC": "matic-network",
+	"ATOM":  "cosmos",
+}
+
+var coingeckoFiats = map[string]bool{
+	"USD": true, "EUR": true, "GBP": true, "JPY": true, "CHF": true, "CAD": true,
+	"AUD": true, "NZD": true, "CNY": true, "HKD": true, "SGD": true, "KRW": true,
+	"INR": true, "IDR": true, "MYR": true, "THB": true, "PHP": true, "BRL": true,
+	"MXN": true, "TRY": true, "ZAR": true, "SEK": true, "NOK": true, "DKK": true,
+	"PLN": true, "CZK": true, "HUF": true, "ILS": true, "AED": true, "SAR": true,
+}
+
+type coingeckoRateProvider struct{}
+
+func (p *coingeckoRateProvider) GetName() string {
+	return JC.RATE_PROVIDER_COINGECKO
+}
+
+func (p *coingeckoRateProvider) GetEndpoints() []string {
+	return []string{"https://api.coingecko.com/api/v3/simple/price"}
+}
+
+func (p *coingeckoRateProvider) MapSymbol(symbol string) string {
+	symbol = strings.ToUpper(symbol)
+
+	if coingeckoFiats[symbol] {
+		return strings.ToLower(symbol)
+	}
+
+	if id, ok := coingeckoCoinIds[symbol]; ok {
+		return id
+	}
+
+	return strings.ToLower(symbol)
+}
+
+func (p *coingeckoRateProvider) isFiat(asset rateAssetType) bool {
+	return coingeckoFiats[strings.ToUpper(asset.Symbol)]
+}
+
+func (p *coingeckoRateProvider) Prepare(q url.Values, req *http.Request, source rateAssetType, targets []rateAssetType) {
+	ids := []string{}
+	vs := []string{coingeckoQuote}
+	seen := map[string]bool{coingeckoQuote: true}
+
+	add := func(asset rateAssetType) {
+		if asset.Code == JC.STRING_EMPTY || seen[asset.Code] {
+			return
+		}
+		seen[asset.Code] = true
+
+		if p.isFiat(asset) {
+			vs = append(vs, asset.Code)
+		} else {
+			ids = append(ids, asset.Code)
+		}
+	}
+
+	add(source)
+	for _, target := range targets {
+		add(target)
+	}
+
+	q.Add("ids", strings.Join(ids, ","))
+	q.Add("vs_currencies", strings.Join(vs, ","))
+	q.Add("precision", "full")
+}
+
+func (p *coingeckoRateProvider) Parse(data []byte, source rateAssetType, targets []rateAssetType) ([]exchangeDataType, error) {
+	price := func(coin string, currency string) *big.Float {
+		raw, dataType, _, err := jsonparser.Get(data, coin, currency)
+		if err != nil || dataType != jsonparser.Number {
+			return nil
+		}
+
+		val, ok := JC.ToBigString(string(raw))
+		if !ok || val.Sign() <= 0 {
+			return nil
+		}
+
+		return val
+	}
+
+	rates := []exchangeDataType{}
+	now := time.Now()
+
+	for _, target := range targets {
+		var rate *big.Float
+
+		switch {
+		case p.isFiat(source) && p.isFiat(target):
+			continue
+
+		case p.isFiat(target):
+			rate = price(source.Code, target.Code)
+
+		case p.isFiat(source):
+			if val := price(target.Code, source.Code); val != nil {
+				rate = invertRate(val)
+			}
+
+		default:
+			sp := price(source.Code, coingeckoQuote)
+			tp := price(target.Code, coingeckoQuote)
+			if sp != nil && tp != nil {
+				rate = new(big.Float).SetPrec(256).Quo(sp, tp)
+			}
+		}
+
+		if rate == nil {
+			JC.Logln("CoinGecko has no rate for", source.Symbol, target.Symbol)
+			continue
+		}
+
+		ex := newRate(source, target, rate)
+		ex.Timestamp = now
+		rates = append(rates, ex)
+	}
+
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("no rates found in coingecko response")
+	}
+
+	return rates, nil
+}
+
+func NewCoinGeckoRateProvider() *coingeckoRateProvider {
+	return &coingeckoRateProvider{}
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestCoinGeckoRateProviderPrepare(t *testing.T) {
+	p := NewCoinGeckoRateProvider()
+
+	q := url.Values{}
+	req, _ := http.NewRequest("GET", "http://localhost", nil)
+
+	p.Prepare(q, req,
+		rateAssetType{Id: 1, Symbol: "BTC", Code: "bitcoin"},
+		[]rateAssetType{
+			{Id: 1027, Symbol: "ETH", Code: "ethereum"},
+			{Id: 2790, Symbol: "EUR", Code: "eur"},
+			{Id: 2781, Symbol: "USD", Code: "usd"},
+		},
+	)
+
+	if q.Get("ids") != "bitcoin,ethereum" {
+		t.Errorf("Unexpected ids %q", q.Get("ids"))
+	}
+	if q.Get("vs_currencies") != "usd,eur" {
+		t.Errorf("Unexpected vs_currencies %q", q.Get("vs_currencies"))
+	}
+	if q.Get("precision") != "full" {
+		t.Error("Expected full precision to be requested")
+	}
+}
+
+func TestCoinGeckoRateProviderParse(t *testing.T) {
+	rateProviderTurnOffLogs()
+	defer rateProviderTurnOnLogs()
+
+	p := NewCoinGeckoRateProvider()
+	data := rateProviderFixture(t, "rates_coingecko.json")
+
+	btc := rateAssetType{Id: 1, Symbol: "BTC", Code: "bitcoin"}
+	eth := rateAssetType{Id: 1027, Symbol: "ETH", Code: "ethereum"}
+	usdt := rateAssetType{Id: 825, Symbol: "USDT", Code: "tether"}
+	eur := rateAssetType{Id: 2790, Symbol: "EUR", Code: "eur"}
+	missing := rateAssetType{Id: 5604, Symbol: "SCRT", Code: "secret"}
+
+	rates, err := p.Parse(data, btc, []rateAssetType{eur, eth, usdt, missing})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(rates) != 3 {
+		t.Fatalf("Expected 3 rates, got %d", len(rates))
+	}
+
+	rateProviderExpect(t, rates, 1, 2790, 95874.12)
+	rateProviderExpect(t, rates, 1, 1027, 112301.55/4141.27)
+	rateProviderExpect(t, rates, 1, 825, 112301.55/1.0001)
+
+	rates, err = p.Parse(data, eur, []rateAssetType{eth})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rateProviderExpect(t, rates, 2790, 1027, 1/3535.41)
+
+	if _, err := p.Parse(data, btc, []rateAssetType{missing}); err == nil {
+		t.Error("Expected error when no rate is found")
+	}
+}
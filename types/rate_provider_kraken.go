@@ -0,0 +1,257 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/buger/jsonparser"
+
+	JC "jxwatcher/core"
+)
+
+var krakenAssetCodes = map[string]string{
+	"BTC":  "XBT",
+	"DOGE": "XDG",
+}
+
+var krakenPairsEndpoint = "https://api.kraken.com/0/public/AssetPairs"
+
+const krakenPairsRefresh = 24 * time.Hour
+
+type krakenRateProvider struct {
+	mu       sync.RWMutex
+	pairs    map[string]string
+	resolved time.Time
+}
+
+func (p *krakenRateProvider) GetName() string {
+	return JC.RATE_PROVIDER_KRAKEN
+}
+
+func (p *krakenRateProvider) GetEndpoints() []string {
+	return []string{"https://api.kraken.com/0/public/Ticker"}
+}
+
+func (p *krakenRateProvider) MapSymbol(symbol string) string {
+	symbol = strings.ToUpper(symbol)
+
+	if code, ok := krakenAssetCodes[symbol]; ok {
+		return code
+	}
+
+	return symbol
+}
+
+// ResolvePairs loads the markets once a day, Kraken rejects the whole request when a single pair is unknown
+func (p *krakenRateProvider) ResolvePairs(ctx context.Context) int64 {
+	p.mu.RLock()
+	loaded := p.pairs != nil
+	fresh := loaded && time.Since(p.resolved) < krakenPairsRefresh
+	p.mu.RUnlock()
+
+	if fresh {
+		return JC.NETWORKING_SUCCESS
+	}
+
+	code := JC.GetRequest(ctx, krakenPairsEndpoint, nil, func(cctx context.Context, resp *http.Response) int64 {
+		if cctx != nil && cctx.Err() != nil {
+			return JC.NETWORKING_ERROR_CONNECTION
+		}
+
+		body, close, err := JC.ReadResponse("kraken_asset_pairs", resp, 64)
+		defer close()
+		if err != nil {
+			return JC.NETWORKING_BAD_DATA_RECEIVED
+		}
+
+		pairs, err := p.parsePairs(body)
+		if err != nil {
+			JC.Logln("Failed to parse kraken asset pairs", err)
+			return JC.NETWORKING_BAD_DATA_RECEIVED
+		}
+
+		p.mu.Lock()
+		p.pairs = pairs
+		p.resolved = time.Now()
+		p.mu.Unlock()
+
+		return JC.NETWORKING_SUCCESS
+	})
+
+	// Markets rarely change, a failed refresh keeps using the previous list
+	if code != JC.NETWORKING_SUCCESS && loaded {
+		JC.Logln("Unable to refresh kraken asset pairs, using cached pairs:", code)
+		return JC.NETWORKING_SUCCESS
+	}
+
+	return code
+}
+
+// Pairs are indexed by their websocket name, which uses the same asset codes as MapSymbol
+func (p *krakenRateProvider) parsePairs(data []byte) (map[string]string, error) {
+	if err := p.parseErrors(data); err != nil {
+		return nil, err
+	}
+
+	pairs := make(map[string]string)
+
+	err := jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		if wsname, err := jsonparser.GetString(value, "wsname"); err == nil {
+			pairs[strings.ToUpper(wsname)] = string(key)
+		}
+		return nil
+	}, "result")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("no pairs found in kraken response")
+	}
+
+	return pairs, nil
+}
+
+func (p *krakenRateProvider) Prepare(q url.Values, req *http.Request, source rateAssetType, targets []rateAssetType) {
+	pairs := []string{}
+	for _, target := range targets {
+		if name := p.resolvePair(source.Code, target.Code); name != JC.STRING_EMPTY {
+			pairs = append(pairs, name)
+		}
+	}
+
+	q.Add("pair", strings.Join(pairs, ","))
+}
+
+// Prefers the direct market and falls back to the reversed one, which Parse inverts
+func (p *krakenRateProvider) resolvePair(base string, quote string) string {
+	if base == JC.STRING_EMPTY || quote == JC.STRING_EMPTY {
+		return JC.STRING_EMPTY
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.pairs == nil {
+		return base + quote
+	}
+
+	if name, ok := p.pairs[base+"/"+quote]; ok {
+		return name
+	}
+
+	if name, ok := p.pairs[quote+"/"+base]; ok {
+		return name
+	}
+
+	JC.Logln("Kraken has no market for", base, quote)
+
+	return JC.STRING_EMPTY
+}
+
+func (p *krakenRateProvider) parseErrors(data []byte) error {
+	errs := []string{}
+	jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		errs = append(errs, string(value))
+	}, "error")
+
+	if len(errs) != 0 {
+		return fmt.Errorf("kraken error: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+func (p *krakenRateProvider) Parse(data []byte, source rateAssetType, targets []rateAssetType) ([]exchangeDataType, error) {
+	if err := p.parseErrors(data); err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]*big.Float)
+
+	err := jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		// "c" holds the last trade closed as [price, lot volume]
+		raw, err := jsonparser.GetString(value, "c", "[0]")
+		if err != nil {
+			return nil
+		}
+
+		if val, ok := JC.ToBigString(raw); ok && val.Sign() > 0 {
+			prices[string(key)] = val
+		}
+
+		return nil
+	}, "result")
+
+	if err != nil {
+		return nil, err
+	}
+
+	rates := []exchangeDataType{}
+	now := time.Now()
+
+	for _, target := range targets {
+		if source.Code == JC.STRING_EMPTY || target.Code == JC.STRING_EMPTY {
+			continue
+		}
+
+		var rate *big.Float
+
+		if val := p.findPrice(prices, source.Code, target.Code); val != nil {
+			rate = val
+		} else if val := p.findPrice(prices, target.Code, source.Code); val != nil {
+			rate = invertRate(val)
+		}
+
+		if rate == nil {
+			JC.Logln("Kraken has no market for", source.Code, target.Code)
+			continue
+		}
+
+		ex := newRate(source, target, rate)
+		ex.Timestamp = now
+		rates = append(rates, ex)
+	}
+
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("no rates found in kraken response")
+	}
+
+	return rates, nil
+}
+
+// Kraken answers with its own pair names, legacy assets are prefixed with X for cryptos and Z for fiats
+func (p *krakenRateProvider) findPrice(prices map[string]*big.Float, base string, quote string) *big.Float {
+	p.mu.RLock()
+	name, ok := p.pairs[base+"/"+quote]
+	p.mu.RUnlock()
+
+	if ok {
+		return prices[name]
+	}
+
+	for _, key := range []string{
+		base + quote,
+		"X" + base + "Z" + quote,
+		"X" + base + "X" + quote,
+		base + "Z" + quote,
+		"X" + base + quote,
+	} {
+		if val, ok := prices[key]; ok {
+			return val
+		}
+	}
+
+	return nil
+}
+
+func NewKrakenRateProvider() *krakenRateProvider {
+	return &krakenRateProvider{}
+}
@@ -0,0 +1,154 @@
+package types
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	JC "jxwatcher/core"
+)
+
+func TestKrakenRateProviderPrepare(t *testing.T) {
+	p := NewKrakenRateProvider()
+
+	q := url.Values{}
+	req, _ := http.NewRequest("GET", "http://localhost", nil)
+
+	p.Prepare(q, req,
+		rateAssetType{Id: 1, Symbol: "BTC", Code: "XBT"},
+		[]rateAssetType{{Id: 2781, Symbol: "USD", Code: "USD"}, {Id: 1027, Symbol: "ETH", Code: "ETH"}},
+	)
+
+	if q.Get("pair") != "XBTUSD,XBTETH" {
+		t.Errorf("Unexpected pair %q", q.Get("pair"))
+	}
+}
+
+func TestKrakenRateProviderParse(t *testing.T) {
+	rateProviderTurnOffLogs()
+	defer rateProviderTurnOnLogs()
+
+	p := NewKrakenRateProvider()
+	data := rateProviderFixture(t, "rates_kraken.json")
+
+	btc := rateAssetType{Id: 1, Symbol: "BTC", Code: "XBT"}
+	eth := rateAssetType{Id: 1027, Symbol: "ETH", Code: "ETH"}
+	usd := rateAssetType{Id: 2781, Symbol: "USD", Code: "USD"}
+	doge := rateAssetType{Id: 74, Symbol: "DOGE", Code: "XDG"}
+
+	rates, err := p.Parse(data, btc, []rateAssetType{usd, eth})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(rates) != 2 {
+		t.Fatalf("Expected 2 rates, got %d", len(rates))
+	}
+
+	rateProviderExpect(t, rates, 1, 2781, 112290.1)
+	rateProviderExpect(t, rates, 1, 1027, 1/0.036875)
+
+	rates, err = p.Parse(data, doge, []rateAssetType{usd})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rateProviderExpect(t, rates, 74, 2781, 0.2321)
+
+	if _, err := p.Parse(rateProviderFixture(t, "rates_kraken_error.json"), btc, []rateAssetType{usd}); err == nil {
+		t.Error("Expected kraken error array to fail")
+	}
+}
+
+func TestKrakenRateProviderResolvedPairs(t *testing.T) {
+	rateProviderTurnOffLogs()
+	defer rateProviderTurnOnLogs()
+
+	p := NewKrakenRateProvider()
+
+	pairs, err := p.parsePairs(rateProviderFixture(t, "rates_kraken_pairs.json"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	p.pairs = pairs
+
+	btc := rateAssetType{Id: 1, Symbol: "BTC", Code: "XBT"}
+	eth := rateAssetType{Id: 1027, Symbol: "ETH", Code: "ETH"}
+	usd := rateAssetType{Id: 2781, Symbol: "USD", Code: "USD"}
+	sol := rateAssetType{Id: 5426, Symbol: "SOL", Code: "SOL"}
+
+	q := url.Values{}
+	req, _ := http.NewRequest("GET", "http://localhost", nil)
+	p.Prepare(q, req, btc, []rateAssetType{usd, eth, sol})
+
+	if q.Get("pair") != "XXBTZUSD,XETHXXBT" {
+		t.Errorf("Expected reversed market for ETH and no unknown pair, got %q", q.Get("pair"))
+	}
+
+	data := rateProviderFixture(t, "rates_kraken.json")
+
+	rates, err := p.Parse(data, btc, []rateAssetType{eth})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rateProviderExpect(t, rates, 1, 1027, 1/0.036875)
+
+	rates, err = p.Parse(data, eth, []rateAssetType{btc})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rateProviderExpect(t, rates, 1027, 1, 0.036875)
+
+	if _, err := p.parsePairs(rateProviderFixture(t, "rates_kraken_error.json")); err == nil {
+		t.Error("Expected kraken error array to fail")
+	}
+}
+
+func TestKrakenRateProviderResolvePairs(t *testing.T) {
+	rateProviderTurnOffLogs()
+	defer rateProviderTurnOnLogs()
+
+	fixture := rateProviderFixture(t, "rates_kraken_pairs.json")
+
+	var hits atomic.Int64
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write(fixture)
+	}))
+	defer server.Close()
+
+	previous := krakenPairsEndpoint
+	krakenPairsEndpoint = server.URL
+	defer func() { krakenPairsEndpoint = previous }()
+
+	p := NewKrakenRateProvider()
+
+	if code := p.ResolvePairs(context.Background()); code != JC.NETWORKING_SUCCESS {
+		t.Fatalf("Expected pairs resolved, got %s", JC.GetNetworkingCodeName(code))
+	}
+	if p.resolvePair("XBT", "ETH") != "XETHXXBT" {
+		t.Errorf("Expected reversed pair, got %q", p.resolvePair("XBT", "ETH"))
+	}
+
+	p.ResolvePairs(context.Background())
+	if hits.Load() != 1 {
+		t.Errorf("Expected pairs cached for a day, got %d requests", hits.Load())
+	}
+
+	failing.Store(true)
+	p.resolved = p.resolved.Add(-krakenPairsRefresh)
+
+	if code := p.ResolvePairs(context.Background()); code != JC.NETWORKING_SUCCESS {
+		t.Errorf("Expected stale pairs kept on a failed refresh, got %s", JC.GetNetworkingCodeName(code))
+	}
+	if hits.Load() != 2 || p.resolvePair("XBT", "USD") != "XXBTZUSD" {
+		t.Error("Expected a refresh attempt keeping the cached pairs")
+	}
+}
@@ -0,0 +1,191 @@
+package types
+
+import (
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	JC "jxwatcher/core"
+)
+
+type rateProviderNullWriter struct{}
+
+func (rateProviderNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func rateProviderTurnOffLogs() {
+	log.SetOutput(rateProviderNullWriter{})
+}
+
+func rateProviderTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func rateProviderFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Failed to read fixture %s: %v", name, err)
+	}
+
+	return data
+}
+
+func rateProviderExpect(t *testing.T, rates []exchangeDataType, sourceId int64, targetId int64, expected float64) {
+	t.Helper()
+
+	for _, ex := range rates {
+		if ex.SourceId != sourceId || ex.TargetId != targetId {
+			continue
+		}
+
+		got, _ := ex.TargetAmount.Float64()
+		if diff := got - expected; diff > expected*1e-9 || diff < -expected*1e-9 {
+			t.Errorf("Expected rate %d->%d to be %v, got %v", sourceId, targetId, expected, got)
+		}
+		return
+	}
+
+	t.Errorf("Expected a rate for %d->%d", sourceId, targetId)
+}
+
+func TestRateProvidersInit(t *testing.T) {
+	rateProviderTurnOffLogs()
+	defer rateProviderTurnOnLogs()
+
+	cfg := UseConfig()
+	oldProvider, oldSymbols := cfg.RateProvider, cfg.RateSymbols
+	defer func() {
+		cfg.RateProvider, cfg.RateSymbols = oldProvider, oldSymbols
+	}()
+
+	cfg.RateProvider = JC.RATE_PROVIDER_KRAKEN
+	cfg.RateSymbols = map[string]map[string]string{
+		JC.RATE_PROVIDER_COINGECKO: {"scrt": "secret"},
+	}
+
+	r := &rateProvidersType{}
+	r.Init()
+
+	names := r.GetNames()
+	expected := []string{JC.RATE_PROVIDER_BINANCE, JC.RATE_PROVIDER_COINGECKO, JC.RATE_PROVIDER_CMC, JC.RATE_PROVIDER_KRAKEN}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected %d providers, got %v", len(expected), names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("Expected provider %s, got %s", expected[i], names[i])
+		}
+	}
+
+	if !r.Has(JC.RATE_PROVIDER_BINANCE) || r.Has("unknown") {
+		t.Error("Has returned unexpected result")
+	}
+
+	if r.Get(JC.STRING_EMPTY).GetName() != JC.RATE_PROVIDER_KRAKEN {
+		t.Error("Expected empty provider to fall back to the configured provider")
+	}
+	if r.Get("unknown").GetName() != JC.RATE_PROVIDER_KRAKEN {
+		t.Error("Expected unknown provider to fall back to the configured provider")
+	}
+	if r.Get(JC.RATE_PROVIDER_BINANCE).GetName() != JC.RATE_PROVIDER_BINANCE {
+		t.Error("Expected explicit provider to be returned")
+	}
+
+	pdt := NewPanelData()
+	pdt.Init()
+	pdt.SetProvider(JC.RATE_PROVIDER_COINGECKO)
+	if r.Resolve(pdt).GetName() != JC.RATE_PROVIDER_COINGECKO {
+		t.Error("Expected panel provider to be resolved")
+	}
+
+	cfg.RateProvider = "unknown"
+	r.Init()
+	if r.Get(JC.STRING_EMPTY).GetName() != JC.RATE_PROVIDER_CMC {
+		t.Error("Expected unknown configured provider to fall back to coinmarketcap")
+	}
+}
+
+func TestRateProvidersMapSymbol(t *testing.T) {
+	rateProviderTurnOffLogs()
+	defer rateProviderTurnOnLogs()
+
+	cfg := UseConfig()
+	oldSymbols := cfg.RateSymbols
+	defer func() {
+		cfg.RateSymbols = oldSymbols
+	}()
+
+	cfg.RateSymbols = map[string]map[string]string{
+		JC.RATE_PROVIDER_COINGECKO: {"scrt": "secret"},
+	}
+
+	r := &rateProvidersType{}
+	r.Init()
+
+	coingecko := r.Get(JC.RATE_PROVIDER_COINGECKO)
+	kraken := r.Get(JC.RATE_PROVIDER_KRAKEN)
+	cmc := r.Get(JC.RATE_PROVIDER_CMC)
+
+	cases := []struct {
+		provider RateProvider
+		symbol   string
+		expected string
+	}{
+		{coingecko, "SCRT", "secret"},
+		{coingecko, "BTC", "bitcoin"},
+		{coingecko, "EUR", "eur"},
+		{coingecko, "PEPE", "pepe"},
+		{kraken, "BTC", "XBT"},
+		{kraken, "doge", "XDG"},
+		{kraken, "ETH", "ETH"},
+		{r.Get(JC.RATE_PROVIDER_BINANCE), "eth", "ETH"},
+		{cmc, "SCRT", "SCRT"},
+		{coingecko, JC.STRING_EMPTY, JC.STRING_EMPTY},
+	}
+
+	for _, c := range cases {
+		if got := r.MapSymbol(c.provider, c.symbol); got != c.expected {
+			t.Errorf("Expected %s to map %q to %q, got %q", c.provider.GetName(), c.symbol, c.expected, got)
+		}
+	}
+}
+
+func TestRateProvidersNewAsset(t *testing.T) {
+	rateProviderTurnOffLogs()
+	defer rateProviderTurnOnLogs()
+
+	cm := &cryptosMapType{}
+	cm.Init()
+	cm.Insert("1", "1|BTC - Bitcoin")
+
+	oldMaps := UsePanelMaps().GetMaps()
+	UsePanelMaps().SetMaps(cm)
+	defer UsePanelMaps().SetMaps(oldMaps)
+
+	r := &rateProvidersType{}
+	r.Init()
+
+	asset, err := r.NewAsset(r.Get(JC.RATE_PROVIDER_KRAKEN), " 1 ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if asset.Id != 1 || asset.Symbol != "BTC" || asset.Code != "XBT" {
+		t.Errorf("Unexpected asset %+v", asset)
+	}
+
+	if _, err := r.NewAsset(r.Get(JC.RATE_PROVIDER_KRAKEN), "abc"); err == nil {
+		t.Error("Expected invalid id to fail")
+	}
+}
+
+func TestInvertRate(t *testing.T) {
+	got, _ := invertRate(big.NewFloat(4)).Float64()
+	if got != 0.25 {
+		t.Errorf("Expected 0.25, got %v", got)
+	}
+}
@@ -0,0 +1,97 @@
+package types
+
+import (
+	"time"
+
+	JC "jxwatcher/core"
+)
+
+type tickerBreakdownType struct {
+	Key    string
+	Title  string
+	Format string
+}
+
+type tickerBreakdownRowType struct {
+	tickerBreakdownType
+	Value     string
+	Timestamp time.Time
+	History   []float64
+}
+
+// Cache keys written next to the main value by the same fetcher
+var tickerBreakdowns = map[string][]tickerBreakdownType{
+	TickerTypeMarketCap: {
+		{TickerTypeMarketCap24hChange, "24h Change", TickerFormatShortPercentage},
+		{TickerTypeCMC10030dChange, "30d Change", TickerFormatShortPercentage},
+	},
+	TickerTypeCMC100: {
+		{TickerTypeCMC10024hChange, "24h Change", TickerFormatShortPercentage},
+	},
+	TickerTypeRSI: {
+		{TickerTypePulse, "Market Bias", TickerFormatPulse},
+		{TickerTypeRSIOversold, "Oversold", TickerFormatShortPercentage},
+		{TickerTypeRSINeutral, "Neutral", TickerFormatShortPercentage},
+		{TickerTypeRSIOverbought, "Overbought", TickerFormatShortPercentage},
+	},
+	TickerTypePulse: {
+		{TickerTypeRSI, "Crypto RSI", TickerFormatNumber},
+		{TickerTypeRSIOversold, "Oversold", TickerFormatShortPercentage},
+		{TickerTypeRSINeutral, "Neutral", TickerFormatShortPercentage},
+		{TickerTypeRSIOverbought, "Overbought", TickerFormatShortPercentage},
+	},
+	TickerTypeETF: {
+		{TickerTypeETFBTC, "BTC Flow", TickerFormatShortCurrencyWithSign},
+		{TickerTypeETFETH, "ETH Flow", TickerFormatShortCurrencyWithSign},
+	},
+	TickerTypeDominance: {
+		{TickerTypeETCDominance, "ETH Dominance", TickerFormatShortPercentage},
+		{TickerTypeOtherDominance, "Other Dominance", TickerFormatShortPercentage},
+	},
+	TickerTypePortfolio: {
+		{TickerTypePortfolioPnL, "Profit & Loss", TickerFormatShortPercentage},
+	},
+}
+
+// The ticker itself comes first, followed by its related sub metrics
+func GetTickerBreakdown(tickerType string) []tickerBreakdownType {
+	tdt := NewTickerFromSettings(tickerType)
+
+	title := tdt.GetTitle()
+	if title == JC.STRING_EMPTY {
+		title = tickerType
+	}
+
+	breakdown := []tickerBreakdownType{{tickerType, title, tdt.GetFormat()}}
+
+	return append(breakdown, tickerBreakdowns[tickerType]...)
+}
+
+func GetTickerBreakdownRows(tickerType string, points int) []tickerBreakdownRowType {
+	rows := []tickerBreakdownRowType{}
+
+	if tickerCacheStorage == nil {
+		return rows
+	}
+
+	for _, item := range GetTickerBreakdown(tickerType) {
+		row := tickerBreakdownRowType{tickerBreakdownType: item}
+		row.Title = JC.Translate(item.Title)
+
+		tdt := NewTickerData()
+		tdt.Init()
+		tdt.SetFormat(item.Format)
+		tdt.Set(tickerCacheStorage.Get(item.Key))
+
+		if tdt.HasData() {
+			row.Value = tdt.FormatContent()
+		}
+
+		row.Timestamp, _ = tickerCacheStorage.GetTimestamp(item.Key)
+		row.History = tickerCacheStorage.GetHistory(item.Key, points)
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
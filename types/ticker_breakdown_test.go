@@ -0,0 +1,90 @@
+package types
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+)
+
+type tickerBreakdownNullWriter struct{}
+
+func (tickerBreakdownNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func tickerBreakdownTurnOffLogs() {
+	log.SetOutput(tickerBreakdownNullWriter{})
+}
+
+func tickerBreakdownTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func TestTickerBreakdownKeys(t *testing.T) {
+	tickerBreakdownTurnOffLogs()
+	defer tickerBreakdownTurnOnLogs()
+
+	tests := map[string][]string{
+		TickerTypeMarketCap: {TickerTypeMarketCap, TickerTypeMarketCap24hChange, TickerTypeCMC10030dChange},
+		TickerTypeRSI:       {TickerTypeRSI, TickerTypePulse, TickerTypeRSIOversold, TickerTypeRSINeutral, TickerTypeRSIOverbought},
+		TickerTypeETF:       {TickerTypeETF, TickerTypeETFBTC, TickerTypeETFETH},
+		TickerTypeDominance: {TickerTypeDominance, TickerTypeETCDominance, TickerTypeOtherDominance},
+		TickerTypeFearGreed: {TickerTypeFearGreed},
+		"custom_gas":        {"custom_gas"},
+	}
+
+	for tickerType, expected := range tests {
+		breakdown := GetTickerBreakdown(tickerType)
+		if len(breakdown) != len(expected) {
+			t.Errorf("%s: expected %d keys, got %d", tickerType, len(expected), len(breakdown))
+			continue
+		}
+		for i, key := range expected {
+			if breakdown[i].Key != key {
+				t.Errorf("%s: expected key %s at %d, got %s", tickerType, key, i, breakdown[i].Key)
+			}
+		}
+	}
+
+	if title := GetTickerBreakdown(TickerTypeETF)[0].Title; title != "ETF Flow" {
+		t.Errorf("Expected the ticker title first, got %s", title)
+	}
+	if title := GetTickerBreakdown("custom_gas")[0].Title; title != "custom_gas" {
+		t.Errorf("Expected unknown tickers to fall back to their type, got %s", title)
+	}
+}
+
+func TestTickerBreakdownRows(t *testing.T) {
+	tickerBreakdownTurnOffLogs()
+	defer tickerBreakdownTurnOnLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	RegisterTickerCache().Init()
+
+	now := time.Now()
+	UseTickerCache().Insert(TickerTypeETF, "1500000", now)
+	UseTickerCache().Insert(TickerTypeETFBTC, "-2000000", now)
+	UseTickerCache().Insert(TickerTypeETFBTC, "-2500000", now.Add(time.Minute))
+
+	rows := GetTickerBreakdownRows(TickerTypeETF, TickerHistoryPoints)
+	if len(rows) != 3 {
+		t.Fatalf("Expected 3 rows, got %d", len(rows))
+	}
+
+	if rows[0].Value == "" || rows[0].Timestamp.IsZero() {
+		t.Errorf("Expected the main value with its timestamp, got %+v", rows[0])
+	}
+	if len(rows[1].History) != 2 || rows[1].History[1] != -2500000 {
+		t.Errorf("Expected BTC flow history, got %v", rows[1].History)
+	}
+	if rows[1].Value[0] != '-' {
+		t.Errorf("Expected signed BTC flow, got %s", rows[1].Value)
+	}
+	if rows[2].Value != "" || !rows[2].Timestamp.IsZero() || len(rows[2].History) != 0 {
+		t.Errorf("Expected empty ETH flow row, got %+v", rows[2])
+	}
+}
@@ -1,6 +1,10 @@
 package types
 
 import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	JC "jxwatcher/core"
@@ -8,18 +12,25 @@ import (
 
 var tickerCacheStorage *tickerDataCacheType = nil
 
+const TickerHistoryPoints = 48
+
 type tickerDataCacheSnapshot struct {
 	Data      []tickerDataCacheEntry `json:"data"`
 	Timestamp time.Time              `json:"timestamp"`
 }
 
 type tickerDataCacheEntry struct {
-	Key   string `json:"key"`
-	Value string `json:"value"`
+	Key       string    `json:"key"`
+	Value     string    `json:"value"`
+	Timestamp time.Time `json:"timestamp"`
+	History   []float64 `json:"history,omitempty"`
 }
 
 type tickerDataCacheType struct {
 	JC.Database
+	mu         sync.RWMutex
+	timestamps map[string]time.Time
+	history    map[string][]float64
 }
 
 func (tc *tickerDataCacheType) Init() {
@@ -27,6 +38,15 @@ func (tc *tickerDataCacheType) Init() {
 	tc.SetUpdateTreshold(10 * time.Second)
 }
 
+func (tc *tickerDataCacheType) Reset() {
+	tc.Database.Reset()
+
+	tc.mu.Lock()
+	tc.timestamps = make(map[string]time.Time)
+	tc.history = make(map[string][]float64)
+	tc.mu.Unlock()
+}
+
 func (tc *tickerDataCacheType) Get(key string) string {
 	if val, ok := tc.UseData().Load(key); ok {
 		if strVal, ok := val.(string); ok {
@@ -60,10 +80,65 @@ func (tc *tickerDataCacheType) Insert(key, value string, timestamp time.Time) {
 
 	// JC.Logf("Ticker received: [%s] = %s", key, value)
 
+	tc.record(key, value, timestamp)
+
 	tc.UseData().Store(key, value)
 	tc.UpdatedAt(&timestamp)
 }
 
+func (tc *tickerDataCacheType) GetTimestamp(key string) (time.Time, bool) {
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+
+	ts, ok := tc.timestamps[key]
+	return ts, ok
+}
+
+// Returns up to n of the most recent numeric values, oldest first
+func (tc *tickerDataCacheType) GetHistory(key string, n int) []float64 {
+	tc.mu.RLock()
+	defer tc.mu.RUnlock()
+
+	points := tc.history[key]
+	if n > 0 && len(points) > n {
+		points = points[len(points)-n:]
+	}
+
+	return slices.Clone(points)
+}
+
+// A point is only added when the value moved or the source reported a newer time,
+// refetching an unchanged api response must not flatten the chart
+func (tc *tickerDataCacheType) record(key, value string, timestamp time.Time) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if tc.timestamps == nil {
+		tc.timestamps = make(map[string]time.Time)
+		tc.history = make(map[string][]float64)
+	}
+
+	last, seen := tc.timestamps[key]
+	tc.timestamps[key] = timestamp
+
+	val, err := parseTickerHistoryValue(value)
+	if err != nil {
+		return
+	}
+
+	points := tc.history[key]
+	if seen && len(points) != 0 && points[len(points)-1] == val && !timestamp.After(last) {
+		return
+	}
+
+	points = append(points, val)
+	if len(points) > TickerHistoryPoints {
+		points = points[len(points)-TickerHistoryPoints:]
+	}
+
+	tc.history[key] = points
+}
+
 func (tc *tickerDataCacheType) Serialize() tickerDataCacheSnapshot {
 	var entries []tickerDataCacheEntry
 
@@ -71,9 +146,12 @@ func (tc *tickerDataCacheType) Serialize() tickerDataCacheSnapshot {
 		k, ok1 := key.(string)
 		v, ok2 := value.(string)
 		if ok1 && ok2 {
+			ts, _ := tc.GetTimestamp(k)
 			entries = append(entries, tickerDataCacheEntry{
-				Key:   k,
-				Value: v,
+				Key:       k,
+				Value:     v,
+				Timestamp: ts,
+				History:   tc.GetHistory(k, TickerHistoryPoints),
 			})
 		}
 		return true
@@ -94,13 +172,30 @@ func (tc *tickerDataCacheType) Serialize() tickerDataCacheSnapshot {
 func (tc *tickerDataCacheType) Hydrate(snapshot tickerDataCacheSnapshot) {
 	tc.Reset()
 
+	tc.mu.Lock()
 	for _, entry := range snapshot.Data {
 		tc.UseData().Store(entry.Key, entry.Value)
+
+		if !entry.Timestamp.IsZero() {
+			tc.timestamps[entry.Key] = entry.Timestamp
+		}
+		if len(entry.History) != 0 {
+			tc.history[entry.Key] = slices.Clone(entry.History)
+		}
 	}
+	tc.mu.Unlock()
 
 	tc.UpdatedAt(&snapshot.Timestamp)
 }
 
+// Pulse carries its sign and percentage, portfolio values are not numeric and skipped
+func parseTickerHistoryValue(value string) (float64, error) {
+	raw := strings.TrimSuffix(strings.TrimSpace(value), JC.STRING_PERCENTAGE)
+	raw = strings.ReplaceAll(raw, ",", JC.STRING_EMPTY)
+
+	return strconv.ParseFloat(raw, 64)
+}
+
 func NewTickerDataCacheSnapshot() *tickerDataCacheSnapshot {
 	return &tickerDataCacheSnapshot{}
 }
@@ -153,3 +153,62 @@ func TestTickerCacheGetRecentUpdates(t *testing.T) {
 
 	tickerTurnOnLogs()
 }
+
+func TestTickerCacheHistoryAndTimestamps(t *testing.T) {
+	tickerTurnOffLogs()
+	defer tickerTurnOnLogs()
+
+	tc := &tickerDataCacheType{}
+	tc.Init()
+
+	first := time.Date(2025, 10, 6, 21, 0, 0, 0, time.UTC)
+	tc.Insert(TickerTypePulse, "+1.50%", first)
+	tc.Insert(TickerTypePulse, "+1.50%", first)
+	tc.Insert(TickerTypePulse, "-0.25%", first)
+	tc.Insert(TickerTypePulse, "-0.25%", first.Add(time.Minute))
+
+	if got := tc.GetHistory(TickerTypePulse, 0); len(got) != 3 || got[0] != 1.5 || got[1] != -0.25 {
+		t.Errorf("Expected repeated responses to be skipped, got %v", got)
+	}
+
+	if ts, ok := tc.GetTimestamp(TickerTypePulse); !ok || !ts.Equal(first.Add(time.Minute)) {
+		t.Errorf("Expected latest timestamp, got %v", ts)
+	}
+
+	tc.Insert(TickerTypePortfolio, "34,000 USDT · 25 ETH", first)
+	if len(tc.GetHistory(TickerTypePortfolio, 0)) != 0 {
+		t.Error("Expected non numeric values to be kept out of the history")
+	}
+	if _, ok := tc.GetTimestamp(TickerTypePortfolio); !ok {
+		t.Error("Expected non numeric values to still record their timestamp")
+	}
+
+	for i := range TickerHistoryPoints + 10 {
+		tc.Insert(TickerTypeRSI, "1,000", first.Add(time.Duration(i)*time.Minute))
+	}
+
+	if got := tc.GetHistory(TickerTypeRSI, 0); len(got) != TickerHistoryPoints || got[0] != 1000 {
+		t.Errorf("Expected history to be capped at %d points, got %d", TickerHistoryPoints, len(got))
+	}
+	if got := tc.GetHistory(TickerTypeRSI, 5); len(got) != 5 {
+		t.Errorf("Expected 5 most recent points, got %d", len(got))
+	}
+
+	snapshot := tc.Serialize()
+
+	restored := &tickerDataCacheType{}
+	restored.Init()
+	restored.Hydrate(snapshot)
+
+	if got := restored.GetHistory(TickerTypePulse, 0); len(got) != 3 {
+		t.Errorf("Expected history to survive hydration, got %v", got)
+	}
+	if ts, ok := restored.GetTimestamp(TickerTypePulse); !ok || !ts.Equal(first.Add(time.Minute)) {
+		t.Errorf("Expected timestamp to survive hydration, got %v", ts)
+	}
+
+	restored.Reset()
+	if len(restored.GetHistory(TickerTypePulse, 0)) != 0 {
+		t.Error("Expected reset to clear the history")
+	}
+}
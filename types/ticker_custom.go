@@ -0,0 +1,125 @@
+package types
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/buger/jsonparser"
+
+	JC "jxwatcher/core"
+)
+
+const TickerTypeCustomPrefix = "custom_"
+
+var customTickerIdPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+type customTickerConfigType struct {
+	ID            string            `json:"id"`
+	Title         string            `json:"title"`
+	Endpoint      string            `json:"endpoint"`
+	Params        map[string]string `json:"params,omitempty"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	ValuePath     []string          `json:"value_path"`
+	TimestampPath []string          `json:"timestamp_path,omitempty"`
+	Format        string            `json:"format"`
+	Interval      int64             `json:"interval"`
+}
+
+func (c *customTickerConfigType) parseJSON(data []byte) {
+	if val, err := jsonparser.GetString(data, "id"); err == nil {
+		c.ID = val
+	}
+	if val, err := jsonparser.GetString(data, "title"); err == nil {
+		c.Title = val
+	}
+	if val, err := jsonparser.GetString(data, "endpoint"); err == nil {
+		c.Endpoint = val
+	}
+	if val, err := jsonparser.GetString(data, "format"); err == nil {
+		c.Format = val
+	}
+	if val, err := jsonparser.GetInt(data, "interval"); err == nil {
+		c.Interval = val
+	}
+
+	parseMap := func(key string) map[string]string {
+		values := map[string]string{}
+		jsonparser.ObjectEach(data, func(k []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+			if val, err := jsonparser.ParseString(value); err == nil {
+				values[string(k)] = val
+			}
+			return nil
+		}, key)
+		return values
+	}
+
+	parsePath := func(key string) []string {
+		path := []string{}
+		jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+			if val, err := jsonparser.ParseString(value); err == nil {
+				path = append(path, val)
+			}
+		}, key)
+		return path
+	}
+
+	c.Params = parseMap("params")
+	c.Headers = parseMap("headers")
+	c.ValuePath = parsePath("value_path")
+	c.TimestampPath = parsePath("timestamp_path")
+}
+
+func (c *customTickerConfigType) IsValid() bool {
+	if !customTickerIdPattern.MatchString(c.ID) {
+		return false
+	}
+
+	parsed, err := url.Parse(c.Endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == JC.STRING_EMPTY {
+		return false
+	}
+
+	return len(c.ValuePath) != 0
+}
+
+func (c *customTickerConfigType) GetType() string {
+	return TickerTypeCustomPrefix + c.ID
+}
+
+func (c *customTickerConfigType) GetTitle() string {
+	if c.Title == JC.STRING_EMPTY {
+		return c.ID
+	}
+	return c.Title
+}
+
+func (c *customTickerConfigType) GetFormat() string {
+	if !isTickerFormat(c.Format) {
+		return TickerFormatNumber
+	}
+	return c.Format
+}
+
+// Falls back to the app delay, never faster than every 10 seconds
+func (c *customTickerConfigType) GetInterval() time.Duration {
+	interval := c.Interval
+	if interval <= 0 {
+		interval = UseConfig().Delay
+	}
+	return time.Duration(max(interval, 10)) * time.Second
+}
+
+func IsCustomTickerType(tickerType string) bool {
+	return strings.HasPrefix(tickerType, TickerTypeCustomPrefix)
+}
+
+func GetCustomTicker(tickerType string) *customTickerConfigType {
+	for _, ct := range UseConfig().GetCustomTickers() {
+		if ct.GetType() == tickerType {
+			return &ct
+		}
+	}
+	return nil
+}
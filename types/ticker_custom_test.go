@@ -0,0 +1,167 @@
+package types
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+)
+
+type customTickerNullWriter struct{}
+
+func (customTickerNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func customTickerTurnOffLogs() {
+	log.SetOutput(customTickerNullWriter{})
+}
+
+func customTickerTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func useCustomTickerConfig(t *testing.T, cfg *configType) {
+	configMu.Lock()
+	previous := configStorage
+	configStorage = cfg
+	configMu.Unlock()
+
+	t.Cleanup(func() {
+		configMu.Lock()
+		configStorage = previous
+		configMu.Unlock()
+	})
+}
+
+func TestCustomTickerParseJSON(t *testing.T) {
+	customTickerTurnOffLogs()
+	defer customTickerTurnOnLogs()
+
+	raw := []byte(`{
+		"id": "eth_gas",
+		"title": "ETH Gas",
+		"endpoint": "https://example.com/gas?chain=1",
+		"params": {"unit": "gwei"},
+		"headers": {"X-Api-Key": "secret"},
+		"value_path": ["result", "fast"],
+		"timestamp_path": ["result", "time"],
+		"format": "number",
+		"interval": 120
+	}`)
+
+	ct := customTickerConfigType{}
+	ct.parseJSON(raw)
+
+	if ct.ID != "eth_gas" || ct.Title != "ETH Gas" || ct.Endpoint != "https://example.com/gas?chain=1" {
+		t.Errorf("Unexpected identity fields: %+v", ct)
+	}
+	if ct.Params["unit"] != "gwei" || ct.Headers["X-Api-Key"] != "secret" {
+		t.Errorf("Expected params and headers to be parsed, got %v %v", ct.Params, ct.Headers)
+	}
+	if len(ct.ValuePath) != 2 || ct.ValuePath[1] != "fast" {
+		t.Errorf("Expected value path [result fast], got %v", ct.ValuePath)
+	}
+	if len(ct.TimestampPath) != 2 || ct.TimestampPath[1] != "time" {
+		t.Errorf("Expected timestamp path [result time], got %v", ct.TimestampPath)
+	}
+	if ct.Interval != 120 || ct.GetInterval() != 120*time.Second {
+		t.Errorf("Expected 120s interval, got %v", ct.GetInterval())
+	}
+	if !ct.IsValid() {
+		t.Error("Expected custom ticker to be valid")
+	}
+	if ct.GetType() != "custom_eth_gas" || !IsCustomTickerType(ct.GetType()) {
+		t.Errorf("Unexpected ticker type %s", ct.GetType())
+	}
+}
+
+func TestCustomTickerValidation(t *testing.T) {
+	customTickerTurnOffLogs()
+	defer customTickerTurnOnLogs()
+
+	tests := []struct {
+		name   string
+		config customTickerConfigType
+		valid  bool
+	}{
+		{"valid", customTickerConfigType{ID: "gas", Endpoint: "https://example.com", ValuePath: []string{"v"}}, true},
+		{"uppercase id", customTickerConfigType{ID: "Gas", Endpoint: "https://example.com", ValuePath: []string{"v"}}, false},
+		{"empty id", customTickerConfigType{Endpoint: "https://example.com", ValuePath: []string{"v"}}, false},
+		{"bad scheme", customTickerConfigType{ID: "gas", Endpoint: "ftp://example.com", ValuePath: []string{"v"}}, false},
+		{"no host", customTickerConfigType{ID: "gas", Endpoint: "https://", ValuePath: []string{"v"}}, false},
+		{"no value path", customTickerConfigType{ID: "gas", Endpoint: "https://example.com"}, false},
+	}
+
+	for _, tt := range tests {
+		if tt.config.IsValid() != tt.valid {
+			t.Errorf("%s: expected valid=%v", tt.name, tt.valid)
+		}
+	}
+}
+
+func TestCustomTickerDefaults(t *testing.T) {
+	customTickerTurnOffLogs()
+	defer customTickerTurnOnLogs()
+
+	useCustomTickerConfig(t, &configType{Delay: 5})
+
+	ct := customTickerConfigType{ID: "gas", Format: "bogus"}
+
+	if ct.GetTitle() != "gas" {
+		t.Errorf("Expected title to fall back to id, got %s", ct.GetTitle())
+	}
+	if ct.GetFormat() != TickerFormatNumber {
+		t.Errorf("Expected format to fall back to number, got %s", ct.GetFormat())
+	}
+	if ct.GetInterval() != 10*time.Second {
+		t.Errorf("Expected interval to be clamped to 10s, got %v", ct.GetInterval())
+	}
+
+	UseConfig().Delay = 90
+	if ct.GetInterval() != 90*time.Second {
+		t.Errorf("Expected interval to fall back to delay, got %v", ct.GetInterval())
+	}
+}
+
+func TestCustomTickerLookup(t *testing.T) {
+	customTickerTurnOffLogs()
+	defer customTickerTurnOnLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	useCustomTickerConfig(t, &configType{
+		CustomTickers: []customTickerConfigType{
+			{ID: "gas", Title: "Gas", Endpoint: "https://example.com", ValuePath: []string{"v"}},
+			{ID: "broken", Endpoint: "not a url", ValuePath: []string{"v"}},
+		},
+	})
+
+	if ct := GetCustomTicker("custom_gas"); ct == nil || ct.Title != "Gas" {
+		t.Errorf("Expected to find custom_gas, got %v", ct)
+	}
+	if GetCustomTicker("custom_broken") != nil {
+		t.Error("Expected invalid custom ticker to be ignored")
+	}
+	if GetCustomTicker(TickerTypeCMC100) != nil {
+		t.Error("Expected built in ticker not to be a custom ticker")
+	}
+
+	found := false
+	for _, tk := range defaultTickers() {
+		if tk.Type == "custom_gas" {
+			found = true
+			if tk.Title != "Gas" {
+				t.Errorf("Expected default title Gas, got %s", tk.Title)
+			}
+		}
+		if tk.Type == "custom_broken" {
+			t.Error("Expected invalid custom ticker not to be listed")
+		}
+	}
+	if !found {
+		t.Error("Expected custom ticker to be part of the default tickers")
+	}
+}
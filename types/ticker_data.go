@@ -15,6 +15,7 @@ const TickerFormatShortCurrencyWithSign = "shortcurrency_withsign"
 const TickerFormatPercentage = "percentage"
 const TickerFormatShortPercentage = "shortpercentage"
 const TickerFormatPulse = "pulse"
+const TickerFormatPortfolio = "portfolio"
 
 const TickerTypeMarketCap = "market_cap"
 const TickerTypePulse = "pulse"
@@ -34,6 +35,9 @@ const TickerTypeOtherDominance = "other_dominance"
 const TickerTypeMarketCap24hChange = "market_cap_24_percentage"
 const TickerTypeCMC10024hChange = "cmc100_24_percentage"
 const TickerTypeCMC10030dChange = "market_cap_30_percentage"
+const TickerTypePortfolio = "portfolio"
+const TickerTypePortfolioPnL = "portfolio_pnl_percentage"
+const TickerTypeFiatRate = "fiat_rate"
 
 type TickerData interface {
 	Init()
@@ -255,6 +259,11 @@ func (p *tickerDataType) FormatContent() string {
 	raw := p.Get()
 	format := p.GetFormat()
 
+	// Pulse is stored with its sign and percentage
+	if format == TickerFormatPulse {
+		return JC.LocalizeDecimal(raw)
+	}
+
 	val, err := strconv.ParseFloat(raw, 64)
 	if err != nil {
 		return raw
@@ -262,35 +271,54 @@ func (p *tickerDataType) FormatContent() string {
 
 	switch format {
 	case TickerFormatNodecimal:
-		return strconv.FormatFloat(val, 'f', 0, 64)
+		return JC.FormatDecimal(val, 0)
 
 	case TickerFormatNumber:
-		return strconv.FormatFloat(val, 'f', 2, 64)
+		return JC.FormatDecimal(val, 2)
 
 	case TickerFormatCurrency:
-		return JC.STRING_DOLLAR + JC.FormatNumberWithCommas(val, 2)
+		val, fiat := displayFiatValue(val)
+		return JC.FormatFiat(val, fiat)
 
 	case TickerFormatShortCurrency:
-		return JC.FormatShortCurrency(raw)
+		val, fiat := displayFiatValue(val)
+		return JC.FormatShortFiat(strconv.FormatFloat(val, 'f', -1, 64), fiat)
 
 	case TickerFormatShortCurrencyWithSign:
 		sign := JC.STRING_PLUS
 		if val < 0 {
 			sign = JC.STRING_MINUS
 		}
-		return sign + JC.FormatShortCurrency(strconv.FormatFloat(math.Abs(val), 'f', -1, 64))
+		val, fiat := displayFiatValue(math.Abs(val))
+		return sign + JC.FormatShortFiat(strconv.FormatFloat(val, 'f', -1, 64), fiat)
 
 	case TickerFormatPercentage:
-		return raw + JC.STRING_PERCENTAGE_DIVIDE
+		return JC.LocalizeDecimal(raw) + JC.STRING_PERCENTAGE_DIVIDE
 
 	case TickerFormatShortPercentage:
-		return strconv.FormatFloat(val, 'f', 1, 64) + JC.STRING_PERCENTAGE
+		return JC.FormatDecimal(val, 1) + JC.STRING_PERCENTAGE
 
 	default:
 		return raw
 	}
 }
 
+// Currency tickers are fetched in USD, they stay in USD until the display fiat rate is known
+func displayFiatValue(val float64) (float64, JC.FiatType) {
+	usd, _ := JC.GetFiat(JC.FIAT_USD)
+	if UseConfig() == nil {
+		return val, usd
+	}
+
+	fiat := UseConfig().GetDisplayFiat()
+	rate, ok := GetFiatRate(fiat)
+	if !ok {
+		return val, usd
+	}
+
+	return val * rate, fiat
+}
+
 func (p *tickerDataType) DidChange() bool {
 	if p.oldKey == JC.STRING_EMPTY {
 		return false
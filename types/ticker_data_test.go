@@ -228,3 +228,53 @@ func TestTickerDataFormatVariants(t *testing.T) {
 
 	tickerDataTurnOnLogs()
 }
+
+func TestTickerDataFormatContentLocale(t *testing.T) {
+	tickerDataTurnOffLogs()
+	defer tickerDataTurnOnLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	JC.RegisterLocaleManager().Init()
+	defer JC.UseLocale().SetLocale(JC.LOCALE_ENGLISH)
+
+	tests := []struct {
+		format string
+		raw    string
+		en     string
+		de     string
+	}{
+		{TickerFormatNumber, "1234.56", "1234.56", "1234,56"},
+		{TickerFormatCurrency, "1234.56", "$1,234.56", "$1.234,56"},
+		{TickerFormatShortCurrency, "1234567", "$1.23M", "$1,23M"},
+		{TickerFormatPercentage, "45.5", "45.5/100", "45,5/100"},
+		{TickerFormatShortPercentage, "12.3456", "12.3%", "12,3%"},
+		{TickerFormatPulse, "+1.25%", "+1.25%", "+1,25%"},
+	}
+
+	td := NewTickerData()
+	td.Init()
+
+	for _, tt := range tests {
+		td.SetFormat(tt.format)
+		td.Set(tt.raw)
+
+		JC.UseLocale().SetLocale(JC.LOCALE_ENGLISH)
+		if got := td.FormatContent(); got != tt.en {
+			t.Errorf("[en] %s: expected %q, got %q", tt.format, tt.en, got)
+		}
+
+		JC.UseLocale().SetLocale(JC.LOCALE_GERMAN)
+		if got := td.FormatContent(); got != tt.de {
+			t.Errorf("[de] %s: expected %q, got %q", tt.format, tt.de, got)
+		}
+	}
+
+	RegisterTickerCache().Init()
+	JC.UseLocale().SetLocale(JC.LOCALE_INDONESIAN)
+	for _, row := range GetTickerBreakdownRows(TickerTypeRSI, TickerHistoryPoints) {
+		if row.Title == "Oversold" {
+			t.Errorf("Expected breakdown titles to be translated, got %q", row.Title)
+		}
+	}
+}
@@ -91,6 +91,35 @@ func TestTickersMapFilterAndReset(t *testing.T) {
 	tickerMapTurnOnLogs()
 }
 
+func TestTickersMapRemoveByType(t *testing.T) {
+	tickerMapTurnOffLogs()
+	t.Setenv("FYNE_STORAGE", t.TempDir())
+	test.NewApp()
+
+	tm := &tickersMapType{}
+	tm.Init()
+
+	td1 := NewTickerData()
+	td1.Init()
+	td1.SetType(TickerTypeMarketCap)
+
+	td2 := NewPortfolioTicker()
+	td2.Init()
+
+	tm.SetData([]TickerData{td1, td2})
+
+	if !tm.RemoveByType(TickerTypePortfolio) {
+		t.Error("Expected portfolio ticker to be removed")
+	}
+	if len(tm.GetData()) != 1 || !tm.GetData()[0].IsType(TickerTypeMarketCap) {
+		t.Error("Expected only the market cap ticker to remain")
+	}
+	if tm.RemoveByType(TickerTypePortfolio) {
+		t.Error("Expected nothing to remove the second time")
+	}
+	tickerMapTurnOnLogs()
+}
+
 func TestTickersMapSerialize(t *testing.T) {
 	tickerMapTurnOffLogs()
 	t.Setenv("FYNE_STORAGE", t.TempDir())
@@ -0,0 +1,115 @@
+package types
+
+import (
+	"sync"
+	"time"
+)
+
+var tickerScheduleStorage *tickerScheduleType = nil
+
+// Upstream hints further away than this are treated as bogus and ignored
+const tickerScheduleMaxHint = 24 * time.Hour
+
+// Upstream data is rarely published exactly on its announced time
+const tickerScheduleHintGrace = 5 * time.Second
+
+// Sources that cannot change faster than this are not polled more often, whatever the delay is
+var tickerMinimumIntervals = map[string]time.Duration{
+	TickerTypeFearGreed:    time.Hour,
+	TickerTypeAltcoinIndex: time.Hour,
+	TickerTypeETF:          time.Hour,
+	TickerTypeFiatRate:     time.Hour,
+	TickerTypeMarketCap:    5 * time.Minute,
+	TickerTypeDominance:    5 * time.Minute,
+	TickerTypeRSI:          5 * time.Minute,
+}
+
+type tickerScheduleType struct {
+	mu   sync.RWMutex
+	next map[string]time.Time
+}
+
+func (ts *tickerScheduleType) Init() {
+	ts.Reset()
+}
+
+func (ts *tickerScheduleType) Reset() {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.next = make(map[string]time.Time)
+}
+
+// Shared ticker cadence, also used to retry failed fetches
+func (ts *tickerScheduleType) GetBaseInterval() time.Duration {
+	return time.Duration(max(UseConfig().Delay, 30)) * time.Second
+}
+
+func (ts *tickerScheduleType) GetInterval(tickerType string) time.Duration {
+	if IsCustomTickerType(tickerType) {
+		if ct := GetCustomTicker(tickerType); ct != nil {
+			return ct.GetInterval()
+		}
+	}
+
+	return max(ts.GetBaseInterval(), tickerMinimumIntervals[tickerType])
+}
+
+// Called after a successful fetch, a later upstream next update time overrides the interval
+func (ts *tickerScheduleType) Schedule(tickerType string, hint time.Time) {
+	now := time.Now()
+	next := now.Add(ts.GetInterval(tickerType))
+
+	if !hint.IsZero() && hint.After(next) && hint.Sub(now) <= tickerScheduleMaxHint {
+		next = hint.Add(tickerScheduleHintGrace)
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.next[tickerType] = next
+}
+
+// Called when a fetch is dispatched, a failed fetch is retried once the base interval passed
+func (ts *tickerScheduleType) Postpone(tickerType string) {
+	next := time.Now().Add(ts.GetBaseInterval())
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.next[tickerType] = next
+}
+
+func (ts *tickerScheduleType) GetNext(tickerType string) time.Time {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	return ts.next[tickerType]
+}
+
+func (ts *tickerScheduleType) IsDue(tickerType string) bool {
+	return !time.Now().Before(ts.GetNext(tickerType))
+}
+
+func (ts *tickerScheduleType) Until(tickerType string) time.Duration {
+	return max(time.Until(ts.GetNext(tickerType)), 0)
+}
+
+func scheduleTicker(tickerType string, hint time.Time) {
+	if tickerScheduleStorage != nil {
+		tickerScheduleStorage.Schedule(tickerType, hint)
+	}
+}
+
+func RegisterTickerSchedule() *tickerScheduleType {
+	if tickerScheduleStorage == nil {
+		tickerScheduleStorage = &tickerScheduleType{}
+		tickerScheduleStorage.Init()
+	}
+
+	return tickerScheduleStorage
+}
+
+func UseTickerSchedule() *tickerScheduleType {
+	return tickerScheduleStorage
+}
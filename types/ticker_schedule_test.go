@@ -0,0 +1,103 @@
+package types
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+)
+
+type tickerScheduleNullWriter struct{}
+
+func (tickerScheduleNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func tickerScheduleTurnOffLogs() {
+	log.SetOutput(tickerScheduleNullWriter{})
+}
+
+func tickerScheduleTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func TestTickerScheduleIntervals(t *testing.T) {
+	tickerScheduleTurnOffLogs()
+	defer tickerScheduleTurnOnLogs()
+
+	useCustomTickerConfig(t, &configType{
+		Delay: 10,
+		CustomTickers: []customTickerConfigType{
+			{ID: "gas", Endpoint: "https://example.com", ValuePath: []string{"v"}, Interval: 15},
+		},
+	})
+
+	ts := &tickerScheduleType{}
+	ts.Init()
+
+	if ts.GetBaseInterval() != 30*time.Second {
+		t.Errorf("Expected base interval to be at least 30s, got %v", ts.GetBaseInterval())
+	}
+	if ts.GetInterval(TickerTypeCMC100) != 30*time.Second {
+		t.Errorf("Expected CMC100 to follow the base interval, got %v", ts.GetInterval(TickerTypeCMC100))
+	}
+	if ts.GetInterval(TickerTypeFearGreed) != time.Hour {
+		t.Errorf("Expected fear and greed to be polled hourly, got %v", ts.GetInterval(TickerTypeFearGreed))
+	}
+	if ts.GetInterval("custom_gas") != 15*time.Second {
+		t.Errorf("Expected custom ticker to use its own interval, got %v", ts.GetInterval("custom_gas"))
+	}
+
+	UseConfig().Delay = 7200
+	if ts.GetInterval(TickerTypeFearGreed) != 2*time.Hour {
+		t.Errorf("Expected a longer delay to win over the minimum, got %v", ts.GetInterval(TickerTypeFearGreed))
+	}
+}
+
+func TestTickerScheduleHints(t *testing.T) {
+	tickerScheduleTurnOffLogs()
+	defer tickerScheduleTurnOnLogs()
+
+	useCustomTickerConfig(t, &configType{Delay: 60})
+
+	ts := &tickerScheduleType{}
+	ts.Init()
+
+	if !ts.IsDue(TickerTypeCMC100) || ts.Until(TickerTypeCMC100) != 0 {
+		t.Error("Expected unscheduled ticker to be due")
+	}
+
+	ts.Schedule(TickerTypeCMC100, time.Time{})
+	if ts.IsDue(TickerTypeCMC100) {
+		t.Error("Expected ticker not to be due right after a fetch")
+	}
+	if until := ts.Until(TickerTypeCMC100); until <= 55*time.Second || until > 60*time.Second {
+		t.Errorf("Expected about a minute until the next fetch, got %v", until)
+	}
+
+	hint := time.Now().Add(10 * time.Minute)
+	ts.Schedule(TickerTypeCMC100, hint)
+	if next := ts.GetNext(TickerTypeCMC100); !next.Equal(hint.Add(tickerScheduleHintGrace)) {
+		t.Errorf("Expected next update hint to be honored, got %v", next)
+	}
+
+	ts.Schedule(TickerTypeCMC100, time.Now().Add(10*time.Second))
+	if until := ts.Until(TickerTypeCMC100); until <= 55*time.Second {
+		t.Errorf("Expected an earlier hint not to shorten the interval, got %v", until)
+	}
+
+	ts.Schedule(TickerTypeCMC100, time.Now().Add(48*time.Hour))
+	if until := ts.Until(TickerTypeCMC100); until > time.Minute {
+		t.Errorf("Expected a bogus hint to be ignored, got %v", until)
+	}
+
+	ts.Postpone(TickerTypeFearGreed)
+	if until := ts.Until(TickerTypeFearGreed); until <= 55*time.Second || until > time.Minute {
+		t.Errorf("Expected a dispatched fetch to be retried after the base interval, got %v", until)
+	}
+
+	ts.Reset()
+	if !ts.IsDue(TickerTypeCMC100) || !ts.IsDue(TickerTypeFearGreed) {
+		t.Error("Expected reset to make every ticker due")
+	}
+}
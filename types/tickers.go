@@ -0,0 +1,464 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"github.com/buger/jsonparser"
+
+	JC "jxwatcher/core"
+)
+
+var tickersMu sync.RWMutex
+var tickersStorage tickersType = nil
+
+type tickerType struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Format string `json:"format"`
+	Hidden bool   `json:"hidden"`
+
+	Colors colorRulesType `json:"colors"`
+}
+
+type tickersType []tickerType
+
+func (t *tickersType) load() *tickersType {
+	data, ok := JC.LoadFileFromStorage("tickers.json")
+	if !ok {
+		*t = defaultTickers()
+		return t
+	}
+
+	if err := t.parseJSON([]byte(data)); err != nil {
+		JC.Logln(err)
+		*t = defaultTickers()
+		return t
+	}
+
+	JC.Logln("Tickers Loaded")
+
+	return t
+}
+
+// Unknown types are dropped, missing ones are appended with their defaults so new tickers still show up
+func (t *tickersType) parseJSON(data []byte) error {
+	defaults := defaultTickers()
+	seen := map[string]bool{}
+	parsed := tickersType{}
+
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var ticker tickerType
+
+		if val, e := jsonparser.GetString(value, "type"); e == nil {
+			ticker.Type = val
+		}
+		if val, e := jsonparser.GetString(value, "title"); e == nil {
+			ticker.Title = val
+		}
+		if val, e := jsonparser.GetString(value, "format"); e == nil {
+			ticker.Format = val
+		}
+		if val, e := jsonparser.GetBoolean(value, "hidden"); e == nil {
+			ticker.Hidden = val
+		}
+		if val, _, _, e := jsonparser.Get(value, "colors"); e == nil {
+			rules, err := ParseColorRules(val)
+			if err != nil {
+				JC.Logln("Ignoring invalid ticker colors:", string(val))
+			} else {
+				ticker.Colors = rules
+			}
+		}
+
+		def := defaults.get(ticker.Type)
+		if def == nil || seen[ticker.Type] {
+			JC.Logln("Ignoring unknown or duplicated ticker:", string(value))
+			return
+		}
+
+		if ticker.Title == JC.STRING_EMPTY {
+			ticker.Title = def.Title
+		}
+		if !isTickerFormat(ticker.Format) {
+			ticker.Format = def.Format
+		}
+		if ticker.Colors == nil {
+			ticker.Colors = def.Colors
+		}
+
+		seen[ticker.Type] = true
+		parsed = append(parsed, ticker)
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to parse tickers.json: %w", err)
+	}
+
+	for _, def := range defaults {
+		if !seen[def.Type] {
+			parsed = append(parsed, def)
+		}
+	}
+
+	*t = parsed
+
+	return nil
+}
+
+func (t *tickersType) save() bool {
+	return JC.SaveFileToStorage("tickers.json", *t)
+}
+
+func (t tickersType) get(tickerType string) *tickerType {
+	for i := range t {
+		if t[i].Type == tickerType {
+			return &t[i]
+		}
+	}
+	return nil
+}
+
+func defaultTickers() tickersType {
+	signed := func() colorRulesType {
+		return colorRulesType{
+			colorSign(JC.COLOR_RULE_POSITIVE, JC.ColorNameGreen),
+			colorSign(JC.COLOR_RULE_NEGATIVE, JC.ColorNameRed),
+			colorFallback(JC.ColorNameDarkGrey),
+		}
+	}
+
+	tickers := tickersType{
+		{
+			Type: TickerTypeMarketCap, Title: "Market Cap", Format: TickerFormatShortCurrency,
+			Colors: colorRulesType{
+				colorSign(JC.COLOR_RULE_POSITIVE, JC.ColorNameGreen),
+				colorSign(JC.COLOR_RULE_NEGATIVE, JC.ColorNameRed),
+			},
+		},
+		{
+			Type: TickerTypePulse, Title: "Market Bias", Format: TickerFormatPulse,
+			Colors: signed(),
+		},
+		{
+			Type: TickerTypeCMC100, Title: "CMC100", Format: TickerFormatCurrency,
+			Colors: colorRulesType{
+				colorBand(0, JC.ColorNameGreen),
+				colorFallback(JC.ColorNameRed),
+			},
+		},
+		{
+			Type: TickerTypeAltcoinIndex, Title: "Altcoin Index", Format: TickerFormatPercentage,
+			Colors: colorRulesType{
+				colorBand(75, JC.ColorNameBlue),
+				colorBand(50, JC.ColorNameLightPurple),
+				colorBand(25, JC.ColorNameLightOrange),
+				colorFallback(JC.ColorNameOrange),
+			},
+		},
+		{
+			Type: TickerTypeFearGreed, Title: "Fear & Greed", Format: TickerFormatPercentage,
+			Colors: colorRulesType{
+				colorBand(75, JC.ColorNameGreen),
+				colorBand(55, JC.ColorNameTeal),
+				colorBand(45, JC.ColorNameYellow),
+				colorBand(25, JC.ColorNameOrange),
+				colorFallback(JC.ColorNameRed),
+			},
+		},
+		{
+			Type: TickerTypeRSI, Title: "Crypto RSI", Format: TickerFormatNumber,
+			Colors: colorRulesType{
+				colorBand(70, JC.ColorNameGreen),
+				colorBand(55, JC.ColorNameDarkGreen),
+				colorBand(45, JC.ColorNameDarkGrey),
+				colorBand(30, JC.ColorNameDarkRed),
+				colorFallback(JC.ColorNameRed),
+			},
+		},
+		{
+			Type: TickerTypeETF, Title: "ETF Flow", Format: TickerFormatShortCurrencyWithSign,
+			Colors: signed(),
+		},
+		{
+			Type: TickerTypeDominance, Title: "Dominance", Format: TickerFormatShortPercentage,
+			Colors: colorRulesType{
+				colorBand(50, JC.ColorNameGreen),
+				colorFallback(JC.ColorNameRed),
+			},
+		},
+		{
+			Type: TickerTypePortfolio, Title: "Portfolio", Format: TickerFormatPortfolio,
+			Colors: signed(),
+		},
+	}
+
+	for _, ct := range UseConfig().GetCustomTickers() {
+		tickers = append(tickers, tickerType{
+			Type:   ct.GetType(),
+			Title:  ct.GetTitle(),
+			Format: ct.GetFormat(),
+			Colors: colorRulesType{},
+		})
+	}
+
+	return tickers
+}
+
+func isTickerFormat(format string) bool {
+	switch format {
+	case TickerFormatNodecimal, TickerFormatNumber, TickerFormatCurrency, TickerFormatShortCurrency,
+		TickerFormatShortCurrencyWithSign, TickerFormatPercentage, TickerFormatShortPercentage,
+		TickerFormatPulse, TickerFormatPortfolio:
+		return true
+	}
+	return false
+}
+
+// Whether the data source behind the ticker is configured, regardless of the user hiding it
+func IsTickerAvailable(tickerType string) bool {
+	switch tickerType {
+	case TickerTypeMarketCap:
+		return UseConfig().CanDoMarketCap()
+	case TickerTypeCMC100:
+		return UseConfig().CanDoCMC100()
+	case TickerTypeAltcoinIndex:
+		return UseConfig().CanDoAltSeason()
+	case TickerTypeFearGreed:
+		return UseConfig().CanDoFearGreed()
+	case TickerTypeRSI, TickerTypePulse:
+		return UseConfig().CanDoRSI()
+	case TickerTypeETF:
+		return UseConfig().CanDoETF()
+	case TickerTypeDominance:
+		return UseConfig().CanDoDominance()
+	case TickerTypePortfolio:
+		return UsePanelMaps().HasHoldings()
+	case TickerTypeFiatRate:
+		return NeedsFiatRate()
+	}
+	if IsCustomTickerType(tickerType) {
+		return GetCustomTicker(tickerType) != nil
+	}
+	return false
+}
+
+// The fiat rate is only worth fetching while a currency ticker is visible in a non USD fiat
+func NeedsFiatRate() bool {
+	if UseConfig().GetDisplayFiat().IsUSD() || !UseConfig().IsValid() {
+		return false
+	}
+
+	tickersMu.RLock()
+	defer tickersMu.RUnlock()
+
+	for _, ticker := range tickersStorage {
+		switch ticker.Format {
+		case TickerFormatCurrency, TickerFormatShortCurrency, TickerFormatShortCurrencyWithSign:
+			if !ticker.Hidden && IsTickerAvailable(ticker.Type) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func IsTickerHidden(tickerType string) bool {
+	tickersMu.RLock()
+	defer tickersMu.RUnlock()
+
+	if ticker := tickersStorage.get(tickerType); ticker != nil {
+		return ticker.Hidden
+	}
+	return false
+}
+
+func CanShowTicker(tickerType string) bool {
+	return IsTickerAvailable(tickerType) && !IsTickerHidden(tickerType)
+}
+
+func NewTickerFromSettings(tickerType string) *tickerDataType {
+	tickersMu.RLock()
+	ticker := tickersStorage.get(tickerType)
+	if ticker == nil {
+		ticker = defaultTickers().get(tickerType)
+	}
+	tickersMu.RUnlock()
+
+	tdt := NewTickerData()
+	if ticker != nil {
+		tdt.SetTitle(JC.Translate(ticker.Title))
+		tdt.SetType(ticker.Type)
+		tdt.SetFormat(ticker.Format)
+	}
+	return tdt
+}
+
+// Value the color rules of a ticker are evaluated against, some tickers are colored by their change instead of their value
+func GetTickerColorValue(tdt TickerData) (float64, bool) {
+	raw := tdt.Get()
+	key := tdt.GetType()
+
+	switch tdt.GetType() {
+	case TickerTypeAltcoinIndex, TickerTypeFearGreed:
+		key = JC.STRING_EMPTY
+	case TickerTypeMarketCap:
+		key = TickerTypeMarketCap24hChange
+	case TickerTypeCMC100:
+		key = TickerTypeCMC10024hChange
+	case TickerTypePortfolio:
+		key = TickerTypePortfolioPnL
+	}
+
+	if key != JC.STRING_EMPTY {
+		if UseTickerCache() == nil {
+			return 0, false
+		}
+		raw = UseTickerCache().Get(key)
+	}
+
+	val, err := strconv.ParseFloat(strings.TrimSuffix(raw, JC.STRING_PERCENTAGE), 64)
+	if err != nil {
+		// These tickers always took their lowest band color for values they could not read
+		switch tdt.GetType() {
+		case TickerTypeAltcoinIndex, TickerTypeFearGreed, TickerTypeCMC100, TickerTypeRSI:
+			return 0, true
+		}
+
+		return 0, false
+	}
+
+	return val, true
+}
+
+func GetTickerColor(tdt TickerData) (fyne.ThemeColorName, bool) {
+	val, ok := GetTickerColorValue(tdt)
+	if !ok {
+		return JC.STRING_EMPTY, false
+	}
+
+	tickersMu.RLock()
+	ticker := tickersStorage.get(tdt.GetType())
+	if ticker == nil {
+		ticker = defaultTickers().get(tdt.GetType())
+	}
+	tickersMu.RUnlock()
+
+	if ticker == nil {
+		return JC.STRING_EMPTY, false
+	}
+
+	return ticker.Colors.Evaluate(val)
+}
+
+func GetTickerSettings() tickersType {
+	tickersMu.RLock()
+	defer tickersMu.RUnlock()
+
+	settings := make(tickersType, len(tickersStorage))
+	copy(settings, tickersStorage)
+	return settings
+}
+
+func SetTickerHidden(tickerType string, hidden bool) bool {
+	tickersMu.Lock()
+	defer tickersMu.Unlock()
+
+	ticker := tickersStorage.get(tickerType)
+	if ticker == nil || ticker.Hidden == hidden {
+		return false
+	}
+
+	ticker.Hidden = hidden
+	return true
+}
+
+// Visible tickers take the given order, hidden ones keep their slot relative to them
+func (t tickersType) reorder(order []string) tickersType {
+	shown := map[string]bool{}
+	for _, tt := range order {
+		if t.get(tt) != nil {
+			shown[tt] = true
+		}
+	}
+
+	sorted := make(tickersType, 0, len(t))
+	next := 0
+	for _, ticker := range t {
+		if !shown[ticker.Type] {
+			sorted = append(sorted, ticker)
+			continue
+		}
+
+		for next < len(order) && !shown[order[next]] {
+			next++
+		}
+		if next < len(order) {
+			sorted = append(sorted, *t.get(order[next]))
+			next++
+		}
+	}
+
+	return sorted
+}
+
+func SaveTickers() bool {
+	order := []string{}
+	for _, tdt := range UseTickerMaps().GetData() {
+		order = append(order, tdt.GetType())
+	}
+
+	tickersMu.Lock()
+	defer tickersMu.Unlock()
+
+	if tickersStorage == nil {
+		tickersStorage = defaultTickers()
+	}
+
+	tickersStorage = tickersStorage.reorder(order)
+
+	return tickersStorage.save()
+}
+
+// Whether the ticker maps no longer match the configured visibility and availability
+func IsTickerMapsOutdated() bool {
+	current := map[string]bool{}
+	for _, tdt := range UseTickerMaps().GetData() {
+		current[tdt.GetType()] = true
+	}
+
+	for _, ticker := range GetTickerSettings() {
+		if current[ticker.Type] != (!ticker.Hidden && IsTickerAvailable(ticker.Type)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TickersInit() {
+	UseTickerMaps().Init()
+
+	tickersMu.Lock()
+	tickers := tickersType{}
+	exists, _ := JC.FileExists(JC.BuildPathRelatedToUserDirectory([]string{"tickers.json"}))
+	if exists {
+		tickers.load()
+	} else {
+		tickers = defaultTickers()
+	}
+	tickersStorage = tickers
+	tickersMu.Unlock()
+
+	for _, ticker := range tickers {
+		if ticker.Hidden || !IsTickerAvailable(ticker.Type) {
+			continue
+		}
+
+		UseTickerMaps().Add(NewTickerFromSettings(ticker.Type))
+	}
+}
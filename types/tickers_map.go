@@ -53,6 +53,23 @@ func (pc *tickersMapType) Update(uuid string) bool {
 	return false
 }
 
+func (pc *tickersMapType) RemoveByType(tickerType string) bool {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	nd := make([]TickerData, 0, len(pc.data))
+	for _, tdt := range pc.data {
+		if !tdt.IsType(tickerType) {
+			nd = append(nd, tdt)
+		}
+	}
+
+	removed := len(nd) != len(pc.data)
+	pc.data = nd
+
+	return removed
+}
+
 func (pc *tickersMapType) GetData() []TickerData {
 	pc.mu.RLock()
 	defer pc.mu.RUnlock()
@@ -92,6 +109,12 @@ func (pc *tickersMapType) IsEmpty() bool {
 	return len(pc.data) == 0
 }
 
+func (pc *tickersMapType) TotalData() int {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+	return len(pc.data)
+}
+
 func (pc *tickersMapType) Reset() {
 	pc.mu.Lock()
 	defer pc.mu.Unlock()
@@ -114,6 +137,19 @@ func (pc *tickersMapType) ChangeStatus(newStatus int, shouldChange func(pdt Tick
 	}
 }
 
+// Tickers without data yet turn into errors when a fetch failed, a skipped fetch leaves them as they are
+func (pc *tickersMapType) ApplyFetchStatus(status int) bool {
+	switch status {
+	case JC.STATUS_NETWORK_ERROR, JC.STATUS_CONFIG_ERROR:
+		pc.ChangeStatus(JC.STATE_ERROR, func(pdt TickerData) bool {
+			return !pdt.HasData() || pdt.IsStatus(JC.STATE_LOADING)
+		})
+		return true
+	}
+
+	return false
+}
+
 func (pc *tickersMapType) Hydrate(data []TickerData) {
 	for _, tkd := range data {
 		ex := pc.GetDataByType(tkd.GetType())
@@ -130,51 +166,15 @@ func (pc *tickersMapType) Hydrate(data []TickerData) {
 					tkt.SetStatus(tkd.GetStatus())
 				}
 			}
-		} else {
-			switch tkd.GetType() {
-			case TickerTypeMarketCap:
-				if UseConfig().CanDoMarketCap() {
-					pc.mu.Lock()
-					pc.data = append(pc.data, tkd)
-					pc.mu.Unlock()
-				}
-			case TickerTypeCMC100:
-				if UseConfig().CanDoCMC100() {
-					pc.mu.Lock()
-					pc.data = append(pc.data, tkd)
-					pc.mu.Unlock()
-				}
-			case TickerTypeAltcoinIndex:
-				if UseConfig().CanDoAltSeason() {
-					pc.mu.Lock()
-					pc.data = append(pc.data, tkd)
-					pc.mu.Unlock()
-				}
-			case TickerTypeFearGreed:
-				if UseConfig().CanDoFearGreed() {
-					pc.mu.Lock()
-					pc.data = append(pc.data, tkd)
-					pc.mu.Unlock()
-				}
-			case TickerTypeRSI, TickerTypePulse:
-				if UseConfig().CanDoRSI() {
-					pc.mu.Lock()
-					pc.data = append(pc.data, tkd)
-					pc.mu.Unlock()
-				}
-			case TickerTypeETF:
-				if UseConfig().CanDoETF() {
-					pc.mu.Lock()
-					pc.data = append(pc.data, tkd)
-					pc.mu.Unlock()
-				}
-			case TickerTypeDominance:
-				if UseConfig().CanDoDominance() {
-					pc.mu.Lock()
-					pc.data = append(pc.data, tkd)
-					pc.mu.Unlock()
-				}
+		} else if CanShowTicker(tkd.GetType()) {
+			if ticker := NewTickerFromSettings(tkd.GetType()); ticker != nil {
+				tkd.SetTitle(ticker.GetTitle())
+				tkd.SetFormat(ticker.GetFormat())
 			}
+
+			pc.mu.Lock()
+			pc.data = append(pc.data, tkd)
+			pc.mu.Unlock()
 		}
 	}
 }
@@ -193,72 +193,8 @@ func (pc *tickersMapType) Serialize() []tickerDataCache {
 	return out
 }
 
-func TickersInit() {
-	UseTickerMaps().Init()
-
-	if UseConfig().CanDoMarketCap() {
-		tdt := NewTickerData()
-		tdt.SetTitle("Market Cap")
-		tdt.SetType(TickerTypeMarketCap)
-		tdt.SetFormat(TickerFormatShortCurrency)
-		UseTickerMaps().Add(tdt)
-	}
-
-	if UseConfig().CanDoRSI() {
-		tdt := NewTickerData()
-		tdt.SetTitle("Market Bias")
-		tdt.SetType(TickerTypePulse)
-		tdt.SetFormat(TickerFormatPulse)
-		UseTickerMaps().Add(tdt)
-	}
-
-	if UseConfig().CanDoCMC100() {
-		tdt := NewTickerData()
-		tdt.SetTitle("CMC100")
-		tdt.SetType(TickerTypeCMC100)
-		tdt.SetFormat(TickerFormatCurrency)
-		UseTickerMaps().Add(tdt)
-	}
-
-	if UseConfig().CanDoAltSeason() {
-		tdt := NewTickerData()
-		tdt.SetTitle("Altcoin Index")
-		tdt.SetType(TickerTypeAltcoinIndex)
-		tdt.SetFormat(TickerFormatPercentage)
-		UseTickerMaps().Add(tdt)
-	}
-
-	if UseConfig().CanDoFearGreed() {
-		tdt := NewTickerData()
-		tdt.SetTitle("Fear & Greed")
-		tdt.SetType(TickerTypeFearGreed)
-		tdt.SetFormat(TickerFormatPercentage)
-		UseTickerMaps().Add(tdt)
-	}
-
-	if UseConfig().CanDoRSI() {
-		tdt := NewTickerData()
-		tdt.SetTitle("Crypto RSI")
-		tdt.SetType(TickerTypeRSI)
-		tdt.SetFormat(TickerFormatNumber)
-		UseTickerMaps().Add(tdt)
-	}
-
-	if UseConfig().CanDoETF() {
-		tdt := NewTickerData()
-		tdt.SetTitle("ETF Flow")
-		tdt.SetType(TickerTypeETF)
-		tdt.SetFormat(TickerFormatShortCurrencyWithSign)
-		UseTickerMaps().Add(tdt)
-	}
-
-	if UseConfig().CanDoDominance() {
-		tdt := NewTickerData()
-		tdt.SetTitle("Dominance")
-		tdt.SetType(TickerTypeDominance)
-		tdt.SetFormat(TickerFormatShortPercentage)
-		UseTickerMaps().Add(tdt)
-	}
+func NewPortfolioTicker() *tickerDataType {
+	return NewTickerFromSettings(TickerTypePortfolio)
 }
 
 func UseTickerMaps() *tickersMapType {
@@ -0,0 +1,289 @@
+package types
+
+import (
+	"log"
+	"os"
+	"testing"
+)
+
+type tickersNullWriter struct{}
+
+func (tickersNullWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func tickersTurnOffLogs() {
+	log.SetOutput(tickersNullWriter{})
+}
+
+func tickersTurnOnLogs() {
+	log.SetOutput(os.Stdout)
+}
+
+func TestTickersParseJSON(t *testing.T) {
+	tickersTurnOffLogs()
+	defer tickersTurnOnLogs()
+
+	data := []byte(`[
+		{"type": "rsi", "title": "RSI", "format": "nodecimal"},
+		{"type": "market_cap", "hidden": true},
+		{"type": "unknown", "title": "Nope"},
+		{"type": "rsi", "title": "Duplicated"},
+		{"type": "etf", "format": "bogus"}
+	]`)
+
+	var tickers tickersType
+	if err := tickers.parseJSON(data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(tickers) != len(defaultTickers()) {
+		t.Fatalf("Expected %d tickers, got %d", len(defaultTickers()), len(tickers))
+	}
+
+	if tickers[0].Type != TickerTypeRSI || tickers[0].Title != "RSI" || tickers[0].Format != TickerFormatNodecimal {
+		t.Errorf("Unexpected first ticker: %+v", tickers[0])
+	}
+
+	if tickers[1].Type != TickerTypeMarketCap || !tickers[1].Hidden || tickers[1].Title != "Market Cap" {
+		t.Errorf("Expected hidden market cap with default title, got %+v", tickers[1])
+	}
+
+	if tickers[2].Type != TickerTypeETF || tickers[2].Format != TickerFormatShortCurrencyWithSign {
+		t.Errorf("Expected etf with default format, got %+v", tickers[2])
+	}
+
+	if tickers[3].Type != TickerTypePulse {
+		t.Errorf("Expected missing defaults appended in default order, got %+v", tickers[3])
+	}
+
+	if len(tickers[0].Colors) != len(defaultTickers().get(TickerTypeRSI).Colors) {
+		t.Errorf("Expected default rsi colors, got %+v", tickers[0].Colors)
+	}
+
+	if err := tickers.parseJSON([]byte(`{"type": "rsi"}`)); err == nil {
+		t.Error("Expected error for non array json")
+	}
+}
+
+func TestTickersReorder(t *testing.T) {
+	tickers := tickersType{
+		{Type: TickerTypeMarketCap},
+		{Type: TickerTypePulse, Hidden: true},
+		{Type: TickerTypeCMC100},
+		{Type: TickerTypeRSI},
+	}
+
+	sorted := tickers.reorder([]string{TickerTypeRSI, TickerTypeMarketCap, "unknown", TickerTypeCMC100})
+
+	expected := []string{TickerTypeRSI, TickerTypePulse, TickerTypeMarketCap, TickerTypeCMC100}
+	for i, tt := range expected {
+		if sorted[i].Type != tt {
+			t.Fatalf("Expected %s at %d, got %s", tt, i, sorted[i].Type)
+		}
+	}
+
+	if !sorted[1].Hidden {
+		t.Error("Expected hidden flag to be kept")
+	}
+}
+
+func TestTickersVisibility(t *testing.T) {
+	tickersMu.Lock()
+	old := tickersStorage
+	tickersStorage = defaultTickers()
+	tickersMu.Unlock()
+
+	defer func() {
+		tickersMu.Lock()
+		tickersStorage = old
+		tickersMu.Unlock()
+	}()
+
+	if IsTickerHidden(TickerTypeETF) {
+		t.Fatal("Expected etf to be visible by default")
+	}
+
+	if !SetTickerHidden(TickerTypeETF, true) {
+		t.Fatal("Expected visibility change")
+	}
+
+	if SetTickerHidden(TickerTypeETF, true) {
+		t.Error("Expected no change when already hidden")
+	}
+
+	if SetTickerHidden("unknown", true) {
+		t.Error("Expected unknown ticker to be ignored")
+	}
+
+	if !IsTickerHidden(TickerTypeETF) {
+		t.Error("Expected etf to be hidden")
+	}
+
+	settings := GetTickerSettings()
+	settings[0].Title = "Changed"
+	if GetTickerSettings()[0].Title == "Changed" {
+		t.Error("Expected settings to be a copy")
+	}
+
+	tdt := NewTickerFromSettings(TickerTypeDominance)
+	if tdt.GetTitle() != "Dominance" || tdt.GetFormat() != TickerFormatShortPercentage {
+		t.Errorf("Unexpected ticker from settings: %s %s", tdt.GetTitle(), tdt.GetFormat())
+	}
+}
+
+func TestTickersParseJSONColors(t *testing.T) {
+	tickersTurnOffLogs()
+	defer tickersTurnOnLogs()
+
+	data := []byte(`[
+		{"type": "feargreed", "colors": [
+			{"min": 60, "color": "blue"},
+			{"min": 40, "color": "nope"},
+			{"color": "tickerBG"}
+		]},
+		{"type": "dominance", "colors": []},
+		{"type": "rsi", "colors": {"min": 1}}
+	]`)
+
+	var tickers tickersType
+	if err := tickers.parseJSON(data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(tickers.get(TickerTypeFearGreed).Colors) != 2 {
+		t.Errorf("Expected invalid rule to be dropped, got %+v", tickers.get(TickerTypeFearGreed).Colors)
+	}
+
+	if tickers.get(TickerTypeDominance).Colors == nil || len(tickers.get(TickerTypeDominance).Colors) != 0 {
+		t.Error("Expected empty colors to disable coloring")
+	}
+
+	if len(tickers.get(TickerTypeRSI).Colors) != len(defaultTickers().get(TickerTypeRSI).Colors) {
+		t.Error("Expected malformed colors to fall back to the defaults")
+	}
+}
+
+func TestTickersColor(t *testing.T) {
+	tickersMu.Lock()
+	old := tickersStorage
+	tickersStorage = defaultTickers()
+	tickersMu.Unlock()
+
+	defer func() {
+		tickersMu.Lock()
+		tickersStorage = old
+		tickersMu.Unlock()
+	}()
+
+	tests := []struct {
+		tickerType string
+		value      string
+		expected   string
+	}{
+		{TickerTypeFearGreed, "80", "green"},
+		{TickerTypeFearGreed, "75", "green"},
+		{TickerTypeFearGreed, "60", "teal"},
+		{TickerTypeFearGreed, "50", "yellow"},
+		{TickerTypeFearGreed, "30", "orange"},
+		{TickerTypeFearGreed, "10", "red"},
+		{TickerTypeAltcoinIndex, "90", "blue"},
+		{TickerTypeAltcoinIndex, "60", "lightPurple"},
+		{TickerTypeAltcoinIndex, "30", "lightOrange"},
+		{TickerTypeAltcoinIndex, "5", "orange"},
+	}
+
+	for _, tt := range tests {
+		tdt := NewTickerFromSettings(tt.tickerType)
+		tdt.Init()
+		tdt.Set(tt.value)
+
+		color, ok := GetTickerColor(tdt)
+		if !ok || string(color) != tt.expected {
+			t.Errorf("%s %s: expected %s, got %q", tt.tickerType, tt.value, tt.expected, color)
+		}
+	}
+
+	tdt := NewTickerFromSettings(TickerTypeFearGreed)
+	tdt.Init()
+	tdt.Set("n/a")
+	if color, ok := GetTickerColor(tdt); !ok || color != "red" {
+		t.Errorf("Expected the lowest band for unparsable value, got %q", color)
+	}
+
+	dominance := NewTickerFromSettings(TickerTypeDominance)
+	dominance.Init()
+	dominance.Set("n/a")
+	if _, ok := GetTickerColor(dominance); ok {
+		t.Error("Expected no color for unparsable dominance")
+	}
+
+	tickersMu.Lock()
+	tickersStorage.get(TickerTypeFearGreed).Colors = colorRulesType{}
+	tickersMu.Unlock()
+
+	tdt.Set("80")
+	if _, ok := GetTickerColor(tdt); ok {
+		t.Error("Expected no color without rules")
+	}
+}
+
+// Pins the bands the tickers had before they were turned into rules
+func TestTickersDefaultColorEdges(t *testing.T) {
+	tests := []struct {
+		tickerType string
+		values     map[float64]string
+	}{
+		{TickerTypeAltcoinIndex, map[float64]string{100: "blue", 75: "blue", 74: "lightPurple", 50: "lightPurple", 49: "lightOrange", 25: "lightOrange", 24: "orange", 0: "orange"}},
+		{TickerTypeFearGreed, map[float64]string{75: "green", 74: "teal", 55: "teal", 54: "yellow", 45: "yellow", 44: "orange", 25: "orange", 24: "red", 0: "red"}},
+		{TickerTypeRSI, map[float64]string{70: "green", 69.99: "darkGreen", 55: "darkGreen", 54.99: "darkGrey", 45: "darkGrey", 44.99: "darkRed", 30: "darkRed", 29.99: "red", 0: "red"}},
+		{TickerTypeCMC100, map[float64]string{0.01: "green", 0: "green", -0.01: "red"}},
+		{TickerTypeDominance, map[float64]string{50: "green", 49.99: "red"}},
+		{TickerTypePulse, map[float64]string{0.01: "green", 0: "darkGrey", -0.01: "red"}},
+		{TickerTypeETF, map[float64]string{1: "green", 0: "darkGrey", -1: "red"}},
+		{TickerTypePortfolio, map[float64]string{1: "green", 0: "darkGrey", -1: "red"}},
+		{TickerTypeMarketCap, map[float64]string{0.01: "green", -0.01: "red"}},
+	}
+
+	for _, tt := range tests {
+		rules := defaultTickers().get(tt.tickerType).Colors
+		for val, expected := range tt.values {
+			if color, _ := rules.Evaluate(val); string(color) != expected {
+				t.Errorf("%s %v: expected %s, got %q", tt.tickerType, val, expected, color)
+			}
+		}
+	}
+}
+
+func TestTickersDefaultColors(t *testing.T) {
+	for _, ticker := range defaultTickers() {
+		if len(ticker.Colors) == 0 {
+			t.Errorf("Expected default colors for %s", ticker.Type)
+		}
+		for _, rule := range ticker.Colors {
+			if !rule.IsValid() {
+				t.Errorf("Invalid default rule for %s: %+v", ticker.Type, rule)
+			}
+		}
+	}
+
+	rsi := defaultTickers().get(TickerTypeRSI).Colors
+	for val, expected := range map[float64]string{70: "green", 60: "darkGreen", 50: "darkGrey", 35: "darkRed", 20: "red"} {
+		if color, _ := rsi.Evaluate(val); string(color) != expected {
+			t.Errorf("RSI %v: expected %s, got %s", val, expected, color)
+		}
+	}
+
+	dominance := defaultTickers().get(TickerTypeDominance).Colors
+	if color, _ := dominance.Evaluate(50); color != "green" {
+		t.Errorf("Expected dominance 50 to be green, got %s", color)
+	}
+	if color, _ := dominance.Evaluate(49.9); color != "red" {
+		t.Errorf("Expected dominance 49.9 to be red, got %s", color)
+	}
+
+	market := defaultTickers().get(TickerTypeMarketCap).Colors
+	if _, ok := market.Evaluate(0); ok {
+		t.Error("Expected flat market cap to keep the ticker background")
+	}
+}
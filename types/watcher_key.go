@@ -9,7 +9,7 @@ import (
 )
 
 type watcherKeyType struct {
-	value string // Format: "sent|comparator|rate|limit|duration|timestamp"
+	value string // Format: "sent|comparator|rate|limit|duration|timestamp[|logic|rules[|alert_rate]]"
 }
 
 func (p *watcherKeyType) Set(value string) {
@@ -37,8 +37,27 @@ func (p *watcherKeyType) UpdateTimestamp(ts int64) string {
 	return p.value
 }
 
+// The alert rate only has a place in keys with rules
+func (p *watcherKeyType) UpdateAlertRate(rate float64) string {
+
+	parts := strings.Split(p.value, JC.STRING_PIPE)
+	if len(parts) >= 8 {
+		parts = append(parts[:8], strconv.FormatFloat(rate, 'g', -1, 64))
+		p.value = strings.Join(parts, JC.STRING_PIPE)
+	}
+	return p.value
+}
+
 func (p *watcherKeyType) GenerateKeyFromPanel(panel panelType) string {
 
+	if panel.Conditions != nil && len(panel.Conditions.Rules) != 0 {
+		p.GenerateKeyFromRules(panel.Sent, panel.Conditions.Logic, panel.Conditions.Rules, panel.Limit, panel.Duration, panel.Timestamp)
+		if panel.AlertRate > 0 {
+			p.UpdateAlertRate(panel.AlertRate)
+		}
+		return p.value
+	}
+
 	var b strings.Builder
 
 	b.WriteString(strconv.Itoa(panel.Sent))
@@ -76,6 +95,28 @@ func (p *watcherKeyType) GenerateKeyFromArgs(sent int, operator int, rate float6
 	return p.value
 }
 
+func (p *watcherKeyType) GenerateKeyFromRules(sent int, logic int, rules []watcherRuleType, limit int, duration int, timestamp int) string {
+	if len(rules) == 0 {
+		return p.GenerateKeyFromArgs(sent, JC.WATCHER_OPERATOR_EQUAL, 1.0, limit, duration, timestamp)
+	}
+
+	// Single rate rule is kept in the legacy format
+	if len(rules) == 1 && rules[0].Metric == JC.WATCHER_METRIC_RATE && !rules[0].IsCrossing() {
+		return p.GenerateKeyFromArgs(sent, rules[0].Operator, rules[0].Value, limit, duration, timestamp)
+	}
+
+	var b strings.Builder
+
+	b.WriteString(p.GenerateKeyFromArgs(sent, rules[0].Operator, rules[0].Value, limit, duration, timestamp))
+	b.WriteString(JC.STRING_PIPE)
+	b.WriteString(strconv.Itoa(logic))
+	b.WriteString(JC.STRING_PIPE)
+	b.WriteString(EncodeWatcherRules(rules))
+
+	p.value = b.String()
+	return p.value
+}
+
 func (p *watcherKeyType) GetRawValue() string {
 	return p.value
 }
@@ -105,6 +146,9 @@ func (p *watcherKeyType) ToPanel(base panelType) panelType {
 		base.Timestamp = v
 	}
 
+	base.Conditions = p.GetConditions()
+	base.AlertRate = p.GetAlertRate()
+
 	return base
 }
 
@@ -168,6 +212,56 @@ func (p *watcherKeyType) GetTimestamp() int {
 	return 0
 }
 
+func (p *watcherKeyType) GetLogic() int {
+	parts := strings.Split(p.value, JC.STRING_PIPE)
+	if len(parts) >= 7 {
+		if v, err := strconv.Atoi(parts[6]); err == nil {
+			return v
+		}
+	}
+	return JC.WATCHER_LOGIC_AND
+}
+
+func (p *watcherKeyType) GetRules() []watcherRuleType {
+	parts := strings.Split(p.value, JC.STRING_PIPE)
+	if len(parts) >= 8 {
+		if rules := DecodeWatcherRules(parts[7]); len(rules) != 0 {
+			return rules
+		}
+	}
+
+	return []watcherRuleType{
+		NewWatcherRule(JC.WATCHER_METRIC_RATE, p.GetOperator(), p.GetRate()),
+	}
+}
+
+// Rate at the time the last alert fired, 0 when none was stored
+func (p *watcherKeyType) GetAlertRate() float64 {
+	parts := strings.Split(p.value, JC.STRING_PIPE)
+	if len(parts) >= 9 {
+		if v, err := strconv.ParseFloat(parts[8], 64); err == nil && v > 0 {
+			return v
+		}
+	}
+	return 0
+}
+
+func (p *watcherKeyType) GetConditions() *watcherConditionsType {
+	if !p.HasConditions() {
+		return nil
+	}
+
+	return &watcherConditionsType{
+		Logic: p.GetLogic(),
+		Rules: p.GetRules(),
+	}
+}
+
+func (p *watcherKeyType) HasConditions() bool {
+	parts := strings.Split(p.value, JC.STRING_PIPE)
+	return len(parts) >= 8 && len(DecodeWatcherRules(parts[7])) != 0
+}
+
 func (p *watcherKeyType) GetFormattedRateString() string {
 	rate := p.GetRate()
 	frac := JC.NumDecPlaces(rate)
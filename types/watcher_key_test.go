@@ -1,7 +1,10 @@
 package types
 
 import (
+	"strings"
 	"testing"
+
+	JC "jxwatcher/core"
 )
 
 func TestWatcherKeyType_Basic(t *testing.T) {
@@ -122,3 +125,82 @@ func TestWatcherKeyType_FormattedRate(t *testing.T) {
 	formatted2 := w.GetFormattedRateString()
 	t.Logf("Formatted rate: %s", formatted2)
 }
+
+func TestWatcherKeyType_Rules(t *testing.T) {
+	w := NewWatcherKey()
+	w.GenerateKeyFromArgs(0, 2, 100, 3, 30, 0)
+
+	rules := w.GetRules()
+	if len(rules) != 1 || rules[0] != NewWatcherRule(JC.WATCHER_METRIC_RATE, 2, 100) {
+		t.Errorf("expected legacy key to migrate into a single rate rule, got %+v", rules)
+	}
+	if w.GetLogic() != JC.WATCHER_LOGIC_AND {
+		t.Errorf("expected legacy key to default to AND logic, got %d", w.GetLogic())
+	}
+	if w.HasConditions() || w.GetConditions() != nil {
+		t.Error("expected legacy key to have no conditions")
+	}
+
+	single := w.GenerateKeyFromRules(0, JC.WATCHER_LOGIC_OR, rules, 3, 30, 0)
+	if single != "0|2|100|3|30|0" {
+		t.Errorf("expected single rate rule to keep legacy format, got %s", single)
+	}
+
+	rules = []watcherRuleType{
+		NewWatcherRule(JC.WATCHER_METRIC_RATE, JC.WATCHER_OPERATOR_LESS, 90),
+		NewWatcherRule(JC.WATCHER_METRIC_RATE, JC.WATCHER_OPERATOR_GREATER, 110),
+	}
+
+	key := w.GenerateKeyFromRules(1, JC.WATCHER_LOGIC_OR, rules, 5, 10, 0)
+	if key != "1|1|90|5|10|0|1|0:1:90,0:2:110" {
+		t.Errorf("unexpected compound key %s", key)
+	}
+
+	if w.GetLogic() != JC.WATCHER_LOGIC_OR || len(w.GetRules()) != 2 || !w.HasConditions() {
+		t.Error("expected compound key to expose logic and rules")
+	}
+
+	w.UpdateSent(2)
+	w.UpdateTimestamp(77)
+	if w.GetRawValue() != "2|1|90|5|10|77|1|0:1:90,0:2:110" {
+		t.Errorf("expected updates to keep rules, got %s", w.GetRawValue())
+	}
+
+	npt := w.ToPanel(panelType{})
+	if npt.Conditions == nil || npt.Conditions.Logic != JC.WATCHER_LOGIC_OR || len(npt.Conditions.Rules) != 2 {
+		t.Fatalf("expected ToPanel to carry conditions, got %+v", npt.Conditions)
+	}
+
+	nw := NewWatcherKey()
+	if nw.GenerateKeyFromPanel(npt) != w.GetRawValue() {
+		t.Errorf("expected panel round trip, got %s", nw.GetRawValue())
+	}
+}
+
+func TestWatcherKeyAlertRate(t *testing.T) {
+	w := NewWatcherKey()
+	w.GenerateKeyFromArgs(0, JC.WATCHER_OPERATOR_GREATER, 100, 3, 30, 0)
+
+	if w.UpdateAlertRate(95) != "0|2|100|3|30|0" || w.GetAlertRate() != 0 {
+		t.Errorf("expected legacy key without an alert rate, got %s", w.GetRawValue())
+	}
+
+	rules := []watcherRuleType{NewWatcherRule(JC.WATCHER_METRIC_CHANGE_SINCE_ALERT, JC.WATCHER_OPERATOR_MOVES_BY, 5)}
+	w.GenerateKeyFromRules(1, JC.WATCHER_LOGIC_AND, rules, 3, 30, 77)
+
+	w.UpdateAlertRate(95.5)
+	w.UpdateAlertRate(97.25)
+	if w.GetAlertRate() != 97.25 || !strings.HasSuffix(w.GetRawValue(), "|97.25") || len(w.GetRules()) != 1 {
+		t.Errorf("expected alert rate stored after the rules, got %s", w.GetRawValue())
+	}
+
+	npt := w.ToPanel(panelType{})
+	if npt.AlertRate != 97.25 {
+		t.Errorf("expected ToPanel to carry the alert rate, got %v", npt.AlertRate)
+	}
+
+	nw := NewWatcherKey()
+	if nw.GenerateKeyFromPanel(npt) != w.GetRawValue() {
+		t.Errorf("expected panel round trip, got %s", nw.GetRawValue())
+	}
+}
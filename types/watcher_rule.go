@@ -0,0 +1,268 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	JC "jxwatcher/core"
+)
+
+const watcherRuleSeparator = ","
+const watcherRuleFieldSeparator = ":"
+
+type watcherRuleType struct {
+	Metric   int     `json:"metric"`
+	Operator int     `json:"operator"`
+	Value    float64 `json:"value"`
+	Window   int     `json:"window,omitempty"`
+}
+
+type WatcherRule = watcherRuleType
+
+type watcherConditionsType struct {
+	Logic int               `json:"logic"`
+	Rules []watcherRuleType `json:"rules"`
+}
+
+func (r *watcherRuleType) IsValid() bool {
+	switch r.Metric {
+	case JC.WATCHER_METRIC_RATE:
+		if r.Value <= 0 {
+			return false
+		}
+
+		switch r.Operator {
+		case JC.WATCHER_OPERATOR_EQUAL, JC.WATCHER_OPERATOR_LESS, JC.WATCHER_OPERATOR_GREATER,
+			JC.WATCHER_OPERATOR_CROSS_ABOVE, JC.WATCHER_OPERATOR_CROSS_BELOW:
+			return true
+		}
+
+		return false
+
+	case JC.WATCHER_METRIC_CHANGE_WINDOW:
+		if r.Window <= 0 || time.Duration(r.Window)*time.Minute > ExchangeHistoryWindow {
+			return false
+		}
+	case JC.WATCHER_METRIC_CHANGE_24H, JC.WATCHER_METRIC_CHANGE_SINCE_ALERT:
+	default:
+		return false
+	}
+
+	switch r.Operator {
+	case JC.WATCHER_OPERATOR_EQUAL, JC.WATCHER_OPERATOR_LESS, JC.WATCHER_OPERATOR_GREATER:
+		return true
+	case JC.WATCHER_OPERATOR_MOVES_BY:
+		return r.Value > 0
+	}
+
+	return false
+}
+
+func (r *watcherRuleType) IsCrossing() bool {
+	return r.Operator == JC.WATCHER_OPERATOR_CROSS_ABOVE || r.Operator == JC.WATCHER_OPERATOR_CROSS_BELOW
+}
+
+func (r *watcherRuleType) Match(value float64) bool {
+	switch r.Operator {
+	case JC.WATCHER_OPERATOR_EQUAL:
+		return JC.ToBigFloat(value).Cmp(JC.ToBigFloat(r.Value)) == 0
+	case JC.WATCHER_OPERATOR_LESS:
+		return value < r.Value
+	case JC.WATCHER_OPERATOR_GREATER:
+		return value > r.Value
+	case JC.WATCHER_OPERATOR_MOVES_BY:
+		return math.Abs(value) >= r.Value
+	}
+
+	return false
+}
+
+func (r *watcherRuleType) MatchCrossing(previous float64, current float64) bool {
+	switch r.Operator {
+	case JC.WATCHER_OPERATOR_CROSS_ABOVE:
+		return previous <= r.Value && current > r.Value
+	case JC.WATCHER_OPERATOR_CROSS_BELOW:
+		return previous >= r.Value && current < r.Value
+	}
+
+	return false
+}
+
+func (r *watcherRuleType) GetOperatorSymbol() string {
+	switch r.Operator {
+	case JC.WATCHER_OPERATOR_EQUAL:
+		return JC.STRING_EQUAL
+	case JC.WATCHER_OPERATOR_LESS, JC.WATCHER_OPERATOR_CROSS_BELOW:
+		return JC.STRING_LESS
+	case JC.WATCHER_OPERATOR_GREATER, JC.WATCHER_OPERATOR_CROSS_ABOVE:
+		return JC.STRING_GREATER
+	}
+
+	return JC.STRING_EMPTY
+}
+
+func (r *watcherRuleType) GetOperatorText() string {
+	switch r.Operator {
+	case JC.WATCHER_OPERATOR_EQUAL:
+		return "equal"
+	case JC.WATCHER_OPERATOR_LESS:
+		return "less than"
+	case JC.WATCHER_OPERATOR_GREATER:
+		return "greater than"
+	case JC.WATCHER_OPERATOR_CROSS_ABOVE:
+		return "crossed above"
+	case JC.WATCHER_OPERATOR_CROSS_BELOW:
+		return "crossed below"
+	case JC.WATCHER_OPERATOR_MOVES_BY:
+		return "moved by"
+	}
+
+	return JC.STRING_EMPTY
+}
+
+func (r *watcherRuleType) GetFormattedValueString() string {
+	if r.Metric != JC.WATCHER_METRIC_RATE {
+		return JC.FormatNumberWithCommas(r.Value, 2) + JC.STRING_PERCENTAGE
+	}
+
+	frac := JC.NumDecPlaces(r.Value)
+
+	if frac < 3 {
+		frac = 2
+	}
+
+	if r.Value < 1 {
+		frac = 4
+	}
+
+	return JC.FormatNumberWithCommas(r.Value, frac)
+}
+
+func (r *watcherRuleType) FormatDescription() string {
+	var subject string
+
+	switch r.Metric {
+	case JC.WATCHER_METRIC_CHANGE_24H:
+		subject = "24h change"
+	case JC.WATCHER_METRIC_CHANGE_SINCE_ALERT:
+		subject = "change since last alert"
+	case JC.WATCHER_METRIC_CHANGE_WINDOW:
+		subject = fmt.Sprintf("change within %d minutes", r.Window)
+	default:
+		subject = "rates"
+	}
+
+	switch r.Operator {
+	case JC.WATCHER_OPERATOR_CROSS_ABOVE, JC.WATCHER_OPERATOR_CROSS_BELOW:
+		return fmt.Sprintf("%s %s %s", subject, r.GetOperatorText(), r.GetFormattedValueString())
+	case JC.WATCHER_OPERATOR_MOVES_BY:
+		return fmt.Sprintf("%s %s ±%s", subject, r.GetOperatorText(), r.GetFormattedValueString())
+	}
+
+	return fmt.Sprintf("%s is %s %s", subject, r.GetOperatorText(), r.GetFormattedValueString())
+}
+
+func (r *watcherRuleType) Encode() string {
+	var b strings.Builder
+
+	b.WriteString(strconv.Itoa(r.Metric))
+	b.WriteString(watcherRuleFieldSeparator)
+	b.WriteString(strconv.Itoa(r.Operator))
+	b.WriteString(watcherRuleFieldSeparator)
+	b.WriteString(strconv.FormatFloat(r.Value, 'g', -1, 64))
+
+	if r.Window > 0 {
+		b.WriteString(watcherRuleFieldSeparator)
+		b.WriteString(strconv.Itoa(r.Window))
+	}
+
+	return b.String()
+}
+
+func (r *watcherRuleType) Decode(value string) bool {
+	parts := strings.Split(value, watcherRuleFieldSeparator)
+	if len(parts) != 3 && len(parts) != 4 {
+		return false
+	}
+
+	metric, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+
+	operator, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+
+	val, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return false
+	}
+
+	window := 0
+	if len(parts) == 4 {
+		window, err = strconv.Atoi(parts[3])
+		if err != nil {
+			return false
+		}
+	}
+
+	r.Metric = metric
+	r.Operator = operator
+	r.Value = val
+	r.Window = window
+
+	return true
+}
+
+func (c *watcherConditionsType) IsValid() bool {
+	if c.Logic != JC.WATCHER_LOGIC_AND && c.Logic != JC.WATCHER_LOGIC_OR {
+		return false
+	}
+
+	if len(c.Rules) == 0 {
+		return false
+	}
+
+	for _, rule := range c.Rules {
+		if !rule.IsValid() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func EncodeWatcherRules(rules []watcherRuleType) string {
+	encoded := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		encoded = append(encoded, rule.Encode())
+	}
+
+	return strings.Join(encoded, watcherRuleSeparator)
+}
+
+func DecodeWatcherRules(value string) []watcherRuleType {
+	rules := []watcherRuleType{}
+
+	for _, raw := range strings.Split(value, watcherRuleSeparator) {
+		rule := watcherRuleType{}
+		if rule.Decode(strings.TrimSpace(raw)) {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+func NewWatcherRule(metric int, operator int, value float64) watcherRuleType {
+	return watcherRuleType{
+		Metric:   metric,
+		Operator: operator,
+		Value:    value,
+	}
+}
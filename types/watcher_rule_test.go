@@ -0,0 +1,147 @@
+package types
+
+import (
+	"testing"
+
+	JC "jxwatcher/core"
+)
+
+func TestWatcherRuleEncodeDecode(t *testing.T) {
+	rule := NewWatcherRule(JC.WATCHER_METRIC_CHANGE_24H, JC.WATCHER_OPERATOR_GREATER, -5.5)
+
+	encoded := rule.Encode()
+	if encoded != "1:2:-5.5" {
+		t.Errorf("expected encoded rule 1:2:-5.5, got %s", encoded)
+	}
+
+	decoded := watcherRuleType{}
+	if !decoded.Decode(encoded) {
+		t.Fatal("expected rule to decode")
+	}
+	if decoded != rule {
+		t.Errorf("expected %+v, got %+v", rule, decoded)
+	}
+
+	if decoded.Decode("1:2") || decoded.Decode("a:2:3") || decoded.Decode("1:2:x") {
+		t.Error("expected malformed rules to be rejected")
+	}
+}
+
+func TestWatcherRulesEncodeDecodeList(t *testing.T) {
+	rules := []watcherRuleType{
+		NewWatcherRule(JC.WATCHER_METRIC_RATE, JC.WATCHER_OPERATOR_LESS, 90),
+		NewWatcherRule(JC.WATCHER_METRIC_RATE, JC.WATCHER_OPERATOR_GREATER, 110.25),
+	}
+
+	encoded := EncodeWatcherRules(rules)
+	if encoded != "0:1:90,0:2:110.25" {
+		t.Errorf("unexpected encoded rules %s", encoded)
+	}
+
+	decoded := DecodeWatcherRules(encoded + ",broken")
+	if len(decoded) != 2 || decoded[0] != rules[0] || decoded[1] != rules[1] {
+		t.Errorf("unexpected decoded rules %+v", decoded)
+	}
+
+	if len(DecodeWatcherRules(JC.STRING_EMPTY)) != 0 {
+		t.Error("expected no rules from empty string")
+	}
+}
+
+func TestWatcherRuleValidation(t *testing.T) {
+	tests := []struct {
+		rule  watcherRuleType
+		valid bool
+	}{
+		{NewWatcherRule(JC.WATCHER_METRIC_RATE, JC.WATCHER_OPERATOR_EQUAL, 1), true},
+		{NewWatcherRule(JC.WATCHER_METRIC_RATE, JC.WATCHER_OPERATOR_LESS, 0), false},
+		{NewWatcherRule(JC.WATCHER_METRIC_CHANGE_24H, JC.WATCHER_OPERATOR_LESS, -10), true},
+		{NewWatcherRule(JC.WATCHER_METRIC_RATE, 99, 1), false},
+		{NewWatcherRule(99, JC.WATCHER_OPERATOR_LESS, 1), false},
+		{NewWatcherRule(JC.WATCHER_METRIC_RATE, JC.WATCHER_OPERATOR_CROSS_ABOVE, 1), true},
+		{NewWatcherRule(JC.WATCHER_METRIC_CHANGE_24H, JC.WATCHER_OPERATOR_CROSS_BELOW, 1), false},
+		{NewWatcherRule(JC.WATCHER_METRIC_RATE, JC.WATCHER_OPERATOR_MOVES_BY, 1), false},
+		{NewWatcherRule(JC.WATCHER_METRIC_CHANGE_SINCE_ALERT, JC.WATCHER_OPERATOR_MOVES_BY, 2), true},
+		{NewWatcherRule(JC.WATCHER_METRIC_CHANGE_SINCE_ALERT, JC.WATCHER_OPERATOR_MOVES_BY, -2), false},
+		{NewWatcherRule(JC.WATCHER_METRIC_CHANGE_WINDOW, JC.WATCHER_OPERATOR_MOVES_BY, 2), false},
+		{watcherRuleType{Metric: JC.WATCHER_METRIC_CHANGE_WINDOW, Operator: JC.WATCHER_OPERATOR_MOVES_BY, Value: 2, Window: 30}, true},
+		{watcherRuleType{Metric: JC.WATCHER_METRIC_CHANGE_WINDOW, Operator: JC.WATCHER_OPERATOR_MOVES_BY, Value: 2, Window: 1441}, false},
+	}
+
+	for _, tt := range tests {
+		if tt.rule.IsValid() != tt.valid {
+			t.Errorf("expected IsValid()=%v for %+v", tt.valid, tt.rule)
+		}
+	}
+
+	conditions := watcherConditionsType{Logic: JC.WATCHER_LOGIC_OR, Rules: []watcherRuleType{tests[0].rule}}
+	if !conditions.IsValid() {
+		t.Error("expected conditions to be valid")
+	}
+
+	conditions.Logic = 5
+	if conditions.IsValid() {
+		t.Error("expected unknown logic to be invalid")
+	}
+
+	conditions = watcherConditionsType{Logic: JC.WATCHER_LOGIC_AND}
+	if conditions.IsValid() {
+		t.Error("expected empty rules to be invalid")
+	}
+}
+
+func TestWatcherRuleMatchAndDescription(t *testing.T) {
+	rule := NewWatcherRule(JC.WATCHER_METRIC_CHANGE_24H, JC.WATCHER_OPERATOR_GREATER, 5)
+
+	if !rule.Match(5.1) || rule.Match(5) || rule.Match(-6) {
+		t.Error("unexpected greater than match result")
+	}
+
+	if desc := rule.FormatDescription(); desc != "24h change is greater than 5%" {
+		t.Errorf("unexpected description %s", desc)
+	}
+
+	rule = NewWatcherRule(JC.WATCHER_METRIC_RATE, JC.WATCHER_OPERATOR_LESS, 1234.5)
+	if desc := rule.FormatDescription(); desc != "rates is less than 1,234.5" {
+		t.Errorf("unexpected description %s", desc)
+	}
+}
+
+func TestWatcherRuleCrossingAndMoves(t *testing.T) {
+	above := NewWatcherRule(JC.WATCHER_METRIC_RATE, JC.WATCHER_OPERATOR_CROSS_ABOVE, 100)
+	below := NewWatcherRule(JC.WATCHER_METRIC_RATE, JC.WATCHER_OPERATOR_CROSS_BELOW, 100)
+
+	if !above.IsCrossing() || !below.IsCrossing() {
+		t.Error("expected crossing operators to be detected")
+	}
+	if !above.MatchCrossing(99, 101) || !above.MatchCrossing(100, 101) || above.MatchCrossing(101, 102) || above.MatchCrossing(101, 99) {
+		t.Error("unexpected crossing above result")
+	}
+	if !below.MatchCrossing(101, 99) || below.MatchCrossing(99, 98) || below.MatchCrossing(99, 101) {
+		t.Error("unexpected crossing below result")
+	}
+	if desc := above.FormatDescription(); desc != "rates crossed above 100" {
+		t.Errorf("unexpected description %s", desc)
+	}
+
+	moves := watcherRuleType{Metric: JC.WATCHER_METRIC_CHANGE_WINDOW, Operator: JC.WATCHER_OPERATOR_MOVES_BY, Value: 5, Window: 15}
+	if !moves.Match(5) || !moves.Match(-7) || moves.Match(4.9) || moves.Match(-1) {
+		t.Error("unexpected moves by result")
+	}
+	if desc := moves.FormatDescription(); desc != "change within 15 minutes moved by ±5%" {
+		t.Errorf("unexpected description %s", desc)
+	}
+
+	encoded := moves.Encode()
+	if encoded != "3:5:5:15" {
+		t.Errorf("expected encoded rule 3:5:5:15, got %s", encoded)
+	}
+
+	decoded := watcherRuleType{}
+	if !decoded.Decode(encoded) || decoded != moves {
+		t.Errorf("expected %+v, got %+v", moves, decoded)
+	}
+	if decoded.Decode("3:5:5:x") {
+		t.Error("expected malformed window to be rejected")
+	}
+}
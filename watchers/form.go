@@ -1,12 +1,11 @@
 package watchers
 
 import (
-	"fmt"
+	"errors"
 	"strconv"
 	"time"
 
 	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
@@ -17,6 +16,85 @@ import (
 	JW "jxwatcher/widgets"
 )
 
+var watcherMetricOptions = []string{
+	"Rate",
+	"24h Change %",
+	"Change Since Alert %",
+	"Change Within Minutes %",
+}
+
+var watcherOperatorOptions = []string{
+	"Equal",
+	"Less Than",
+	"Greater Than",
+	"Crosses Above",
+	"Crosses Below",
+	"Moves By ±",
+}
+
+type watcherValueEntry interface {
+	fyne.CanvasObject
+	Validate() error
+	Disable()
+	Enable()
+	GetInt() int
+	GetFloat() float64
+}
+
+type watcherRuleRow struct {
+	container *fyne.Container
+	metric    *widget.Select
+	operator  *widget.Select
+	value     watcherValueEntry
+	window    watcherValueEntry
+	remove    JW.ActionButton
+}
+
+func (r *watcherRuleRow) Disable() {
+	r.metric.Disable()
+	r.operator.Disable()
+	r.value.Disable()
+	r.window.Disable()
+	r.remove.Disable()
+}
+
+func (r *watcherRuleRow) Enable() {
+	r.metric.Enable()
+	r.operator.Enable()
+	r.value.Enable()
+	r.window.Enable()
+	r.remove.Enable()
+}
+
+func (r *watcherRuleRow) GetRule() JT.WatcherRule {
+	rule := JT.NewWatcherRule(r.metric.SelectedIndex(), r.operator.SelectedIndex(), r.value.GetFloat())
+	if rule.Metric == JC.WATCHER_METRIC_CHANGE_WINDOW {
+		rule.Window = r.window.GetInt()
+	}
+
+	return rule
+}
+
+func (r *watcherRuleRow) Validate() error {
+	if err := r.value.Validate(); err != nil {
+		return err
+	}
+
+	if r.metric.SelectedIndex() == JC.WATCHER_METRIC_CHANGE_WINDOW {
+		return r.window.Validate()
+	}
+
+	return nil
+}
+
+func (r *watcherRuleRow) syncWindow() {
+	if r.metric.SelectedIndex() == JC.WATCHER_METRIC_CHANGE_WINDOW {
+		r.window.Show()
+	} else {
+		r.window.Hide()
+	}
+}
+
 func NewWatcherForm(
 	uuid string,
 	onSave func(pdt JT.PanelData),
@@ -33,45 +111,53 @@ func NewWatcherForm(
 			return nil
 		}
 		if len(s) == 0 {
-			return fmt.Errorf("This field is required")
+			return errors.New(JC.Translate("This field is required"))
 		}
 		val, err := strconv.Atoi(s)
 		if err != nil {
-			return fmt.Errorf("Must be an integer")
+			return errors.New(JC.Translate("Must be an integer"))
 		}
 		if val <= 0 {
-			return fmt.Errorf("Must be greater than 0")
+			return errors.New(JC.Translate("Must be greater than 0"))
 		}
 		return nil
 	}
 
-	validateFloat := func(s string) error {
+	validateWindow := func(s string) error {
+		if err := validateInt(s); err != nil || !allowValidation {
+			return err
+		}
+		if val, _ := strconv.Atoi(s); time.Duration(val)*time.Minute > JT.ExchangeHistoryWindow {
+			return errors.New(JC.Translate("Must be one day or less"))
+		}
+		return nil
+	}
+
+	validateFloat := func(s string, allowNegative bool) error {
 		if !allowValidation {
 			return nil
 		}
 		if len(s) == 0 {
-			return fmt.Errorf("This field is required")
+			return errors.New(JC.Translate("This field is required"))
 		}
 		val, err := strconv.ParseFloat(s, 64)
 		if err != nil {
-			return fmt.Errorf("Must be a number")
+			return errors.New(JC.Translate("Must be a number"))
 		}
-		if val <= 0 {
-			return fmt.Errorf("Must be greater than 0")
+		if !allowNegative && val <= 0 {
+			return errors.New(JC.Translate("Must be greater than 0"))
 		}
 		return nil
 	}
 
-	re := JW.NewNumericalEntry(true)
 	le := JW.NewNumericalEntry(false)
 	de := JW.NewNumericalEntry(false)
-	oe := JW.NewRadioEntry(map[int]string{
-		0: "Equal",
-		1: "Less Than",
-		2: "Greater Than",
+	ge := JW.NewRadioEntry(map[int]string{
+		JC.WATCHER_LOGIC_AND: JC.Translate("All rules match"),
+		JC.WATCHER_LOGIC_OR:  JC.Translate("Any rule matches"),
 	}, func(s string) {})
 
-	title := "Adding New Watcher"
+	title := JC.Translate("Adding New Watcher")
 
 	pdt := JT.UsePanelMaps().GetDataByID(uuid)
 	wk := pdt.UseWatcherKey()
@@ -89,58 +175,159 @@ func NewWatcherForm(
 
 		}
 
-		title = "Editing Watcher"
+		title = JC.Translate("Editing Watcher")
 	}
 
-	oe.SetDefaultValue(wk.GetOperator())
-	re.SetDefaultValue(strconv.FormatFloat(wk.GetRate(), 'f', -1, 64))
+	rows := []*watcherRuleRow{}
+	rulesBox := container.NewVBox()
+
+	var addRule func(rule JT.WatcherRule)
+	var addBtn JW.ActionButton
+
+	removeRule := func(row *watcherRuleRow) {
+		if len(rows) <= 1 {
+			return
+		}
+
+		for i, r := range rows {
+			if r == row {
+				rows = append(rows[:i], rows[i+1:]...)
+				break
+			}
+		}
+
+		rulesBox.Remove(row.container)
+		row.remove.Destroy()
+
+		parent.Refresh()
+	}
+
+	addRule = func(rule JT.WatcherRule) {
+		row := &watcherRuleRow{}
+
+		ve := JW.NewNumericalEntry(true)
+		ve.SetDefaultValue(strconv.FormatFloat(rule.Value, 'f', -1, 64))
+		ve.Validator = func(s string) error {
+			return validateFloat(s, row.metric.SelectedIndex() != JC.WATCHER_METRIC_RATE)
+		}
+
+		we := JW.NewNumericalEntry(false)
+		we.SetPlaceHolder(JC.Translate("Minutes"))
+		if rule.Window > 0 {
+			we.SetDefaultValue(strconv.Itoa(rule.Window))
+		}
+		we.Validator = validateWindow
+
+		row.value = ve
+		row.window = we
+		row.operator = widget.NewSelect(JC.TranslateAll(watcherOperatorOptions), nil)
+		row.operator.SetSelectedIndex(rule.Operator)
+		row.metric = widget.NewSelect(JC.TranslateAll(watcherMetricOptions), func(s string) {
+			ve.SetAllowNegative(row.metric.SelectedIndex() != JC.WATCHER_METRIC_RATE)
+			row.syncWindow()
+			if parent != nil {
+				parent.Refresh()
+			}
+		})
+		row.metric.SetSelectedIndex(rule.Metric)
+		row.syncWindow()
+
+		row.remove = JW.NewActionButton(
+			"remove_watcher_rule",
+			JC.STRING_EMPTY,
+			theme.DeleteIcon(),
+			JC.Translate("Remove Rule"),
+			JW.ActionStateNormal,
+			func(JW.ActionButton) {
+				removeRule(row)
+			},
+			nil,
+		)
+
+		row.container = container.NewBorder(nil, nil, nil, row.remove,
+			container.NewVBox(
+				container.NewGridWithColumns(3, row.metric, row.operator, row.value),
+				row.window,
+			))
+
+		rows = append(rows, row)
+		rulesBox.Add(row.container)
+	}
+
+	for _, rule := range wk.GetRules() {
+		addRule(rule)
+	}
+
+	ge.SetDefaultValue(wk.GetLogic())
 	le.SetDefaultValue(strconv.Itoa(limit))
 	de.SetDefaultValue(strconv.Itoa(wk.GetDuration()))
 
+	addBtn = JW.NewActionButton(
+		"add_watcher_rule",
+		JC.Translate("Add Rule"),
+		theme.ContentAddIcon(),
+		JC.Translate("Add another rule"),
+		JW.ActionStateNormal,
+		func(JW.ActionButton) {
+			addRule(JT.NewWatcherRule(JC.WATCHER_METRIC_RATE, JC.WATCHER_OPERATOR_GREATER, 1.0))
+			parent.Refresh()
+		},
+		nil,
+	)
+
+	disableFields := func() {
+		ge.Disable()
+		le.Disable()
+		de.Disable()
+		addBtn.Disable()
+		for _, row := range rows {
+			row.Disable()
+		}
+	}
+
+	enableFields := func() {
+		ge.Enable()
+		le.Enable()
+		de.Enable()
+		addBtn.Enable()
+		for _, row := range rows {
+			row.Enable()
+		}
+	}
+
 	var bannerBox = container.NewVBox()
 	if isDisabled {
 		bannerBox.Add(JW.NewBanner(
-			"This watcher is disabled.",
+			JC.Translate("This watcher is disabled."),
 			JW.BannerDanger))
 
-		oe.Disable()
-		re.Disable()
-		le.Disable()
-		de.Disable()
+		disableFields()
 
 	} else if sent > limit {
 		bannerBox.Add(JW.NewBanner(
-			"This watcher reached its limit.",
+			JC.Translate("This watcher reached its limit."),
 			JW.BannerWarning))
 	} else {
 		bannerBox.RemoveAll()
 	}
 
-	re.Validator = validateFloat
 	le.Validator = validateInt
 	de.Validator = validateInt
 
-	spacer := canvas.NewRectangle(nil)
-	spacer.SetMinSize(fyne.NewSize(10, 10))
-
-	// Working with glitch. When disabled Label wont mutate to muted.
-	// duration := container.NewBorder(nil, spacer, nil, widget.NewLabel("Minutes"), de)
-	// ops := container.NewBorder(oe, spacer, nil, nil)
-
 	fi := []*widget.FormItem{
-		widget.NewFormItem("Operator", oe),
-		widget.NewFormItem("Rate", re),
-		widget.NewFormItem("Limit", le),
-		widget.NewFormItem("Duration", de),
+		widget.NewFormItem(JC.Translate("Match"), ge),
+		widget.NewFormItem(JC.Translate("Rules"), container.NewVBox(rulesBox, container.NewHBox(addBtn))),
+		widget.NewFormItem(JC.Translate("Limit"), le),
+		widget.NewFormItem(JC.Translate("Duration"), de),
 	}
 
 	var label string
 	var state string
 	if isDisabled {
-		label = "Enable"
+		label = JC.Translate("Enable")
 		state = JW.ActionStateActive
 	} else {
-		label = "Disable"
+		label = JC.Translate("Disable")
 		state = JW.ActionStateError
 	}
 
@@ -148,17 +335,14 @@ func NewWatcherForm(
 		"disable_watcher",
 		label,
 		theme.MediaStopIcon(),
-		"Disable Watcher",
+		JC.Translate("Disable Watcher"),
 		state,
 		func(btn JW.ActionButton) {
 			if !isDisabled {
 
-				oe.Disable()
-				re.Disable()
-				le.Disable()
-				de.Disable()
+				disableFields()
 
-				btn.SetText("Enable")
+				btn.SetText(JC.Translate("Enable"))
 				btn.Active()
 
 				isDisabled = true
@@ -166,18 +350,15 @@ func NewWatcherForm(
 
 				bannerBox.RemoveAll()
 				bannerBox.Add(JW.NewBanner(
-					"This watcher is disabled.",
+					JC.Translate("This watcher is disabled."),
 					JW.BannerDanger,
 				))
 
 			} else {
 
-				oe.Enable()
-				re.Enable()
-				le.Enable()
-				de.Enable()
+				enableFields()
 
-				btn.SetText("Disable")
+				btn.SetText(JC.Translate("Disable"))
 				btn.Error()
 
 				isDisabled = false
@@ -201,12 +382,27 @@ func NewWatcherForm(
 			}
 
 			hasError := false
-			if re.Validate() != nil ||
-				le.Validate() != nil ||
+			if le.Validate() != nil ||
 				de.Validate() != nil {
 				hasError = true
 			}
 
+			rules := []JT.WatcherRule{}
+			for _, row := range rows {
+				if row.Validate() != nil {
+					hasError = true
+					continue
+				}
+
+				rule := row.GetRule()
+				if !isDisabled && !rule.IsValid() {
+					hasError = true
+					continue
+				}
+
+				rules = append(rules, rule)
+			}
+
 			if hasError {
 				return false
 			}
@@ -217,10 +413,10 @@ func NewWatcherForm(
 
 			pdt := JT.UsePanelMaps().GetDataByID(uuid)
 			wk := JT.NewWatcherKey()
-			pdt.SetWatcherKey(wk.GenerateKeyFromArgs(
+			pdt.SetWatcherKey(wk.GenerateKeyFromRules(
 				sent,
-				oe.GetInt(),
-				re.GetFloat(),
+				ge.GetInt(),
+				rules,
 				le.GetInt(),
 				de.GetInt(),
 				0,
@@ -237,6 +433,12 @@ func NewWatcherForm(
 		},
 		onRender,
 		func(layer *fyne.Container) {
+			for _, row := range rows {
+				row.remove.Destroy()
+			}
+
+			addBtn.Destroy()
+
 			if onDestroy != nil {
 				onDestroy(layer)
 			}
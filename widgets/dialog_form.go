@@ -138,11 +138,19 @@ func NewDialogForm(
 
 	fd.form.Orientation = widget.Vertical
 
+	// Forms without a callback only display data and need nothing but a way out
+	readOnly := callback == nil
+
+	cancelLabel := JC.Translate("Cancel")
+	if readOnly {
+		cancelLabel = JC.Translate("Close")
+	}
+
 	fd.cancel = NewActionButton(
 		"cancel_save_panel",
-		"Cancel",
+		cancelLabel,
 		theme.CancelIcon(),
-		"Close Form",
+		JC.Translate("Close Form"),
 		ActionStateNormal,
 		func(ActionButton) {
 			fd.Hide()
@@ -152,9 +160,9 @@ func NewDialogForm(
 
 	fd.confirm = NewActionButton(
 		"save_panel",
-		"Save",
+		JC.Translate("Save"),
 		theme.ConfirmIcon(),
-		"Save and Close Form",
+		JC.Translate("Save and Close Form"),
 		ActionStateNormal,
 		func(ActionButton) {
 			fd.Submit()
@@ -176,7 +184,11 @@ func NewDialogForm(
 		objs = append(objs, customAction, spacer)
 	}
 
-	objs = append(objs, fd.cancel, spacer, fd.confirm)
+	if readOnly {
+		objs = append(objs, fd.cancel)
+	} else {
+		objs = append(objs, fd.cancel, spacer, fd.confirm)
+	}
 
 	buttons := container.NewHBox(objs...)
 
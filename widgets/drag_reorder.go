@@ -0,0 +1,84 @@
+package widgets
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+
+	JC "jxwatcher/core"
+)
+
+type DropZone struct {
+	Top    float32
+	Left   float32
+	Bottom float32
+	Right  float32
+	UUID   string
+}
+
+func (z *DropZone) Contains(pos fyne.Position) bool {
+	return pos.X >= z.Left && pos.X <= z.Right && pos.Y >= z.Top && pos.Y <= z.Bottom
+}
+
+func NewDropZone(pos fyne.Position, size fyne.Size, uuid string) *DropZone {
+	return &DropZone{
+		Left:   pos.X,
+		Right:  pos.X + size.Width,
+		Top:    pos.Y,
+		Bottom: pos.Y + size.Height,
+		UUID:   uuid,
+	}
+}
+
+// Returns the zone index under the dragged position, -1 when it is outside every zone or on its own zone
+func FindDropIndex(zones []*DropZone, pos fyne.Position, uuid string, label string) int {
+
+	JC.Logln(fmt.Sprintf("Dragging %s - Position: (%.2f, %.2f)", label, pos.X, pos.Y))
+
+	for i, zone := range zones {
+		if !zone.Contains(pos) {
+			continue
+		}
+
+		if zone.UUID == uuid {
+			JC.Logln(fmt.Sprintf("Refusing to drop %s to the old position %d", label, i))
+			return -1
+		}
+
+		JC.Logln(fmt.Sprintf("Dropped inside %s %d", label, i))
+
+		return i
+	}
+
+	JC.Logln(fmt.Sprintf("Refuse to drop %s to invalid drop position", label))
+
+	return -1
+}
+
+// Returns a copy of objects with the item moved to the target index
+func MoveObject(objects []fyne.CanvasObject, item fyne.CanvasObject, targetIndex int) []fyne.CanvasObject {
+	result := make([]fyne.CanvasObject, 0, len(objects))
+	for _, obj := range objects {
+		if obj != item {
+			result = append(result, obj)
+		}
+	}
+
+	if targetIndex >= len(result) {
+		return append(result, item)
+	}
+
+	return append(result[:targetIndex], append([]fyne.CanvasObject{item}, result[targetIndex:]...)...)
+}
+
+// Collects the tags of the objects in their grid order
+func OrderedTags[T any](objects []fyne.CanvasObject, tag func(obj T) string) []string {
+	tags := []string{}
+	for _, obj := range objects {
+		if item, ok := obj.(T); ok {
+			tags = append(tags, tag(item))
+		}
+	}
+
+	return tags
+}
@@ -12,6 +12,7 @@ import (
 type numericalEntry struct {
 	widget.Entry
 	allow_decimals bool
+	allow_negative bool
 	action         func(active bool)
 }
 
@@ -38,6 +39,8 @@ func (e *numericalEntry) TypedRune(r rune) {
 		e.Entry.TypedRune(r)
 	} else if e.allow_decimals && r == '.' && !strings.Contains(e.Text, ".") {
 		e.Entry.TypedRune(r)
+	} else if e.allow_negative && r == '-' && e.CursorColumn == 0 && !strings.HasPrefix(e.Text, "-") {
+		e.Entry.TypedRune(r)
 	}
 }
 
@@ -61,6 +64,10 @@ func (e *numericalEntry) SetDefaultValue(s string) {
 	e.Text = s
 }
 
+func (e *numericalEntry) SetAllowNegative(allow bool) {
+	e.allow_negative = allow
+}
+
 func (e *numericalEntry) SetValidator(fn func(string) error) {
 	e.Validator = fn
 }
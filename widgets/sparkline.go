@@ -0,0 +1,131 @@
+package widgets
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/widget"
+
+	JC "jxwatcher/core"
+)
+
+type Sparkline struct {
+	widget.BaseWidget
+	values []float64
+	color  color.Color
+	stroke float32
+	cSize  fyne.Size
+	img    *canvas.Image
+}
+
+func (p *Sparkline) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(p.img)
+}
+
+func (p *Sparkline) MinSize() fyne.Size {
+	return fyne.NewSize(0, 0)
+}
+
+func (p *Sparkline) Visible() bool {
+	return p.BaseWidget.Visible() && len(p.values) > 1
+}
+
+func (p *Sparkline) Resize(size fyne.Size) {
+	p.BaseWidget.Resize(size)
+
+	if p.cSize == size {
+		return
+	}
+
+	p.cSize = size
+	p.rasterize()
+}
+
+func (p *Sparkline) SetValues(values []float64) {
+	if slices.Equal(p.values, values) {
+		return
+	}
+
+	p.values = values
+
+	if len(p.values) > 1 {
+		p.Show()
+	} else {
+		p.Hide()
+	}
+
+	p.rasterize()
+}
+
+func (p *Sparkline) SetColor(col color.Color) {
+	if p.color == col {
+		return
+	}
+
+	p.color = col
+	p.rasterize()
+}
+
+func (p *Sparkline) Destroy() {
+	if p == nil {
+		return
+	}
+
+	if p.img != nil {
+		if p.img.Image != nil {
+			p.img.Image = nil
+		}
+		p.img = nil
+	}
+
+	p.values = nil
+	p.color = nil
+	p.cSize = fyne.Size{}
+
+	p.ExtendBaseWidget(nil)
+}
+
+func (p *Sparkline) rasterize() {
+
+	if p.img == nil || p.color == nil {
+		return
+	}
+
+	scale := float32(1)
+	if JC.Window != nil {
+		scale = JC.Window.Canvas().Scale()
+	}
+
+	width := int(math.Round(float64(p.cSize.Width * scale)))
+	height := int(math.Round(float64(p.cSize.Height * scale)))
+
+	current, _ := p.img.Image.(*image.NRGBA)
+	dst := JC.RasterizeSparkline(current, p.values, width, height, p.stroke*scale, p.color)
+	if dst == nil {
+		return
+	}
+
+	p.img.Image = dst
+	p.img.Resize(p.cSize)
+	p.img.Refresh()
+}
+
+func NewSparkline(col color.Color, stroke float32) *Sparkline {
+	s := &Sparkline{
+		color:  col,
+		stroke: stroke,
+		img:    canvas.NewImageFromImage(image.NewNRGBA(image.Rect(0, 0, 0, 0))),
+	}
+
+	s.img.FillMode = canvas.ImageFillStretch
+	s.img.ScaleMode = canvas.ImageScaleSmooth
+
+	s.ExtendBaseWidget(s)
+	s.Hide()
+
+	return s
+}
@@ -50,3 +50,10 @@ func NewTextEntry() *textEntry {
 	entry.ExtendBaseWidget(entry)
 	return entry
 }
+
+func NewPasswordEntry() *textEntry {
+	entry := &textEntry{}
+	entry.Password = true
+	entry.ExtendBaseWidget(entry)
+	return entry
+}